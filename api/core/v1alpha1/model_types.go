@@ -18,27 +18,46 @@ package v1alpha1
 
 import (
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const (
 	ModelFamilyNameLabelKey = "llmaz.io/model-family-name"
 	ModelNameLabelKey       = "llmaz.io/model-name"
-	// Annotation with value = "true" represents we'll preload the model,
-	// by default via Manta(https://github.com/InftyAI/Manta), make sure
-	// Manta is installed in prior.
-	// Note: right now, we only support preloading models from Huggingface,
-	// in the future, more hubs and objstores will also be supported.
+	// ModelCacheLabelKey represents the name of the model whose cache the object belongs to,
+	// e.g. the PersistentVolumeClaims and the Jobs of the model cache.
+	ModelCacheLabelKey = "llmaz.io/model-cache"
+	// ModelPreheatedLabelKeyPrefix prefixes the node label with the model name, e.g.
+	// preheat.llmaz.io/llama3-8b, whose value is the hash of the model source preheated
+	// on the node. Workloads prefer the nodes with the label.
+	ModelPreheatedLabelKeyPrefix = "preheat.llmaz.io/"
+	// Annotation with value = "true" represents we'll preload the model into the host
+	// model path of the candidate nodes, which match the node selectors of the flavors,
+	// with a Job per node. The progress is reported in the status, and the workloads
+	// will be scheduled onto the preheated nodes first.
+	// Note: models from the host path or ollama are not supported, neither are the
+	// models cached in the PersistentVolume.
 	//
 	// We set this as an annotation rather than a field is just because preheating
-	// models is not a common sense and Manta is not a mature solution right now.
-	// Once either of them qualified, we'll expose this as a field in Model.
+	// models is not a common sense and occupies the disk of the nodes.
+	// Once qualified, we'll expose this as a field in Model.
 	ModelPreheatAnnoKey = "llmaz.io/model-preheat"
+	// ModelSizeAnnoKey represents the total size of the model weights, e.g. 16Gi,
+	// the startup probes synthesized from the backendRuntime will be scaled with it.
+	// It overrides the weight size discovered from the model source in the status.
+	ModelSizeAnnoKey = "llmaz.io/model-size"
 
 	// ModelActivatorAnnoKey is used to indicate the model name activated by the activator.
 	ModelActivatorAnnoKey = "activator.llmaz.io/model-name"
 	// CachedModelActivatorAnnoKey is used to cache the activator state of the model.
 	CachedModelActivatorAnnoKey = "activator.llmaz.io/cached-state"
+	// ModelActivatorIdleTimeoutAnnoKey is used to indicate how long the model should be idle
+	// before scaled to zero by the activator.
+	ModelActivatorIdleTimeoutAnnoKey = "activator.llmaz.io/idle-timeout"
+	// ModelActivatorColdStartTimeoutAnnoKey is used to indicate the maximum duration
+	// waiting for the model to be ready once activated.
+	ModelActivatorColdStartTimeoutAnnoKey = "activator.llmaz.io/cold-start-timeout"
 
 	HUGGING_FACE = "Huggingface"
 	MODEL_SCOPE  = "ModelScope"
@@ -73,6 +92,12 @@ type ModelHub struct {
 	// IgnorePatterns refers to files matched with any of the patterns will not be downloaded.
 	// +optional
 	IgnorePatterns []string `json:"ignorePatterns,omitempty"`
+	// PinRevision pins the revision to the commit it resolves to once the model is validated,
+	// so the workloads always load the same files even if the branch moves forward, and the
+	// downloaded files are verified against the checksums of the commit on the hub.
+	// Only supported with Huggingface.
+	// +optional
+	PinRevision *bool `json:"pinRevision,omitempty"`
 }
 
 // URIProtocol represents the protocol of the URI.
@@ -88,9 +113,40 @@ type ModelSource struct {
 	// - oss://<bucket>.<endpoint>/<path-to-your-model>
 	// - ollama://llama3.3
 	// - host://<path-to-your-model>
+	// - https://<host>/<path-to-your-model-file>#sha256=<checksum>, the checksum is optional
+	// - oci://<registry>/<repository>:<tag>, the model files are the layers of the OCI artifact
+	// - pvc://<claim-name>/<path-to-your-model>, the claim should exist in the namespace of the workloads
+	// - nfs://<server>/<path-to-your-model>
+	// - azblob://<account>/<container>/<path-to-your-model>
+	// - abfss://<container>@<account>.dfs.core.windows.net/<path-to-your-model>
 	//
 	// +optional
 	URI *URIProtocol `json:"uri,omitempty"`
+	// SecretRef refers to the Secret storing the credentials to access the model source,
+	// rather than the default ones like modelhub-secret or aws-access-secret.
+	// +optional
+	SecretRef *SecretReference `json:"secretRef,omitempty"`
+	// Checksums maps the paths of the model files relative to the model directory, or the
+	// file name for the single file models like GGUF, to the expected sha256 checksums.
+	// The files are verified by the model loader once downloaded, and the model loader
+	// fails once any of them is missing or mismatched.
+	// Not supported with host path, pvc, nfs and ollama models, which are not downloaded.
+	// +optional
+	Checksums map[string]string `json:"checksums,omitempty"`
+}
+
+// SecretReference refers to the Secret storing the credentials of the model source.
+type SecretReference struct {
+	// Name of the Secret, which is required to exist in the namespaces of the workloads.
+	// The cluster scoped OpenModel also requires it in the llmaz-system namespace to validate the source,
+	// otherwise the model fails with SecretNotFound.
+	Name string `json:"name"`
+	// Keys maps the credential names to the keys in the Secret, e.g. HF_TOKEN: token,
+	// the credential names are the same as the keys of the default secrets.
+	// Others are read from the Secret with the same names, all required except the optional
+	// ones like AWS_SESSION_TOKEN unless mapped here, and the Azure ones are alternatives.
+	// +optional
+	Keys map[string]string `json:"keys,omitempty"`
 }
 
 type FlavorName string
@@ -121,9 +177,35 @@ type Flavor struct {
 	// for autoscaling or be defined as model parallelism parameters like TP or PP size.
 	// E.g. with autoscaling, when scaling up nodes with 8x Nvidia A00, the parameter can be injected
 	// with <INSTANCE-TYPE: p4d.24xlarge> for AWS.
-	// Preset parameters: TP, PP, INSTANCE-TYPE.
+	// Preset parameters: TP, PP, INSTANCE-TYPE, DO-NOT-DISRUPT, ACCELERATOR-MEMORY.
+	// ACCELERATOR-MEMORY represents the memory of each accelerator like 80Gi, which is used
+	// to recommend the flavors fitting the model.
+	// Parameters will be translated to the Pod placements as below:
+	// - INSTANCE-TYPE: required node affinity of node.kubernetes.io/instance-type,
+	//   multiple instance types can be separated by comma.
+	// - DO-NOT-DISRUPT: the karpenter.sh/do-not-disrupt annotation of the Pod if "true".
+	// - Keys with a prefix, like karpenter.sh/capacity-type: required node affinity of the
+	//   node label, values can be separated by comma.
 	// +optional
 	Params map[string]string `json:"params,omitempty"`
+	// Tolerations represents the tolerations of the Pod for the tainted accelerator nodes,
+	// they'll be appended to the Pod tolerations.
+	// +optional
+	Tolerations []v1.Toleration `json:"tolerations,omitempty"`
+	// Affinity represents the scheduling constraints of the Pod, e.g. preferring some
+	// node pools. Required node affinity terms will be ANDed with the Pod ones,
+	// others will be appended to the Pod affinity.
+	// +optional
+	Affinity *v1.Affinity `json:"affinity,omitempty"`
+	// TopologySpreadConstraints represents how the Pods spread across topology domains,
+	// they'll be appended to the Pod topologySpreadConstraints.
+	// +optional
+	TopologySpreadConstraints []v1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+	// Args represents the flavor specific arguments of the inference engine, e.g. the
+	// tensor-parallel-size varies from accelerators. They're merged into the backend args by
+	// the flag names, replacing the backend ones, except for the shell commands like sh -c.
+	// +optional
+	Args []string `json:"args,omitempty"`
 }
 
 // InferenceConfig represents the inference configurations for the model.
@@ -158,19 +240,22 @@ const (
 	// DraftRole represents the draft model in speculative decoding,
 	// the main model is the target model then.
 	DraftRole ModelRole = "draft"
-	// LoraRole represents the lora model.
+	// LoraRole represents a LoRA adapter served on top of the main model,
+	// any number of lora models are allowed.
 	LoraRole ModelRole = "lora"
 )
 
 // ModelRef refers to a created Model with it's role.
 type ModelRef struct {
-	// Name represents the model name.
+	// Name represents the model name. The Model in the namespace of the referrer
+	// is resolved first, then the cluster scoped OpenModel with the same name.
 	Name ModelName `json:"name"`
 	// Role represents the model role once more than one model is required.
 	// Such as a draft role, which means running with SpeculativeDecoding,
 	// and default arguments for backend will be searched in backendRuntime
-	// with the name of speculative-decoding.
-	// +kubebuilder:validation:Enum={main,draft}
+	// with the name of speculative-decoding, or a lora role, which means
+	// the model is served as a LoRA adapter of the main model.
+	// +kubebuilder:validation:Enum={main,draft,lora}
 	// +kubebuilder:default=main
 	// +optional
 	Role *ModelRole `json:"role,omitempty"`
@@ -192,6 +277,39 @@ type ModelClaims struct {
 	InferenceFlavors []FlavorName `json:"inferenceFlavors,omitempty"`
 }
 
+type ModelCacheType string
+
+const (
+	// PersistentVolumeModelCache downloads the model once into a ReadWriteMany
+	// PersistentVolumeClaim with a Job, which is mounted by the workloads read-only.
+	PersistentVolumeModelCache ModelCacheType = "PersistentVolume"
+	// HostPathModelCache downloads the model into the host path of each node
+	// once by the first Pod scheduled to the node.
+	HostPathModelCache ModelCacheType = "HostPath"
+)
+
+// ModelCache represents the cache of the model shared by the workloads, to avoid
+// downloading the model for every replica and every restart.
+type ModelCache struct {
+	// Type represents the type of the cache.
+	// +kubebuilder:default=PersistentVolume
+	// +kubebuilder:validation:Enum={PersistentVolume,HostPath}
+	// +optional
+	Type ModelCacheType `json:"type,omitempty"`
+	// StorageClassName represents the storage class of the PersistentVolumeClaim, which
+	// should support ReadWriteMany. Default to the default storage class of the cluster.
+	// The volume is cloned for the workloads of other namespaces with the same volume handle, so only
+	// the storages tolerating the shared handles are supported, like NFS and the CSI drivers of
+	// the shared file systems, e.g. CephFS, EFS and Filestore.
+	// Only works with the PersistentVolume type.
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+	// Size represents the storage size of the PersistentVolumeClaim, default to 120%
+	// of the weight size discovered in the status. Only works with the PersistentVolume type.
+	// +optional
+	Size *resource.Quantity `json:"size,omitempty"`
+}
+
 // ModelSpec defines the desired state of Model
 type ModelSpec struct {
 	// FamilyName represents the model type, like llama2, which will be auto injected
@@ -214,19 +332,119 @@ type ModelSpec struct {
 	// +optional
 	// +kubebuilder:validation:Format=date-time
 	CreatedAt *metav1.Time `json:"createdAt,omitempty"`
+	// Cache represents the cache of the model shared by the workloads, the model
+	// will be downloaded by every Pod if not set.
+	// +optional
+	Cache *ModelCache `json:"cache,omitempty"`
 }
 
 const (
-	// ModelPending means model is waiting for model downloading.
+	// ModelPending means the model source is being validated, or failed to
+	// be validated temporarily, e.g. the model hub is unreachable.
 	ModelPending = "Pending"
-	// ModelReady means model is already downloaded.
+	// ModelReady means the model source is validated and ready to be served.
 	ModelReady = "Ready"
+	// ModelFailed means the model source is unavailable, e.g. the model is not
+	// found or the credentials are missing, it'll be validated again periodically.
+	ModelFailed = "Failed"
 )
 
+// ModelMetadata represents the metadata discovered from the model source.
+type ModelMetadata struct {
+	// ParameterCount represents the number of the model parameters.
+	// +optional
+	ParameterCount *int64 `json:"parameterCount,omitempty"`
+	// Architecture represents the model architecture, e.g. LlamaForCausalLM, or llama for GGUF models.
+	// +optional
+	Architecture string `json:"architecture,omitempty"`
+	// ContextLength represents the maximum context length supported by the model.
+	// +optional
+	ContextLength *int64 `json:"contextLength,omitempty"`
+	// DType represents the data type of the weights, e.g. bfloat16, or Q4_K_M for GGUF models.
+	// +optional
+	DType string `json:"dtype,omitempty"`
+	// WeightSize represents the total size of the weight files.
+	// +optional
+	WeightSize *resource.Quantity `json:"weightSize,omitempty"`
+	// Files represents the files of the model, at most 100 files will be recorded.
+	// +kubebuilder:validation:MaxItems=100
+	// +optional
+	Files []string `json:"files,omitempty"`
+	// Tokenizer represents whether the tokenizer is shipped with the model.
+	// +optional
+	Tokenizer *bool `json:"tokenizer,omitempty"`
+}
+
+type ModelCachePhase string
+
+const (
+	// ModelCachePending means the cache is waiting for the model source to be validated
+	// or the storage to be provisioned.
+	ModelCachePending ModelCachePhase = "Pending"
+	// ModelCacheDownloading means the model is being downloaded into the cache.
+	ModelCacheDownloading ModelCachePhase = "Downloading"
+	// ModelCacheReady means the cache is ready to be mounted by the workloads.
+	ModelCacheReady ModelCachePhase = "Ready"
+	// ModelCacheFailed means the model failed to be downloaded into the cache.
+	ModelCacheFailed ModelCachePhase = "Failed"
+)
+
+// ModelCacheStatus represents the observed state of the model cache.
+type ModelCacheStatus struct {
+	// Phase represents the phase of the cache.
+	Phase ModelCachePhase `json:"phase"`
+	// ClaimName represents the name of the PersistentVolumeClaim storing the model
+	// in the namespace of llmaz, only set with the PersistentVolume type.
+	// +optional
+	ClaimName string `json:"claimName,omitempty"`
+	// Path represents the host path storing the model, only set with the HostPath type.
+	// +optional
+	Path string `json:"path,omitempty"`
+	// Message represents the human readable details of the phase.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// NodePreheatStatus represents the preheat progress of the model on the node.
+type NodePreheatStatus struct {
+	// NodeName represents the name of the candidate node.
+	NodeName string `json:"nodeName"`
+	// Phase represents the phase of preheating on the node.
+	Phase ModelCachePhase `json:"phase"`
+	// Message represents the human readable details of the phase.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// PinnedRevision represents the commit the revision of the model hub resolves to.
+type PinnedRevision struct {
+	// Revision represents the revision of the model hub, e.g. main.
+	Revision string `json:"revision"`
+	// Commit represents the commit SHA the revision resolved to.
+	Commit string `json:"commit"`
+}
+
 // ModelStatus defines the observed state of Model
 type ModelStatus struct {
 	// Conditions represents the Inference condition.
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Metadata represents the metadata discovered from the model source once the
+	// source is validated, nil if not discovered yet or not supported by the source.
+	// +optional
+	Metadata *ModelMetadata `json:"metadata,omitempty"`
+	// Cache represents the status of the model cache, nil if the cache is not enabled.
+	// +optional
+	Cache *ModelCacheStatus `json:"cache,omitempty"`
+	// PinnedRevision represents the commit the revision of the model hub is pinned to,
+	// nil if the revision is not pinned or not resolved yet.
+	// +optional
+	PinnedRevision *PinnedRevision `json:"pinnedRevision,omitempty"`
+	// Preheat represents the preheat progress on the candidate nodes, nil if
+	// the preheat is not enabled.
+	// +listType=map
+	// +listMapKey=nodeName
+	// +optional
+	Preheat []NodePreheatStatus `json:"preheat,omitempty"`
 }
 
 //+genclient
@@ -257,6 +475,37 @@ type OpenModelList struct {
 	Items           []OpenModel `json:"items"`
 }
 
+//+genclient
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:shortName=mo
+//+kubebuilder:printcolumn:name="OWNEDBY",type=string,JSONPath=`.spec.ownedBy`,description="Owner of the model"
+//+kubebuilder:printcolumn:name="AGE",type=date,JSONPath=`.metadata.creationTimestamp`,description="Time since creation"
+//+kubebuilder:printcolumn:name="MODELHUB",type=string,JSONPath=`.spec.source.modelHub.name`,description="Model hub name"
+//+kubebuilder:printcolumn:name="MODELID",type=string,JSONPath=`.spec.source.modelHub.modelID`,description="Model ID on the model hub"
+//+kubebuilder:printcolumn:name="URI",type=string,JSONPath=`.spec.source.uri`,description="URI of the model when using a custom source (e.g., s3://, ollama://)"
+
+// Model is the Schema for the namespaced models API, which is only visible to
+// the Playgrounds and Services in the same namespace, e.g. the private fine-tunes
+// of a team. It takes precedence over the OpenModel with the same name.
+// Cache and preheat are not supported for namespaced models.
+type Model struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ModelSpec   `json:"spec,omitempty"`
+	Status ModelStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ModelList contains a list of Model
+type ModelList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Model `json:"items"`
+}
+
 func init() {
-	SchemeBuilder.Register(&OpenModel{}, &OpenModelList{})
+	SchemeBuilder.Register(&OpenModel{}, &OpenModelList{}, &Model{}, &ModelList{})
 }
@@ -21,6 +21,8 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	coreapi "github.com/inftyai/llmaz/api/core/v1alpha1"
 )
 
 // HPATrigger represents the configuration of the HorizontalPodAutoscaler.
@@ -54,9 +56,24 @@ type ScaleTrigger struct {
 // user can choose one of them to apply.
 type RecommendedConfig struct {
 	// Name represents the identifier of the config.
+	// Several configs could share the same name with disjoint flavors or modelFamilies,
+	// the most specific one matching the applied flavor and the model family will be chosen,
+	// flavors take precedence over modelFamilies, fallback to the one with neither of them.
 	Name string `json:"name"`
+	// Flavors represents the inference flavors this config applies to, e.g. a100.
+	// Empty means all the flavors.
+	// +optional
+	Flavors []coreapi.FlavorName `json:"flavors,omitempty"`
+	// ModelFamilies represents the model families this config applies to, e.g. llama3.
+	// Empty means all the model families.
+	// +optional
+	ModelFamilies []coreapi.ModelName `json:"modelFamilies,omitempty"`
 	// Args represents all the arguments for the command.
-	// Argument around with {{ .CONFIG }} is a configuration waiting for render.
+	// Arguments are rendered as Go text/template, e.g. {{ .ModelPath }}, the available
+	// data includes ModelName, ModelPath, DraftModelPath, LoraModules, Models (grouped by role),
+	// Flavor, AcceleratorCount, Replicas, Size, LeaderAddress, WorkerIndex and Namespace, together with
+	// helper functions default, add, sub, mul, div, max, min, join, lower, upper and trim,
+	// e.g. {{ .AcceleratorCount | default 1 }}.
 	// +optional
 	Args []string `json:"args,omitempty"`
 	// Resources represents the resource requirements for backend, like cpu/mem,
@@ -72,6 +89,90 @@ type RecommendedConfig struct {
 	// Only one trigger cloud work at a time.
 	// +optional
 	ScaleTrigger *ScaleTrigger `json:"scaleTrigger,omitempty"`
+	// MultiNode represents the configurations of multi-node inference, each workload
+	// consists of a leader pod and one or more worker pods, e.g. Ray based vLLM.
+	// +optional
+	MultiNode *MultiNodeConfig `json:"multiNode,omitempty"`
+}
+
+// MultiNodeConfig represents the configurations of multi-node inference.
+type MultiNodeConfig struct {
+	// Size represents the number of pods in a workload, including the leader.
+	// It will be overwritten by the PP param of the applied flavor if set, as
+	// pipeline parallelism usually spans across nodes.
+	// +kubebuilder:default=2
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Size *int32 `json:"size,omitempty"`
+	// Leader represents the command and args of the leader pod.
+	// Default to the command of the backendRuntime and the args of the recommended config.
+	// +optional
+	Leader *NodeConfig `json:"leader,omitempty"`
+	// Worker represents the command and args of the worker pods.
+	// Default to the command of the backendRuntime and the args of the recommended config.
+	// +optional
+	Worker *NodeConfig `json:"worker,omitempty"`
+}
+
+// NodeConfig represents the command and args of a pod in multi-node inference.
+type NodeConfig struct {
+	// Command represents the command of the pod.
+	// +optional
+	Command []string `json:"command,omitempty"`
+	// Args represents the arguments of the pod, rendered the same as the args of the recommended config.
+	// +optional
+	Args []string `json:"args,omitempty"`
+}
+
+// DynamicLoRA represents the API of the backendRuntime to load and unload
+// LoRA adapters at runtime, e.g. vLLM's /v1/load_lora_adapter.
+type DynamicLoRA struct {
+	// LoadPath represents the HTTP path to load an adapter, requested with POST
+	// and a JSON body of {"lora_name": <name>, "lora_path": <path>}.
+	// +kubebuilder:default="/v1/load_lora_adapter"
+	// +optional
+	LoadPath string `json:"loadPath,omitempty"`
+	// UnloadPath represents the HTTP path to unload an adapter, requested with POST
+	// and a JSON body of {"lora_name": <name>}.
+	// +kubebuilder:default="/v1/unload_lora_adapter"
+	// +optional
+	UnloadPath string `json:"unloadPath,omitempty"`
+	// Envs represents the environments set to the container once dynamic loading
+	// is enabled, e.g. VLLM_ALLOW_RUNTIME_LORA_UPDATING for vLLM.
+	// +optional
+	Envs []corev1.EnvVar `json:"envs,omitempty"`
+}
+
+// BackendEndpoints represents the HTTP endpoints served by the backend on the container port.
+type BackendEndpoints struct {
+	// Health represents the path reporting the backend is alive, e.g. /health,
+	// used to synthesize the startup and liveness probes.
+	// +optional
+	Health *string `json:"health,omitempty"`
+	// Ready represents the path reporting the backend is able to serve requests,
+	// e.g. /health_generate, used to synthesize the readiness probe.
+	// Default to the health path.
+	// +optional
+	Ready *string `json:"ready,omitempty"`
+	// Metrics represents the path of the metrics in Prometheus text format, e.g. /metrics.
+	// +optional
+	Metrics *string `json:"metrics,omitempty"`
+}
+
+// GracefulShutdown represents how the backend drains the in-flight requests before stopping.
+type GracefulShutdown struct {
+	// DrainTimeoutSeconds represents the maximum seconds waiting for the in-flight requests
+	// to finish, the termination grace period of the pod will be 10 seconds longer.
+	// +kubebuilder:default=120
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	DrainTimeoutSeconds *int64 `json:"drainTimeoutSeconds,omitempty"`
+	// InflightRequestMetrics represents the metrics counting the running and waiting requests,
+	// e.g. vllm:num_requests_running, the synthesized preStop hook waits until the sum of them
+	// reaches zero. If empty, the metrics endpoint is not set or the image lacks curl, grep or awk,
+	// the hook sleeps for the whole drain timeout.
+	// +optional
+	InflightRequestMetrics []string `json:"inflightRequestMetrics,omitempty"`
 }
 
 // BackendRuntimeSpec defines the desired state of BackendRuntime
@@ -89,8 +190,17 @@ type BackendRuntimeSpec struct {
 	// +optional
 	Envs []corev1.EnvVar `json:"envs,omitempty"`
 	// Lifecycle represents hooks executed during the lifecycle of the container.
+	// If preStop is not set, a hook draining the in-flight requests will be synthesized
+	// from the gracefulShutdown and the endpoints.
 	// +optional
 	Lifecycle *corev1.Lifecycle `json:"lifecycle,omitempty"`
+	// Endpoints represents the HTTP endpoints served by the backend, the probes not specified
+	// will be synthesized from them.
+	// +optional
+	Endpoints *BackendEndpoints `json:"endpoints,omitempty"`
+	// GracefulShutdown represents how the backend drains the in-flight requests before stopping.
+	// +optional
+	GracefulShutdown *GracefulShutdown `json:"gracefulShutdown,omitempty"`
 	// Periodic probe of backend liveness.
 	// Backend will be restarted if the probe fails.
 	// Cannot be updated.
@@ -110,6 +220,10 @@ type BackendRuntimeSpec struct {
 	// RecommendedConfigs represents the recommended configurations for the backendRuntime.
 	// +optional
 	RecommendedConfigs []RecommendedConfig `json:"recommendedConfigs,omitempty"`
+	// DynamicLoRA represents the API to load LoRA adapters without restarting the backend,
+	// nil means dynamic loading is not supported by the backendRuntime.
+	// +optional
+	DynamicLoRA *DynamicLoRA `json:"dynamicLoRA,omitempty"`
 }
 
 // BackendRuntimeStatus defines the observed state of BackendRuntime
@@ -19,6 +19,7 @@ package v1alpha1
 import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type BackendName string
@@ -73,3 +74,23 @@ type ResourceRequirements struct {
 	// +optional
 	Requests corev1.ResourceList `json:"requests,omitempty"`
 }
+
+// ScaleToZero represents the activation policy of the model.
+type ScaleToZero struct {
+	// Enabled indicates whether to scale the workloads to zero when idle,
+	// and activate them once requests arrive.
+	// +kubebuilder:default=true
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// IdleTimeout represents how long the model should be idle, which means no
+	// running or waiting requests in the inference engine, before scaled to zero.
+	// If not set, the workloads will not be scaled to zero automatically, but
+	// will still be activated once scaled to zero by others, e.g. users.
+	// +optional
+	IdleTimeout *metav1.Duration `json:"idleTimeout,omitempty"`
+	// ColdStartTimeout represents the maximum duration waiting for the model
+	// to be ready once activated, requests buffered by the activator will
+	// be dropped after timeout. Default to 5m.
+	// +optional
+	ColdStartTimeout *metav1.Duration `json:"coldStartTimeout,omitempty"`
+}
@@ -18,6 +18,7 @@ package v1alpha1
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 
 	coreapi "github.com/inftyai/llmaz/api/core/v1alpha1"
 )
@@ -46,6 +47,19 @@ type PlaygroundSpec struct {
 	// e.g. the max/min replicas.
 	// +optional
 	ElasticConfig *ElasticConfig `json:"elasticConfig,omitempty"`
+	// LoRAConfig defines how the models with the lora role are served.
+	// +optional
+	LoRAConfig *LoRAConfig `json:"loraConfig,omitempty"`
+}
+
+type LoRAConfig struct {
+	// DynamicLoading represents whether to load the lora models into the running pods
+	// via the API of the backendRuntime rather than rendering them into the pod template,
+	// so adding or removing adapters will not trigger a rolling restart of the base model.
+	// The backendRuntime must support dynamic LoRA loading.
+	// +kubebuilder:default=false
+	// +optional
+	DynamicLoading *bool `json:"dynamicLoading,omitempty"`
 }
 
 type ElasticConfig struct {
@@ -65,6 +79,36 @@ type ElasticConfig struct {
 	// ScaleTrigger defined here will "overwrite" the scaleTrigger in the recommendedConfig.
 	// +optional
 	ScaleTrigger *ScaleTrigger `json:"scaleTrigger,omitempty"`
+	// ScaleToZero defines the activation policy of the model, once enabled, the workloads
+	// will be scaled to zero when idle and be activated by the service activator on demand.
+	// Requires the service activator enabled in the controller manager.
+	// +optional
+	ScaleToZero *ScaleToZero `json:"scaleToZero,omitempty"`
+	// WarmupSchedules defines the time windows to keep the model warmed up ahead of demand,
+	// during the window, the replicas will not be less than the scheduled ones, neither
+	// scaled down by the HPA nor scaled to zero by the activator.
+	// +optional
+	WarmupSchedules []WarmupSchedule `json:"warmupSchedules,omitempty"`
+}
+
+// WarmupSchedule represents a recurring time window to keep the model warmed up.
+type WarmupSchedule struct {
+	// Schedule is the cron expression in the standard 5-field format indicating
+	// the start of the window, e.g. "0 8 * * 1-5" means 08:00 on weekdays.
+	// +kubebuilder:validation:Required
+	Schedule string `json:"schedule"`
+	// TimeZone is the time zone name of the schedule, e.g. "Asia/Shanghai".
+	// Default to UTC.
+	// +optional
+	TimeZone *string `json:"timeZone,omitempty"`
+	// Duration represents how long the window lasts once started.
+	// +kubebuilder:validation:Required
+	Duration metav1.Duration `json:"duration"`
+	// Replicas represents the minimum number of inference workloads during the window.
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum:=1
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
 }
 
 const (
@@ -78,6 +122,46 @@ const (
 	SkipModelLoaderAnnoKey = "llmaz.io/skip-model-loader"
 )
 
+// LoRAAdapterState represents the state of a dynamically loaded LoRA adapter.
+type LoRAAdapterState string
+
+const (
+	// LoRAAdapterDownloading means the adapter weights are downloading into the model volume.
+	LoRAAdapterDownloading LoRAAdapterState = "Downloading"
+	// LoRAAdapterLoading means the adapter is being loaded by the backendRuntime.
+	LoRAAdapterLoading LoRAAdapterState = "Loading"
+	// LoRAAdapterLoaded means the adapter is loaded by the backendRuntime and ready to serve.
+	LoRAAdapterLoaded LoRAAdapterState = "Loaded"
+	// LoRAAdapterFailed means the adapter failed to download or load.
+	LoRAAdapterFailed LoRAAdapterState = "Failed"
+)
+
+// LoRAAdapterStatus represents the state of a LoRA adapter in a pod.
+type LoRAAdapterStatus struct {
+	// Name represents the name of the lora model.
+	Name coreapi.ModelName `json:"name"`
+	// State represents the state of the adapter.
+	State LoRAAdapterState `json:"state"`
+	// Message represents the details once failed.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// PodLoRAAdapters represents the dynamically loaded LoRA adapters of a pod.
+type PodLoRAAdapters struct {
+	// PodName represents the name of the pod.
+	PodName string `json:"podName"`
+	// PodUID represents the uid of the pod, adapters will be reloaded once the pod is recreated.
+	PodUID types.UID `json:"podUID"`
+	// RestartCount represents the restart count of the model runner container, adapters
+	// will be reloaded once the container restarts as well.
+	// +optional
+	RestartCount int32 `json:"restartCount,omitempty"`
+	// Adapters represents the state of the adapters in the pod.
+	// +optional
+	Adapters []LoRAAdapterStatus `json:"adapters,omitempty"`
+}
+
 // PlaygroundStatus defines the observed state of Playground
 type PlaygroundStatus struct {
 	// Conditions represents the Inference condition.
@@ -86,6 +170,28 @@ type PlaygroundStatus struct {
 	Replicas int32 `json:"replicas"`
 	// Selector points to the string form of a label selector which will be used by HPA.
 	Selector string `json:"selector,omitempty"`
+	// ObservedGeneration is the most recent generation observed by the controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Flavor represents the inference flavor applied to the workloads right now.
+	// +optional
+	Flavor coreapi.FlavorName `json:"flavor,omitempty"`
+	// BackendRuntimeName represents the name of the resolved backendRuntime.
+	// +optional
+	BackendRuntimeName BackendName `json:"backendRuntimeName,omitempty"`
+	// BackendRuntimeVersion represents the version of the resolved backendRuntime.
+	// +optional
+	BackendRuntimeVersion string `json:"backendRuntimeVersion,omitempty"`
+	// Image represents the image of the inference engine actually used.
+	// +optional
+	Image string `json:"image,omitempty"`
+	// ModelPath represents the path of the main model loaded by the inference engine.
+	// +optional
+	ModelPath string `json:"modelPath,omitempty"`
+	// LoRAAdapters represents the state of the dynamically loaded LoRA adapters per pod,
+	// only available once loraConfig.dynamicLoading is enabled.
+	// +optional
+	LoRAAdapters []PodLoRAAdapters `json:"loraAdapters,omitempty"`
 }
 
 //+genclient
@@ -97,6 +203,10 @@ type PlaygroundStatus struct {
 //+kubebuilder:printcolumn:name="REPLICAS",type=integer,JSONPath=`.status.replicas`,description="Current number of replicas"
 //+kubebuilder:printcolumn:name="STATUS",type=string,JSONPath=`.status.conditions[?(@.type=='Available')].reason`,description="Current status (Available/Progressing)"
 //+kubebuilder:printcolumn:name="AGE",type=date,JSONPath=`.metadata.creationTimestamp`,description="Time since creation"
+//+kubebuilder:printcolumn:name="FLAVOR",type=string,JSONPath=`.status.flavor`,description="Inference flavor applied",priority=1
+//+kubebuilder:printcolumn:name="BACKEND",type=string,JSONPath=`.status.backendRuntimeName`,description="BackendRuntime serving the model",priority=1
+//+kubebuilder:printcolumn:name="VERSION",type=string,JSONPath=`.status.backendRuntimeVersion`,description="Version of the BackendRuntime",priority=1
+//+kubebuilder:printcolumn:name="IMAGE",type=string,JSONPath=`.status.image`,description="Image of the inference engine",priority=1
 
 // Playground is the Schema for the playgrounds API
 type Playground struct {
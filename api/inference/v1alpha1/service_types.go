@@ -27,6 +27,15 @@ const (
 	// InferenceServiceFlavorsAnnoKey is the annotation key for the flavors specified
 	// in the inference service, the value is a comma-separated list of flavor names.
 	InferenceServiceFlavorsAnnoKey = "llmaz.io/inference-service-flavors"
+	// FlavorFallbackTimeoutAnnoKey is the annotation key for how long the pods could stay
+	// unschedulable before falling back to the next flavor, e.g. "10m", default to 5m.
+	FlavorFallbackTimeoutAnnoKey = "llmaz.io/flavor-fallback-timeout"
+	// BackendRuntimeNameAnnoKey is the annotation key for the name of the backendRuntime
+	// resolved by the Playground, which is reported in the Service status.
+	BackendRuntimeNameAnnoKey = "llmaz.io/backend-runtime-name"
+	// BackendRuntimeVersionAnnoKey is the annotation key for the version of the backendRuntime
+	// resolved by the Playground, which is reported in the Service status.
+	BackendRuntimeVersionAnnoKey = "llmaz.io/backend-runtime-version"
 )
 
 // ServiceSpec defines the desired state of Service.
@@ -46,6 +55,11 @@ type ServiceSpec struct {
 	// +kubebuilder:default:={type: "RollingUpdate", rollingUpdateConfiguration: {"maxUnavailable": 1, "maxSurge": 0}}
 	// +optional
 	RolloutStrategy *lws.RolloutStrategy `json:"rolloutStrategy,omitempty"`
+	// ScaleToZero defines the activation policy of the model, once enabled, the workloads
+	// will be scaled to zero when idle and be activated by the service activator on demand.
+	// Requires the service activator enabled in the controller manager.
+	// +optional
+	ScaleToZero *ScaleToZero `json:"scaleToZero,omitempty"`
 }
 
 const (
@@ -66,6 +80,30 @@ type ServiceStatus struct {
 	// Selector points to the string form of a label selector, the HPA will be
 	// able to autoscale your resource.
 	Selector string `json:"selector,omitempty"`
+	// Flavor represents the inference flavor applied to the workloads right now,
+	// it falls back to the next one in the preference list once the pods stay
+	// unschedulable for a period of time.
+	// +optional
+	Flavor coreapi.FlavorName `json:"flavor,omitempty"`
+	// FlavorTransitionTime represents the last time the flavor was changed.
+	// +optional
+	FlavorTransitionTime *metav1.Time `json:"flavorTransitionTime,omitempty"`
+	// ObservedGeneration is the most recent generation observed by the controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// BackendRuntimeName represents the name of the backendRuntime resolved by the Playground.
+	// +optional
+	BackendRuntimeName BackendName `json:"backendRuntimeName,omitempty"`
+	// BackendRuntimeVersion represents the version of the backendRuntime resolved by the Playground.
+	// +optional
+	BackendRuntimeVersion string `json:"backendRuntimeVersion,omitempty"`
+	// Image represents the image of the inference engine actually used, which is
+	// reported by the running serving pods.
+	// +optional
+	Image string `json:"image,omitempty"`
+	// ModelPath represents the path of the main model loaded by the inference engine.
+	// +optional
+	ModelPath string `json:"modelPath,omitempty"`
 }
 
 //+genclient
@@ -77,6 +115,10 @@ type ServiceStatus struct {
 //+kubebuilder:printcolumn:name="REPLICAS",type=integer,JSONPath=`.status.replicas`,description="Current number of replicas"
 //+kubebuilder:printcolumn:name="STATUS",type=string,JSONPath=`.status.conditions[?(@.type=='Available')].reason`,description="Current status (Available/Progressing)"
 //+kubebuilder:printcolumn:name="AGE",type=date,JSONPath=`.metadata.creationTimestamp`,description="Time since creation"
+//+kubebuilder:printcolumn:name="FLAVOR",type=string,JSONPath=`.status.flavor`,description="Inference flavor applied",priority=1
+//+kubebuilder:printcolumn:name="BACKEND",type=string,JSONPath=`.status.backendRuntimeName`,description="BackendRuntime serving the model",priority=1
+//+kubebuilder:printcolumn:name="VERSION",type=string,JSONPath=`.status.backendRuntimeVersion`,description="Version of the BackendRuntime",priority=1
+//+kubebuilder:printcolumn:name="IMAGE",type=string,JSONPath=`.status.image`,description="Image of the inference engine",priority=1
 
 // Service is the Schema for the services API
 type Service struct {
@@ -29,6 +29,36 @@ import (
 	leaderworkersetv1 "sigs.k8s.io/lws/api/leaderworkerset/v1"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackendEndpoints) DeepCopyInto(out *BackendEndpoints) {
+	*out = *in
+	if in.Health != nil {
+		in, out := &in.Health, &out.Health
+		*out = new(string)
+		**out = **in
+	}
+	if in.Ready != nil {
+		in, out := &in.Ready, &out.Ready
+		*out = new(string)
+		**out = **in
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendEndpoints.
+func (in *BackendEndpoints) DeepCopy() *BackendEndpoints {
+	if in == nil {
+		return nil
+	}
+	out := new(BackendEndpoints)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackendRuntime) DeepCopyInto(out *BackendRuntime) {
 	*out = *in
@@ -160,6 +190,16 @@ func (in *BackendRuntimeSpec) DeepCopyInto(out *BackendRuntimeSpec) {
 		*out = new(v1.Lifecycle)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = new(BackendEndpoints)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GracefulShutdown != nil {
+		in, out := &in.GracefulShutdown, &out.GracefulShutdown
+		*out = new(GracefulShutdown)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.LivenessProbe != nil {
 		in, out := &in.LivenessProbe, &out.LivenessProbe
 		*out = new(v1.Probe)
@@ -182,6 +222,11 @@ func (in *BackendRuntimeSpec) DeepCopyInto(out *BackendRuntimeSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DynamicLoRA != nil {
+		in, out := &in.DynamicLoRA, &out.DynamicLoRA
+		*out = new(DynamicLoRA)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendRuntimeSpec.
@@ -216,6 +261,28 @@ func (in *BackendRuntimeStatus) DeepCopy() *BackendRuntimeStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DynamicLoRA) DeepCopyInto(out *DynamicLoRA) {
+	*out = *in
+	if in.Envs != nil {
+		in, out := &in.Envs, &out.Envs
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DynamicLoRA.
+func (in *DynamicLoRA) DeepCopy() *DynamicLoRA {
+	if in == nil {
+		return nil
+	}
+	out := new(DynamicLoRA)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ElasticConfig) DeepCopyInto(out *ElasticConfig) {
 	*out = *in
@@ -229,6 +296,18 @@ func (in *ElasticConfig) DeepCopyInto(out *ElasticConfig) {
 		*out = new(ScaleTrigger)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ScaleToZero != nil {
+		in, out := &in.ScaleToZero, &out.ScaleToZero
+		*out = new(ScaleToZero)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WarmupSchedules != nil {
+		in, out := &in.WarmupSchedules, &out.WarmupSchedules
+		*out = make([]WarmupSchedule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticConfig.
@@ -241,6 +320,31 @@ func (in *ElasticConfig) DeepCopy() *ElasticConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GracefulShutdown) DeepCopyInto(out *GracefulShutdown) {
+	*out = *in
+	if in.DrainTimeoutSeconds != nil {
+		in, out := &in.DrainTimeoutSeconds, &out.DrainTimeoutSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.InflightRequestMetrics != nil {
+		in, out := &in.InflightRequestMetrics, &out.InflightRequestMetrics
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GracefulShutdown.
+func (in *GracefulShutdown) DeepCopy() *GracefulShutdown {
+	if in == nil {
+		return nil
+	}
+	out := new(GracefulShutdown)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HPATrigger) DeepCopyInto(out *HPATrigger) {
 	*out = *in
@@ -268,6 +372,96 @@ func (in *HPATrigger) DeepCopy() *HPATrigger {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoRAAdapterStatus) DeepCopyInto(out *LoRAAdapterStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoRAAdapterStatus.
+func (in *LoRAAdapterStatus) DeepCopy() *LoRAAdapterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LoRAAdapterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoRAConfig) DeepCopyInto(out *LoRAConfig) {
+	*out = *in
+	if in.DynamicLoading != nil {
+		in, out := &in.DynamicLoading, &out.DynamicLoading
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoRAConfig.
+func (in *LoRAConfig) DeepCopy() *LoRAConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(LoRAConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiNodeConfig) DeepCopyInto(out *MultiNodeConfig) {
+	*out = *in
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Leader != nil {
+		in, out := &in.Leader, &out.Leader
+		*out = new(NodeConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Worker != nil {
+		in, out := &in.Worker, &out.Worker
+		*out = new(NodeConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiNodeConfig.
+func (in *MultiNodeConfig) DeepCopy() *MultiNodeConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiNodeConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeConfig) DeepCopyInto(out *NodeConfig) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeConfig.
+func (in *NodeConfig) DeepCopy() *NodeConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Playground) DeepCopyInto(out *Playground) {
 	*out = *in
@@ -355,6 +549,11 @@ func (in *PlaygroundSpec) DeepCopyInto(out *PlaygroundSpec) {
 		*out = new(ElasticConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.LoRAConfig != nil {
+		in, out := &in.LoRAConfig, &out.LoRAConfig
+		*out = new(LoRAConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlaygroundSpec.
@@ -377,6 +576,13 @@ func (in *PlaygroundStatus) DeepCopyInto(out *PlaygroundStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.LoRAAdapters != nil {
+		in, out := &in.LoRAAdapters, &out.LoRAAdapters
+		*out = make([]PodLoRAAdapters, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlaygroundStatus.
@@ -389,9 +595,39 @@ func (in *PlaygroundStatus) DeepCopy() *PlaygroundStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodLoRAAdapters) DeepCopyInto(out *PodLoRAAdapters) {
+	*out = *in
+	if in.Adapters != nil {
+		in, out := &in.Adapters, &out.Adapters
+		*out = make([]LoRAAdapterStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodLoRAAdapters.
+func (in *PodLoRAAdapters) DeepCopy() *PodLoRAAdapters {
+	if in == nil {
+		return nil
+	}
+	out := new(PodLoRAAdapters)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RecommendedConfig) DeepCopyInto(out *RecommendedConfig) {
 	*out = *in
+	if in.Flavors != nil {
+		in, out := &in.Flavors, &out.Flavors
+		*out = make([]corev1alpha1.FlavorName, len(*in))
+		copy(*out, *in)
+	}
+	if in.ModelFamilies != nil {
+		in, out := &in.ModelFamilies, &out.ModelFamilies
+		*out = make([]corev1alpha1.ModelName, len(*in))
+		copy(*out, *in)
+	}
 	if in.Args != nil {
 		in, out := &in.Args, &out.Args
 		*out = make([]string, len(*in))
@@ -412,6 +648,11 @@ func (in *RecommendedConfig) DeepCopyInto(out *RecommendedConfig) {
 		*out = new(ScaleTrigger)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MultiNode != nil {
+		in, out := &in.MultiNode, &out.MultiNode
+		*out = new(MultiNodeConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecommendedConfig.
@@ -453,6 +694,36 @@ func (in *ResourceRequirements) DeepCopy() *ResourceRequirements {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleToZero) DeepCopyInto(out *ScaleToZero) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.IdleTimeout != nil {
+		in, out := &in.IdleTimeout, &out.IdleTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ColdStartTimeout != nil {
+		in, out := &in.ColdStartTimeout, &out.ColdStartTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleToZero.
+func (in *ScaleToZero) DeepCopy() *ScaleToZero {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleToZero)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScaleTrigger) DeepCopyInto(out *ScaleTrigger) {
 	*out = *in
@@ -547,6 +818,11 @@ func (in *ServiceSpec) DeepCopyInto(out *ServiceSpec) {
 		*out = new(leaderworkersetv1.RolloutStrategy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ScaleToZero != nil {
+		in, out := &in.ScaleToZero, &out.ScaleToZero
+		*out = new(ScaleToZero)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceSpec.
@@ -569,6 +845,10 @@ func (in *ServiceStatus) DeepCopyInto(out *ServiceStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.FlavorTransitionTime != nil {
+		in, out := &in.FlavorTransitionTime, &out.FlavorTransitionTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceStatus.
@@ -580,3 +860,29 @@ func (in *ServiceStatus) DeepCopy() *ServiceStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WarmupSchedule) DeepCopyInto(out *WarmupSchedule) {
+	*out = *in
+	if in.TimeZone != nil {
+		in, out := &in.TimeZone, &out.TimeZone
+		*out = new(string)
+		**out = **in
+	}
+	out.Duration = in.Duration
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WarmupSchedule.
+func (in *WarmupSchedule) DeepCopy() *WarmupSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(WarmupSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
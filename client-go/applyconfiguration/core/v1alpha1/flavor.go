@@ -26,10 +26,14 @@ import (
 // FlavorApplyConfiguration represents a declarative configuration of the Flavor type for use
 // with apply.
 type FlavorApplyConfiguration struct {
-	Name         *corev1alpha1.FlavorName `json:"name,omitempty"`
-	Limits       *v1.ResourceList         `json:"limits,omitempty"`
-	NodeSelector map[string]string        `json:"nodeSelector,omitempty"`
-	Params       map[string]string        `json:"params,omitempty"`
+	Name                      *corev1alpha1.FlavorName      `json:"name,omitempty"`
+	Limits                    *v1.ResourceList              `json:"limits,omitempty"`
+	NodeSelector              map[string]string             `json:"nodeSelector,omitempty"`
+	Params                    map[string]string             `json:"params,omitempty"`
+	Tolerations               []v1.Toleration               `json:"tolerations,omitempty"`
+	Affinity                  *v1.Affinity                  `json:"affinity,omitempty"`
+	TopologySpreadConstraints []v1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+	Args                      []string                      `json:"args,omitempty"`
 }
 
 // FlavorApplyConfiguration constructs a declarative configuration of the Flavor type for use with
@@ -81,3 +85,41 @@ func (b *FlavorApplyConfiguration) WithParams(entries map[string]string) *Flavor
 	}
 	return b
 }
+
+// WithTolerations adds the given value to the Tolerations field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Tolerations field.
+func (b *FlavorApplyConfiguration) WithTolerations(values ...v1.Toleration) *FlavorApplyConfiguration {
+	for i := range values {
+		b.Tolerations = append(b.Tolerations, values[i])
+	}
+	return b
+}
+
+// WithAffinity sets the Affinity field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Affinity field is set to the value of the last call.
+func (b *FlavorApplyConfiguration) WithAffinity(value v1.Affinity) *FlavorApplyConfiguration {
+	b.Affinity = &value
+	return b
+}
+
+// WithTopologySpreadConstraints adds the given value to the TopologySpreadConstraints field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the TopologySpreadConstraints field.
+func (b *FlavorApplyConfiguration) WithTopologySpreadConstraints(values ...v1.TopologySpreadConstraint) *FlavorApplyConfiguration {
+	for i := range values {
+		b.TopologySpreadConstraints = append(b.TopologySpreadConstraints, values[i])
+	}
+	return b
+}
+
+// WithArgs adds the given value to the Args field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Args field.
+func (b *FlavorApplyConfiguration) WithArgs(values ...string) *FlavorApplyConfiguration {
+	for i := range values {
+		b.Args = append(b.Args, values[i])
+	}
+	return b
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2025 The InftyAI Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	corev1alpha1 "github.com/inftyai/llmaz/api/core/v1alpha1"
+	resource "k8s.io/apimachinery/pkg/api/resource"
+)
+
+// ModelCacheApplyConfiguration represents a declarative configuration of the ModelCache type for use
+// with apply.
+type ModelCacheApplyConfiguration struct {
+	Type             *corev1alpha1.ModelCacheType `json:"type,omitempty"`
+	StorageClassName *string                      `json:"storageClassName,omitempty"`
+	Size             *resource.Quantity           `json:"size,omitempty"`
+}
+
+// ModelCacheApplyConfiguration constructs a declarative configuration of the ModelCache type for use with
+// apply.
+func ModelCache() *ModelCacheApplyConfiguration {
+	return &ModelCacheApplyConfiguration{}
+}
+
+// WithType sets the Type field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Type field is set to the value of the last call.
+func (b *ModelCacheApplyConfiguration) WithType(value corev1alpha1.ModelCacheType) *ModelCacheApplyConfiguration {
+	b.Type = &value
+	return b
+}
+
+// WithStorageClassName sets the StorageClassName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the StorageClassName field is set to the value of the last call.
+func (b *ModelCacheApplyConfiguration) WithStorageClassName(value string) *ModelCacheApplyConfiguration {
+	b.StorageClassName = &value
+	return b
+}
+
+// WithSize sets the Size field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Size field is set to the value of the last call.
+func (b *ModelCacheApplyConfiguration) WithSize(value resource.Quantity) *ModelCacheApplyConfiguration {
+	b.Size = &value
+	return b
+}
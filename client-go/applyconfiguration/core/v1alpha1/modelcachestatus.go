@@ -0,0 +1,70 @@
+/*
+Copyright 2025 The InftyAI Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	corev1alpha1 "github.com/inftyai/llmaz/api/core/v1alpha1"
+)
+
+// ModelCacheStatusApplyConfiguration represents a declarative configuration of the ModelCacheStatus type for use
+// with apply.
+type ModelCacheStatusApplyConfiguration struct {
+	Phase     *corev1alpha1.ModelCachePhase `json:"phase,omitempty"`
+	ClaimName *string                       `json:"claimName,omitempty"`
+	Path      *string                       `json:"path,omitempty"`
+	Message   *string                       `json:"message,omitempty"`
+}
+
+// ModelCacheStatusApplyConfiguration constructs a declarative configuration of the ModelCacheStatus type for use with
+// apply.
+func ModelCacheStatus() *ModelCacheStatusApplyConfiguration {
+	return &ModelCacheStatusApplyConfiguration{}
+}
+
+// WithPhase sets the Phase field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Phase field is set to the value of the last call.
+func (b *ModelCacheStatusApplyConfiguration) WithPhase(value corev1alpha1.ModelCachePhase) *ModelCacheStatusApplyConfiguration {
+	b.Phase = &value
+	return b
+}
+
+// WithClaimName sets the ClaimName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ClaimName field is set to the value of the last call.
+func (b *ModelCacheStatusApplyConfiguration) WithClaimName(value string) *ModelCacheStatusApplyConfiguration {
+	b.ClaimName = &value
+	return b
+}
+
+// WithPath sets the Path field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Path field is set to the value of the last call.
+func (b *ModelCacheStatusApplyConfiguration) WithPath(value string) *ModelCacheStatusApplyConfiguration {
+	b.Path = &value
+	return b
+}
+
+// WithMessage sets the Message field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Message field is set to the value of the last call.
+func (b *ModelCacheStatusApplyConfiguration) WithMessage(value string) *ModelCacheStatusApplyConfiguration {
+	b.Message = &value
+	return b
+}
@@ -27,6 +27,7 @@ type ModelHubApplyConfiguration struct {
 	Revision       *string  `json:"revision,omitempty"`
 	AllowPatterns  []string `json:"allowPatterns,omitempty"`
 	IgnorePatterns []string `json:"ignorePatterns,omitempty"`
+	PinRevision    *bool    `json:"pinRevision,omitempty"`
 }
 
 // ModelHubApplyConfiguration constructs a declarative configuration of the ModelHub type for use with
@@ -86,3 +87,11 @@ func (b *ModelHubApplyConfiguration) WithIgnorePatterns(values ...string) *Model
 	}
 	return b
 }
+
+// WithPinRevision sets the PinRevision field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PinRevision field is set to the value of the last call.
+func (b *ModelHubApplyConfiguration) WithPinRevision(value bool) *ModelHubApplyConfiguration {
+	b.PinRevision = &value
+	return b
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2025 The InftyAI Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	resource "k8s.io/apimachinery/pkg/api/resource"
+)
+
+// ModelMetadataApplyConfiguration represents a declarative configuration of the ModelMetadata type for use
+// with apply.
+type ModelMetadataApplyConfiguration struct {
+	ParameterCount *int64             `json:"parameterCount,omitempty"`
+	Architecture   *string            `json:"architecture,omitempty"`
+	ContextLength  *int64             `json:"contextLength,omitempty"`
+	DType          *string            `json:"dtype,omitempty"`
+	WeightSize     *resource.Quantity `json:"weightSize,omitempty"`
+	Files          []string           `json:"files,omitempty"`
+	Tokenizer      *bool              `json:"tokenizer,omitempty"`
+}
+
+// ModelMetadataApplyConfiguration constructs a declarative configuration of the ModelMetadata type for use with
+// apply.
+func ModelMetadata() *ModelMetadataApplyConfiguration {
+	return &ModelMetadataApplyConfiguration{}
+}
+
+// WithParameterCount sets the ParameterCount field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ParameterCount field is set to the value of the last call.
+func (b *ModelMetadataApplyConfiguration) WithParameterCount(value int64) *ModelMetadataApplyConfiguration {
+	b.ParameterCount = &value
+	return b
+}
+
+// WithArchitecture sets the Architecture field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Architecture field is set to the value of the last call.
+func (b *ModelMetadataApplyConfiguration) WithArchitecture(value string) *ModelMetadataApplyConfiguration {
+	b.Architecture = &value
+	return b
+}
+
+// WithContextLength sets the ContextLength field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ContextLength field is set to the value of the last call.
+func (b *ModelMetadataApplyConfiguration) WithContextLength(value int64) *ModelMetadataApplyConfiguration {
+	b.ContextLength = &value
+	return b
+}
+
+// WithDType sets the DType field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DType field is set to the value of the last call.
+func (b *ModelMetadataApplyConfiguration) WithDType(value string) *ModelMetadataApplyConfiguration {
+	b.DType = &value
+	return b
+}
+
+// WithWeightSize sets the WeightSize field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the WeightSize field is set to the value of the last call.
+func (b *ModelMetadataApplyConfiguration) WithWeightSize(value resource.Quantity) *ModelMetadataApplyConfiguration {
+	b.WeightSize = &value
+	return b
+}
+
+// WithFiles adds the given value to the Files field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Files field.
+func (b *ModelMetadataApplyConfiguration) WithFiles(values ...string) *ModelMetadataApplyConfiguration {
+	for i := range values {
+		b.Files = append(b.Files, values[i])
+	}
+	return b
+}
+
+// WithTokenizer sets the Tokenizer field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Tokenizer field is set to the value of the last call.
+func (b *ModelMetadataApplyConfiguration) WithTokenizer(value bool) *ModelMetadataApplyConfiguration {
+	b.Tokenizer = &value
+	return b
+}
@@ -25,8 +25,10 @@ import (
 // ModelSourceApplyConfiguration represents a declarative configuration of the ModelSource type for use
 // with apply.
 type ModelSourceApplyConfiguration struct {
-	ModelHub *ModelHubApplyConfiguration `json:"modelHub,omitempty"`
-	URI      *corev1alpha1.URIProtocol   `json:"uri,omitempty"`
+	ModelHub  *ModelHubApplyConfiguration        `json:"modelHub,omitempty"`
+	URI       *corev1alpha1.URIProtocol          `json:"uri,omitempty"`
+	SecretRef *SecretReferenceApplyConfiguration `json:"secretRef,omitempty"`
+	Checksums map[string]string                  `json:"checksums,omitempty"`
 }
 
 // ModelSourceApplyConfiguration constructs a declarative configuration of the ModelSource type for use with
@@ -50,3 +52,25 @@ func (b *ModelSourceApplyConfiguration) WithURI(value corev1alpha1.URIProtocol)
 	b.URI = &value
 	return b
 }
+
+// WithSecretRef sets the SecretRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SecretRef field is set to the value of the last call.
+func (b *ModelSourceApplyConfiguration) WithSecretRef(value *SecretReferenceApplyConfiguration) *ModelSourceApplyConfiguration {
+	b.SecretRef = value
+	return b
+}
+
+// WithChecksums puts the entries into the Checksums field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the Checksums field,
+// overwriting an existing map entries in Checksums field with the same key.
+func (b *ModelSourceApplyConfiguration) WithChecksums(entries map[string]string) *ModelSourceApplyConfiguration {
+	if b.Checksums == nil && len(entries) > 0 {
+		b.Checksums = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.Checksums[k] = v
+	}
+	return b
+}
@@ -31,6 +31,7 @@ type ModelSpecApplyConfiguration struct {
 	InferenceConfig *InferenceConfigApplyConfiguration `json:"inferenceConfig,omitempty"`
 	OwnedBy         *string                            `json:"ownedBy,omitempty"`
 	CreatedAt       *v1.Time                           `json:"createdAt,omitempty"`
+	Cache           *ModelCacheApplyConfiguration      `json:"cache,omitempty"`
 }
 
 // ModelSpecApplyConfiguration constructs a declarative configuration of the ModelSpec type for use with
@@ -78,3 +79,11 @@ func (b *ModelSpecApplyConfiguration) WithCreatedAt(value v1.Time) *ModelSpecApp
 	b.CreatedAt = &value
 	return b
 }
+
+// WithCache sets the Cache field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Cache field is set to the value of the last call.
+func (b *ModelSpecApplyConfiguration) WithCache(value *ModelCacheApplyConfiguration) *ModelSpecApplyConfiguration {
+	b.Cache = value
+	return b
+}
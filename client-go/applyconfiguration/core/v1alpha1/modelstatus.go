@@ -25,7 +25,11 @@ import (
 // ModelStatusApplyConfiguration represents a declarative configuration of the ModelStatus type for use
 // with apply.
 type ModelStatusApplyConfiguration struct {
-	Conditions []v1.ConditionApplyConfiguration `json:"conditions,omitempty"`
+	Conditions     []v1.ConditionApplyConfiguration      `json:"conditions,omitempty"`
+	Metadata       *ModelMetadataApplyConfiguration      `json:"metadata,omitempty"`
+	Cache          *ModelCacheStatusApplyConfiguration   `json:"cache,omitempty"`
+	PinnedRevision *PinnedRevisionApplyConfiguration     `json:"pinnedRevision,omitempty"`
+	Preheat        []NodePreheatStatusApplyConfiguration `json:"preheat,omitempty"`
 }
 
 // ModelStatusApplyConfiguration constructs a declarative configuration of the ModelStatus type for use with
@@ -46,3 +50,40 @@ func (b *ModelStatusApplyConfiguration) WithConditions(values ...*v1.ConditionAp
 	}
 	return b
 }
+
+// WithMetadata sets the Metadata field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Metadata field is set to the value of the last call.
+func (b *ModelStatusApplyConfiguration) WithMetadata(value *ModelMetadataApplyConfiguration) *ModelStatusApplyConfiguration {
+	b.Metadata = value
+	return b
+}
+
+// WithCache sets the Cache field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Cache field is set to the value of the last call.
+func (b *ModelStatusApplyConfiguration) WithCache(value *ModelCacheStatusApplyConfiguration) *ModelStatusApplyConfiguration {
+	b.Cache = value
+	return b
+}
+
+// WithPinnedRevision sets the PinnedRevision field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PinnedRevision field is set to the value of the last call.
+func (b *ModelStatusApplyConfiguration) WithPinnedRevision(value *PinnedRevisionApplyConfiguration) *ModelStatusApplyConfiguration {
+	b.PinnedRevision = value
+	return b
+}
+
+// WithPreheat adds the given value to the Preheat field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Preheat field.
+func (b *ModelStatusApplyConfiguration) WithPreheat(values ...*NodePreheatStatusApplyConfiguration) *ModelStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithPreheat")
+		}
+		b.Preheat = append(b.Preheat, *values[i])
+	}
+	return b
+}
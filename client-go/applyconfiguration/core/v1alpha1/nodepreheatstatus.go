@@ -0,0 +1,61 @@
+/*
+Copyright 2025 The InftyAI Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	corev1alpha1 "github.com/inftyai/llmaz/api/core/v1alpha1"
+)
+
+// NodePreheatStatusApplyConfiguration represents a declarative configuration of the NodePreheatStatus type for use
+// with apply.
+type NodePreheatStatusApplyConfiguration struct {
+	NodeName *string                       `json:"nodeName,omitempty"`
+	Phase    *corev1alpha1.ModelCachePhase `json:"phase,omitempty"`
+	Message  *string                       `json:"message,omitempty"`
+}
+
+// NodePreheatStatusApplyConfiguration constructs a declarative configuration of the NodePreheatStatus type for use with
+// apply.
+func NodePreheatStatus() *NodePreheatStatusApplyConfiguration {
+	return &NodePreheatStatusApplyConfiguration{}
+}
+
+// WithNodeName sets the NodeName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NodeName field is set to the value of the last call.
+func (b *NodePreheatStatusApplyConfiguration) WithNodeName(value string) *NodePreheatStatusApplyConfiguration {
+	b.NodeName = &value
+	return b
+}
+
+// WithPhase sets the Phase field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Phase field is set to the value of the last call.
+func (b *NodePreheatStatusApplyConfiguration) WithPhase(value corev1alpha1.ModelCachePhase) *NodePreheatStatusApplyConfiguration {
+	b.Phase = &value
+	return b
+}
+
+// WithMessage sets the Message field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Message field is set to the value of the last call.
+func (b *NodePreheatStatusApplyConfiguration) WithMessage(value string) *NodePreheatStatusApplyConfiguration {
+	b.Message = &value
+	return b
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2025 The InftyAI Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// PinnedRevisionApplyConfiguration represents a declarative configuration of the PinnedRevision type for use
+// with apply.
+type PinnedRevisionApplyConfiguration struct {
+	Revision *string `json:"revision,omitempty"`
+	Commit   *string `json:"commit,omitempty"`
+}
+
+// PinnedRevisionApplyConfiguration constructs a declarative configuration of the PinnedRevision type for use with
+// apply.
+func PinnedRevision() *PinnedRevisionApplyConfiguration {
+	return &PinnedRevisionApplyConfiguration{}
+}
+
+// WithRevision sets the Revision field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Revision field is set to the value of the last call.
+func (b *PinnedRevisionApplyConfiguration) WithRevision(value string) *PinnedRevisionApplyConfiguration {
+	b.Revision = &value
+	return b
+}
+
+// WithCommit sets the Commit field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Commit field is set to the value of the last call.
+func (b *PinnedRevisionApplyConfiguration) WithCommit(value string) *PinnedRevisionApplyConfiguration {
+	b.Commit = &value
+	return b
+}
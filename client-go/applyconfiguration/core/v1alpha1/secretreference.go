@@ -0,0 +1,54 @@
+/*
+Copyright 2025 The InftyAI Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// SecretReferenceApplyConfiguration represents a declarative configuration of the SecretReference type for use
+// with apply.
+type SecretReferenceApplyConfiguration struct {
+	Name *string           `json:"name,omitempty"`
+	Keys map[string]string `json:"keys,omitempty"`
+}
+
+// SecretReferenceApplyConfiguration constructs a declarative configuration of the SecretReference type for use with
+// apply.
+func SecretReference() *SecretReferenceApplyConfiguration {
+	return &SecretReferenceApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *SecretReferenceApplyConfiguration) WithName(value string) *SecretReferenceApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithKeys puts the entries into the Keys field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the Keys field,
+// overwriting an existing map entries in Keys field with the same key.
+func (b *SecretReferenceApplyConfiguration) WithKeys(entries map[string]string) *SecretReferenceApplyConfiguration {
+	if b.Keys == nil && len(entries) > 0 {
+		b.Keys = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.Keys[k] = v
+	}
+	return b
+}
@@ -21,9 +21,11 @@ package v1alpha1
 // ElasticConfigApplyConfiguration represents a declarative configuration of the ElasticConfig type for use
 // with apply.
 type ElasticConfigApplyConfiguration struct {
-	MinReplicas  *int32                          `json:"minReplicas,omitempty"`
-	MaxReplicas  *int32                          `json:"maxReplicas,omitempty"`
-	ScaleTrigger *ScaleTriggerApplyConfiguration `json:"scaleTrigger,omitempty"`
+	MinReplicas     *int32                             `json:"minReplicas,omitempty"`
+	MaxReplicas     *int32                             `json:"maxReplicas,omitempty"`
+	ScaleTrigger    *ScaleTriggerApplyConfiguration    `json:"scaleTrigger,omitempty"`
+	ScaleToZero     *ScaleToZeroApplyConfiguration     `json:"scaleToZero,omitempty"`
+	WarmupSchedules []WarmupScheduleApplyConfiguration `json:"warmupSchedules,omitempty"`
 }
 
 // ElasticConfigApplyConfiguration constructs a declarative configuration of the ElasticConfig type for use with
@@ -55,3 +57,24 @@ func (b *ElasticConfigApplyConfiguration) WithScaleTrigger(value *ScaleTriggerAp
 	b.ScaleTrigger = value
 	return b
 }
+
+// WithScaleToZero sets the ScaleToZero field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ScaleToZero field is set to the value of the last call.
+func (b *ElasticConfigApplyConfiguration) WithScaleToZero(value *ScaleToZeroApplyConfiguration) *ElasticConfigApplyConfiguration {
+	b.ScaleToZero = value
+	return b
+}
+
+// WithWarmupSchedules adds the given value to the WarmupSchedules field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the WarmupSchedules field.
+func (b *ElasticConfigApplyConfiguration) WithWarmupSchedules(values ...*WarmupScheduleApplyConfiguration) *ElasticConfigApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithWarmupSchedules")
+		}
+		b.WarmupSchedules = append(b.WarmupSchedules, *values[i])
+	}
+	return b
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2025 The InftyAI Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	corev1alpha1 "github.com/inftyai/llmaz/api/core/v1alpha1"
+	inferencev1alpha1 "github.com/inftyai/llmaz/api/inference/v1alpha1"
+)
+
+// LoRAAdapterStatusApplyConfiguration represents a declarative configuration of the LoRAAdapterStatus type for use
+// with apply.
+type LoRAAdapterStatusApplyConfiguration struct {
+	Name    *corev1alpha1.ModelName             `json:"name,omitempty"`
+	State   *inferencev1alpha1.LoRAAdapterState `json:"state,omitempty"`
+	Message *string                             `json:"message,omitempty"`
+}
+
+// LoRAAdapterStatusApplyConfiguration constructs a declarative configuration of the LoRAAdapterStatus type for use with
+// apply.
+func LoRAAdapterStatus() *LoRAAdapterStatusApplyConfiguration {
+	return &LoRAAdapterStatusApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *LoRAAdapterStatusApplyConfiguration) WithName(value corev1alpha1.ModelName) *LoRAAdapterStatusApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithState sets the State field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the State field is set to the value of the last call.
+func (b *LoRAAdapterStatusApplyConfiguration) WithState(value inferencev1alpha1.LoRAAdapterState) *LoRAAdapterStatusApplyConfiguration {
+	b.State = &value
+	return b
+}
+
+// WithMessage sets the Message field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Message field is set to the value of the last call.
+func (b *LoRAAdapterStatusApplyConfiguration) WithMessage(value string) *LoRAAdapterStatusApplyConfiguration {
+	b.Message = &value
+	return b
+}
@@ -30,6 +30,7 @@ type PlaygroundSpecApplyConfiguration struct {
 	ModelClaims          *corev1alpha1.ModelClaimsApplyConfiguration `json:"modelClaims,omitempty"`
 	BackendRuntimeConfig *BackendRuntimeConfigApplyConfiguration     `json:"backendRuntimeConfig,omitempty"`
 	ElasticConfig        *ElasticConfigApplyConfiguration            `json:"elasticConfig,omitempty"`
+	LoRAConfig           *LoRAConfigApplyConfiguration               `json:"loraConfig,omitempty"`
 }
 
 // PlaygroundSpecApplyConfiguration constructs a declarative configuration of the PlaygroundSpec type for use with
@@ -77,3 +78,11 @@ func (b *PlaygroundSpecApplyConfiguration) WithElasticConfig(value *ElasticConfi
 	b.ElasticConfig = value
 	return b
 }
+
+// WithLoRAConfig sets the LoRAConfig field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LoRAConfig field is set to the value of the last call.
+func (b *PlaygroundSpecApplyConfiguration) WithLoRAConfig(value *LoRAConfigApplyConfiguration) *PlaygroundSpecApplyConfiguration {
+	b.LoRAConfig = value
+	return b
+}
@@ -19,15 +19,24 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1alpha1 "github.com/inftyai/llmaz/api/core/v1alpha1"
+	inferencev1alpha1 "github.com/inftyai/llmaz/api/inference/v1alpha1"
 	v1 "k8s.io/client-go/applyconfigurations/meta/v1"
 )
 
 // PlaygroundStatusApplyConfiguration represents a declarative configuration of the PlaygroundStatus type for use
 // with apply.
 type PlaygroundStatusApplyConfiguration struct {
-	Conditions []v1.ConditionApplyConfiguration `json:"conditions,omitempty"`
-	Replicas   *int32                           `json:"replicas,omitempty"`
-	Selector   *string                          `json:"selector,omitempty"`
+	Conditions            []v1.ConditionApplyConfiguration    `json:"conditions,omitempty"`
+	Replicas              *int32                              `json:"replicas,omitempty"`
+	Selector              *string                             `json:"selector,omitempty"`
+	ObservedGeneration    *int64                              `json:"observedGeneration,omitempty"`
+	Flavor                *corev1alpha1.FlavorName            `json:"flavor,omitempty"`
+	BackendRuntimeName    *inferencev1alpha1.BackendName      `json:"backendRuntimeName,omitempty"`
+	BackendRuntimeVersion *string                             `json:"backendRuntimeVersion,omitempty"`
+	Image                 *string                             `json:"image,omitempty"`
+	ModelPath             *string                             `json:"modelPath,omitempty"`
+	LoRAAdapters          []PodLoRAAdaptersApplyConfiguration `json:"loraAdapters,omitempty"`
 }
 
 // PlaygroundStatusApplyConfiguration constructs a declarative configuration of the PlaygroundStatus type for use with
@@ -64,3 +73,64 @@ func (b *PlaygroundStatusApplyConfiguration) WithSelector(value string) *Playgro
 	b.Selector = &value
 	return b
 }
+
+// WithObservedGeneration sets the ObservedGeneration field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ObservedGeneration field is set to the value of the last call.
+func (b *PlaygroundStatusApplyConfiguration) WithObservedGeneration(value int64) *PlaygroundStatusApplyConfiguration {
+	b.ObservedGeneration = &value
+	return b
+}
+
+// WithFlavor sets the Flavor field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Flavor field is set to the value of the last call.
+func (b *PlaygroundStatusApplyConfiguration) WithFlavor(value corev1alpha1.FlavorName) *PlaygroundStatusApplyConfiguration {
+	b.Flavor = &value
+	return b
+}
+
+// WithBackendRuntimeName sets the BackendRuntimeName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the BackendRuntimeName field is set to the value of the last call.
+func (b *PlaygroundStatusApplyConfiguration) WithBackendRuntimeName(value inferencev1alpha1.BackendName) *PlaygroundStatusApplyConfiguration {
+	b.BackendRuntimeName = &value
+	return b
+}
+
+// WithBackendRuntimeVersion sets the BackendRuntimeVersion field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the BackendRuntimeVersion field is set to the value of the last call.
+func (b *PlaygroundStatusApplyConfiguration) WithBackendRuntimeVersion(value string) *PlaygroundStatusApplyConfiguration {
+	b.BackendRuntimeVersion = &value
+	return b
+}
+
+// WithImage sets the Image field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Image field is set to the value of the last call.
+func (b *PlaygroundStatusApplyConfiguration) WithImage(value string) *PlaygroundStatusApplyConfiguration {
+	b.Image = &value
+	return b
+}
+
+// WithModelPath sets the ModelPath field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ModelPath field is set to the value of the last call.
+func (b *PlaygroundStatusApplyConfiguration) WithModelPath(value string) *PlaygroundStatusApplyConfiguration {
+	b.ModelPath = &value
+	return b
+}
+
+// WithLoRAAdapters adds the given value to the LoRAAdapters field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the LoRAAdapters field.
+func (b *PlaygroundStatusApplyConfiguration) WithLoRAAdapters(values ...*PodLoRAAdaptersApplyConfiguration) *PlaygroundStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithLoRAAdapters")
+		}
+		b.LoRAAdapters = append(b.LoRAAdapters, *values[i])
+	}
+	return b
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2025 The InftyAI Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	types "k8s.io/apimachinery/pkg/types"
+)
+
+// PodLoRAAdaptersApplyConfiguration represents a declarative configuration of the PodLoRAAdapters type for use
+// with apply.
+type PodLoRAAdaptersApplyConfiguration struct {
+	PodName      *string                               `json:"podName,omitempty"`
+	PodUID       *types.UID                            `json:"podUID,omitempty"`
+	RestartCount *int32                                `json:"restartCount,omitempty"`
+	Adapters     []LoRAAdapterStatusApplyConfiguration `json:"adapters,omitempty"`
+}
+
+// PodLoRAAdaptersApplyConfiguration constructs a declarative configuration of the PodLoRAAdapters type for use with
+// apply.
+func PodLoRAAdapters() *PodLoRAAdaptersApplyConfiguration {
+	return &PodLoRAAdaptersApplyConfiguration{}
+}
+
+// WithPodName sets the PodName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PodName field is set to the value of the last call.
+func (b *PodLoRAAdaptersApplyConfiguration) WithPodName(value string) *PodLoRAAdaptersApplyConfiguration {
+	b.PodName = &value
+	return b
+}
+
+// WithPodUID sets the PodUID field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PodUID field is set to the value of the last call.
+func (b *PodLoRAAdaptersApplyConfiguration) WithPodUID(value types.UID) *PodLoRAAdaptersApplyConfiguration {
+	b.PodUID = &value
+	return b
+}
+
+// WithRestartCount sets the RestartCount field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RestartCount field is set to the value of the last call.
+func (b *PodLoRAAdaptersApplyConfiguration) WithRestartCount(value int32) *PodLoRAAdaptersApplyConfiguration {
+	b.RestartCount = &value
+	return b
+}
+
+// WithAdapters adds the given value to the Adapters field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Adapters field.
+func (b *PodLoRAAdaptersApplyConfiguration) WithAdapters(values ...*LoRAAdapterStatusApplyConfiguration) *PodLoRAAdaptersApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithAdapters")
+		}
+		b.Adapters = append(b.Adapters, *values[i])
+	}
+	return b
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2025 The InftyAI Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ScaleToZeroApplyConfiguration represents a declarative configuration of the ScaleToZero type for use
+// with apply.
+type ScaleToZeroApplyConfiguration struct {
+	Enabled          *bool        `json:"enabled,omitempty"`
+	IdleTimeout      *v1.Duration `json:"idleTimeout,omitempty"`
+	ColdStartTimeout *v1.Duration `json:"coldStartTimeout,omitempty"`
+}
+
+// ScaleToZeroApplyConfiguration constructs a declarative configuration of the ScaleToZero type for use with
+// apply.
+func ScaleToZero() *ScaleToZeroApplyConfiguration {
+	return &ScaleToZeroApplyConfiguration{}
+}
+
+// WithEnabled sets the Enabled field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Enabled field is set to the value of the last call.
+func (b *ScaleToZeroApplyConfiguration) WithEnabled(value bool) *ScaleToZeroApplyConfiguration {
+	b.Enabled = &value
+	return b
+}
+
+// WithIdleTimeout sets the IdleTimeout field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the IdleTimeout field is set to the value of the last call.
+func (b *ScaleToZeroApplyConfiguration) WithIdleTimeout(value v1.Duration) *ScaleToZeroApplyConfiguration {
+	b.IdleTimeout = &value
+	return b
+}
+
+// WithColdStartTimeout sets the ColdStartTimeout field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ColdStartTimeout field is set to the value of the last call.
+func (b *ScaleToZeroApplyConfiguration) WithColdStartTimeout(value v1.Duration) *ScaleToZeroApplyConfiguration {
+	b.ColdStartTimeout = &value
+	return b
+}
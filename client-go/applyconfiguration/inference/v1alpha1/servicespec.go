@@ -30,6 +30,7 @@ type ServiceSpecApplyConfiguration struct {
 	Replicas         *int32                                      `json:"replicas,omitempty"`
 	WorkloadTemplate *v1.LeaderWorkerTemplate                    `json:"workloadTemplate,omitempty"`
 	RolloutStrategy  *v1.RolloutStrategy                         `json:"rolloutStrategy,omitempty"`
+	ScaleToZero      *ScaleToZeroApplyConfiguration              `json:"scaleToZero,omitempty"`
 }
 
 // ServiceSpecApplyConfiguration constructs a declarative configuration of the ServiceSpec type for use with
@@ -69,3 +70,11 @@ func (b *ServiceSpecApplyConfiguration) WithRolloutStrategy(value v1.RolloutStra
 	b.RolloutStrategy = &value
 	return b
 }
+
+// WithScaleToZero sets the ScaleToZero field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ScaleToZero field is set to the value of the last call.
+func (b *ServiceSpecApplyConfiguration) WithScaleToZero(value *ScaleToZeroApplyConfiguration) *ServiceSpecApplyConfiguration {
+	b.ScaleToZero = value
+	return b
+}
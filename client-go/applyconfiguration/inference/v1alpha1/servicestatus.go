@@ -19,15 +19,25 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1alpha1 "github.com/inftyai/llmaz/api/core/v1alpha1"
+	inferencev1alpha1 "github.com/inftyai/llmaz/api/inference/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	v1 "k8s.io/client-go/applyconfigurations/meta/v1"
 )
 
 // ServiceStatusApplyConfiguration represents a declarative configuration of the ServiceStatus type for use
 // with apply.
 type ServiceStatusApplyConfiguration struct {
-	Conditions []v1.ConditionApplyConfiguration `json:"conditions,omitempty"`
-	Replicas   *int32                           `json:"replicas,omitempty"`
-	Selector   *string                          `json:"selector,omitempty"`
+	Conditions            []v1.ConditionApplyConfiguration `json:"conditions,omitempty"`
+	Replicas              *int32                           `json:"replicas,omitempty"`
+	Selector              *string                          `json:"selector,omitempty"`
+	Flavor                *corev1alpha1.FlavorName         `json:"flavor,omitempty"`
+	FlavorTransitionTime  *metav1.Time                     `json:"flavorTransitionTime,omitempty"`
+	ObservedGeneration    *int64                           `json:"observedGeneration,omitempty"`
+	BackendRuntimeName    *inferencev1alpha1.BackendName   `json:"backendRuntimeName,omitempty"`
+	BackendRuntimeVersion *string                          `json:"backendRuntimeVersion,omitempty"`
+	Image                 *string                          `json:"image,omitempty"`
+	ModelPath             *string                          `json:"modelPath,omitempty"`
 }
 
 // ServiceStatusApplyConfiguration constructs a declarative configuration of the ServiceStatus type for use with
@@ -64,3 +74,59 @@ func (b *ServiceStatusApplyConfiguration) WithSelector(value string) *ServiceSta
 	b.Selector = &value
 	return b
 }
+
+// WithFlavor sets the Flavor field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Flavor field is set to the value of the last call.
+func (b *ServiceStatusApplyConfiguration) WithFlavor(value corev1alpha1.FlavorName) *ServiceStatusApplyConfiguration {
+	b.Flavor = &value
+	return b
+}
+
+// WithFlavorTransitionTime sets the FlavorTransitionTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FlavorTransitionTime field is set to the value of the last call.
+func (b *ServiceStatusApplyConfiguration) WithFlavorTransitionTime(value metav1.Time) *ServiceStatusApplyConfiguration {
+	b.FlavorTransitionTime = &value
+	return b
+}
+
+// WithObservedGeneration sets the ObservedGeneration field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ObservedGeneration field is set to the value of the last call.
+func (b *ServiceStatusApplyConfiguration) WithObservedGeneration(value int64) *ServiceStatusApplyConfiguration {
+	b.ObservedGeneration = &value
+	return b
+}
+
+// WithBackendRuntimeName sets the BackendRuntimeName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the BackendRuntimeName field is set to the value of the last call.
+func (b *ServiceStatusApplyConfiguration) WithBackendRuntimeName(value inferencev1alpha1.BackendName) *ServiceStatusApplyConfiguration {
+	b.BackendRuntimeName = &value
+	return b
+}
+
+// WithBackendRuntimeVersion sets the BackendRuntimeVersion field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the BackendRuntimeVersion field is set to the value of the last call.
+func (b *ServiceStatusApplyConfiguration) WithBackendRuntimeVersion(value string) *ServiceStatusApplyConfiguration {
+	b.BackendRuntimeVersion = &value
+	return b
+}
+
+// WithImage sets the Image field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Image field is set to the value of the last call.
+func (b *ServiceStatusApplyConfiguration) WithImage(value string) *ServiceStatusApplyConfiguration {
+	b.Image = &value
+	return b
+}
+
+// WithModelPath sets the ModelPath field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ModelPath field is set to the value of the last call.
+func (b *ServiceStatusApplyConfiguration) WithModelPath(value string) *ServiceStatusApplyConfiguration {
+	b.ModelPath = &value
+	return b
+}
@@ -40,14 +40,22 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &inferencev1alpha1.ElasticConfigApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("HPATrigger"):
 		return &inferencev1alpha1.HPATriggerApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("LoRAAdapterStatus"):
+		return &inferencev1alpha1.LoRAAdapterStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("LoRAConfig"):
+		return &inferencev1alpha1.LoRAConfigApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("Playground"):
 		return &inferencev1alpha1.PlaygroundApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("PlaygroundSpec"):
 		return &inferencev1alpha1.PlaygroundSpecApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("PlaygroundStatus"):
 		return &inferencev1alpha1.PlaygroundStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("PodLoRAAdapters"):
+		return &inferencev1alpha1.PodLoRAAdaptersApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ResourceRequirements"):
 		return &inferencev1alpha1.ResourceRequirementsApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ScaleToZero"):
+		return &inferencev1alpha1.ScaleToZeroApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ScaleTrigger"):
 		return &inferencev1alpha1.ScaleTriggerApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("Service"):
@@ -56,18 +64,28 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &inferencev1alpha1.ServiceSpecApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ServiceStatus"):
 		return &inferencev1alpha1.ServiceStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("WarmupSchedule"):
+		return &inferencev1alpha1.WarmupScheduleApplyConfiguration{}
 
 		// Group=llmaz.io, Version=v1alpha1
 	case corev1alpha1.SchemeGroupVersion.WithKind("Flavor"):
 		return &applyconfigurationcorev1alpha1.FlavorApplyConfiguration{}
 	case corev1alpha1.SchemeGroupVersion.WithKind("InferenceConfig"):
 		return &applyconfigurationcorev1alpha1.InferenceConfigApplyConfiguration{}
+	case corev1alpha1.SchemeGroupVersion.WithKind("Model"):
+		return &applyconfigurationcorev1alpha1.ModelApplyConfiguration{}
+	case corev1alpha1.SchemeGroupVersion.WithKind("ModelCache"):
+		return &applyconfigurationcorev1alpha1.ModelCacheApplyConfiguration{}
+	case corev1alpha1.SchemeGroupVersion.WithKind("ModelCacheStatus"):
+		return &applyconfigurationcorev1alpha1.ModelCacheStatusApplyConfiguration{}
 	case corev1alpha1.SchemeGroupVersion.WithKind("ModelClaim"):
 		return &applyconfigurationcorev1alpha1.ModelClaimApplyConfiguration{}
 	case corev1alpha1.SchemeGroupVersion.WithKind("ModelClaims"):
 		return &applyconfigurationcorev1alpha1.ModelClaimsApplyConfiguration{}
 	case corev1alpha1.SchemeGroupVersion.WithKind("ModelHub"):
 		return &applyconfigurationcorev1alpha1.ModelHubApplyConfiguration{}
+	case corev1alpha1.SchemeGroupVersion.WithKind("ModelMetadata"):
+		return &applyconfigurationcorev1alpha1.ModelMetadataApplyConfiguration{}
 	case corev1alpha1.SchemeGroupVersion.WithKind("ModelRef"):
 		return &applyconfigurationcorev1alpha1.ModelRefApplyConfiguration{}
 	case corev1alpha1.SchemeGroupVersion.WithKind("ModelSource"):
@@ -76,8 +94,14 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &applyconfigurationcorev1alpha1.ModelSpecApplyConfiguration{}
 	case corev1alpha1.SchemeGroupVersion.WithKind("ModelStatus"):
 		return &applyconfigurationcorev1alpha1.ModelStatusApplyConfiguration{}
+	case corev1alpha1.SchemeGroupVersion.WithKind("NodePreheatStatus"):
+		return &applyconfigurationcorev1alpha1.NodePreheatStatusApplyConfiguration{}
 	case corev1alpha1.SchemeGroupVersion.WithKind("OpenModel"):
 		return &applyconfigurationcorev1alpha1.OpenModelApplyConfiguration{}
+	case corev1alpha1.SchemeGroupVersion.WithKind("PinnedRevision"):
+		return &applyconfigurationcorev1alpha1.PinnedRevisionApplyConfiguration{}
+	case corev1alpha1.SchemeGroupVersion.WithKind("SecretReference"):
+		return &applyconfigurationcorev1alpha1.SecretReferenceApplyConfiguration{}
 
 	}
 	return nil
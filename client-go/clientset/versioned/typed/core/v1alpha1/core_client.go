@@ -28,6 +28,7 @@ import (
 
 type LlmazV1alpha1Interface interface {
 	RESTClient() rest.Interface
+	ModelsGetter
 	OpenModelsGetter
 }
 
@@ -36,6 +37,10 @@ type LlmazV1alpha1Client struct {
 	restClient rest.Interface
 }
 
+func (c *LlmazV1alpha1Client) Models(namespace string) ModelInterface {
+	return newModels(c, namespace)
+}
+
 func (c *LlmazV1alpha1Client) OpenModels(namespace string) OpenModelInterface {
 	return newOpenModels(c, namespace)
 }
@@ -28,6 +28,10 @@ type FakeLlmazV1alpha1 struct {
 	*testing.Fake
 }
 
+func (c *FakeLlmazV1alpha1) Models(namespace string) v1alpha1.ModelInterface {
+	return newFakeModels(c, namespace)
+}
+
 func (c *FakeLlmazV1alpha1) OpenModels(namespace string) v1alpha1.OpenModelInterface {
 	return newFakeOpenModels(c, namespace)
 }
@@ -0,0 +1,51 @@
+/*
+Copyright 2025 The InftyAI Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1alpha1 "github.com/inftyai/llmaz/api/core/v1alpha1"
+	corev1alpha1 "github.com/inftyai/llmaz/client-go/applyconfiguration/core/v1alpha1"
+	typedcorev1alpha1 "github.com/inftyai/llmaz/client-go/clientset/versioned/typed/core/v1alpha1"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// fakeModels implements ModelInterface
+type fakeModels struct {
+	*gentype.FakeClientWithListAndApply[*v1alpha1.Model, *v1alpha1.ModelList, *corev1alpha1.ModelApplyConfiguration]
+	Fake *FakeLlmazV1alpha1
+}
+
+func newFakeModels(fake *FakeLlmazV1alpha1, namespace string) typedcorev1alpha1.ModelInterface {
+	return &fakeModels{
+		gentype.NewFakeClientWithListAndApply[*v1alpha1.Model, *v1alpha1.ModelList, *corev1alpha1.ModelApplyConfiguration](
+			fake.Fake,
+			namespace,
+			v1alpha1.SchemeGroupVersion.WithResource("models"),
+			v1alpha1.SchemeGroupVersion.WithKind("Model"),
+			func() *v1alpha1.Model { return &v1alpha1.Model{} },
+			func() *v1alpha1.ModelList { return &v1alpha1.ModelList{} },
+			func(dst, src *v1alpha1.ModelList) { dst.ListMeta = src.ListMeta },
+			func(list *v1alpha1.ModelList) []*v1alpha1.Model { return gentype.ToPointerSlice(list.Items) },
+			func(list *v1alpha1.ModelList, items []*v1alpha1.Model) {
+				list.Items = gentype.FromPointerSlice(items)
+			},
+		),
+		fake,
+	}
+}
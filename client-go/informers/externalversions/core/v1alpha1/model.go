@@ -0,0 +1,90 @@
+/*
+Copyright 2025 The InftyAI Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	context "context"
+	time "time"
+
+	apicorev1alpha1 "github.com/inftyai/llmaz/api/core/v1alpha1"
+	versioned "github.com/inftyai/llmaz/client-go/clientset/versioned"
+	internalinterfaces "github.com/inftyai/llmaz/client-go/informers/externalversions/internalinterfaces"
+	corev1alpha1 "github.com/inftyai/llmaz/client-go/listers/core/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// ModelInformer provides access to a shared informer and lister for
+// Models.
+type ModelInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() corev1alpha1.ModelLister
+}
+
+type modelInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewModelInformer constructs a new informer for Model type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewModelInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredModelInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredModelInformer constructs a new informer for Model type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredModelInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.LlmazV1alpha1().Models(namespace).List(context.TODO(), options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.LlmazV1alpha1().Models(namespace).Watch(context.TODO(), options)
+			},
+		},
+		&apicorev1alpha1.Model{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *modelInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredModelInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *modelInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&apicorev1alpha1.Model{}, f.defaultInformer)
+}
+
+func (f *modelInformer) Lister() corev1alpha1.ModelLister {
+	return corev1alpha1.NewModelLister(f.Informer().GetIndexer())
+}
@@ -18,6 +18,14 @@ limitations under the License.
 
 package v1alpha1
 
+// ModelListerExpansion allows custom methods to be added to
+// ModelLister.
+type ModelListerExpansion interface{}
+
+// ModelNamespaceListerExpansion allows custom methods to be added to
+// ModelNamespaceLister.
+type ModelNamespaceListerExpansion interface{}
+
 // OpenModelListerExpansion allows custom methods to be added to
 // OpenModelLister.
 type OpenModelListerExpansion interface{}
@@ -0,0 +1,70 @@
+/*
+Copyright 2025 The InftyAI Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	corev1alpha1 "github.com/inftyai/llmaz/api/core/v1alpha1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	listers "k8s.io/client-go/listers"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// ModelLister helps list Models.
+// All objects returned here must be treated as read-only.
+type ModelLister interface {
+	// List lists all Models in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*corev1alpha1.Model, err error)
+	// Models returns an object that can list and get Models.
+	Models(namespace string) ModelNamespaceLister
+	ModelListerExpansion
+}
+
+// modelLister implements the ModelLister interface.
+type modelLister struct {
+	listers.ResourceIndexer[*corev1alpha1.Model]
+}
+
+// NewModelLister returns a new ModelLister.
+func NewModelLister(indexer cache.Indexer) ModelLister {
+	return &modelLister{listers.New[*corev1alpha1.Model](indexer, corev1alpha1.Resource("model"))}
+}
+
+// Models returns an object that can list and get Models.
+func (s *modelLister) Models(namespace string) ModelNamespaceLister {
+	return modelNamespaceLister{listers.NewNamespaced[*corev1alpha1.Model](s.ResourceIndexer, namespace)}
+}
+
+// ModelNamespaceLister helps list and get Models.
+// All objects returned here must be treated as read-only.
+type ModelNamespaceLister interface {
+	// List lists all Models in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*corev1alpha1.Model, err error)
+	// Get retrieves the Model from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*corev1alpha1.Model, error)
+	ModelNamespaceListerExpansion
+}
+
+// modelNamespaceLister implements the ModelNamespaceLister
+// interface.
+type modelNamespaceLister struct {
+	listers.ResourceIndexer[*corev1alpha1.Model]
+}
@@ -66,12 +66,15 @@ func main() {
 	var namespace string
 	var enableServiceActivator bool
 	var podIP string
+	var validateNamespacedModelSources bool
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.StringVar(&namespace, "namespace", "llmaz-system", "The namespace of the llmaz to deploy")
-	flag.BoolVar(&enableServiceActivator, "enable-service-activator", false, "Enable the service activator feature. This is an experimental feature.")
+	flag.BoolVar(&enableServiceActivator, "enable-service-activator", false, "Enable the service activator feature, only models opted in scaleToZero will be activated. This is an experimental feature.")
 	flag.StringVar(&podIP, "pod-ip", "", "The pod IP of the llmaz controller manager. Only used when service activator is enabled.")
+	flag.BoolVar(&validateNamespacedModelSources, "validate-namespaced-model-sources", false, "Validate the sources of the namespaced Models over the network. "+
+		"The requests are sent from the controller manager to the endpoints controlled by the namespace users, only enable it with trusted users.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
@@ -125,7 +128,7 @@ func main() {
 	// Cert won't be ready until manager starts, so start a goroutine here which
 	// will block until the cert is ready before setting up the controllers.
 	// Controllers who register after manager starts will start directly.
-	go setupControllers(mgr, certsReady, enableServiceActivator, podIP)
+	go setupControllers(mgr, certsReady, enableServiceActivator, podIP, validateNamespacedModelSources)
 
 	//+kubebuilder:scaffold:builder
 
@@ -145,31 +148,28 @@ func main() {
 	}
 }
 
-func setupControllers(mgr ctrl.Manager, certsReady chan struct{}, enableServiceActivator bool, podIP string) {
+func setupControllers(mgr ctrl.Manager, certsReady chan struct{}, enableServiceActivator bool, podIP string, validateNamespacedModelSources bool) {
 	// The controllers won't work until the webhooks are operating,
 	// and the webhook won't work until the certs are all in places.
 	setupLog.Info("waiting for the cert generation to complete")
 	<-certsReady
 	setupLog.Info("certs ready")
 
-	if err := (&corecontroller.ModelReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-	}).SetupWithManager(mgr); err != nil {
+	if err := corecontroller.NewModelReconciler(mgr.GetClient(), mgr.GetScheme(), mgr.GetEventRecorderFor("model")).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Model")
 		os.Exit(1)
 	}
-	if err := (&inferencecontroller.PlaygroundReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-	}).SetupWithManager(mgr); err != nil {
+	namespacedModelReconciler := corecontroller.NewNamespacedModelReconciler(mgr.GetClient(), mgr.GetScheme(), mgr.GetEventRecorderFor("namespaced-model"))
+	namespacedModelReconciler.ValidateSources = validateNamespacedModelSources
+	if err := namespacedModelReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "NamespacedModel")
+		os.Exit(1)
+	}
+	if err := inferencecontroller.NewPlaygroundReconciler(mgr.GetClient(), mgr.GetScheme(), mgr.GetEventRecorderFor("playground")).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Playground")
 		os.Exit(1)
 	}
-	if err := (&inferencecontroller.ServiceReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-	}).SetupWithManager(mgr); err != nil {
+	if err := inferencecontroller.NewServiceReconciler(mgr.GetClient(), mgr.GetScheme(), mgr.GetEventRecorderFor("service")).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Service")
 		os.Exit(1)
 	}
@@ -200,6 +200,10 @@ func setupControllers(mgr ctrl.Manager, certsReady chan struct{}, enableServiceA
 			setupLog.Error(err, "unable to create webhook", "webhook", "Model")
 			os.Exit(1)
 		}
+		if err := webhook.SetupModelWebhook(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "NamespacedModel")
+			os.Exit(1)
+		}
 		if err := webhook.SetupPlaygroundWebhook(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "Playground")
 			os.Exit(1)
@@ -14,20 +14,28 @@ See the License for the specific language governing permissions and
 limitations under the License.
 """
 
+import json
 import os
+import sys
 from datetime import datetime
 
 from llmaz.model_loader.constant import *
 
 from llmaz.model_loader.objstore.objstore import model_download
+from llmaz.model_loader.https import https
+from llmaz.model_loader.oci import oci
 from llmaz.model_loader.model_hub.hub_factory import HubFactory
 from llmaz.model_loader.model_hub.huggingface import HUB_HUGGING_FACE
+from llmaz.model_loader.verify import (
+    REASON_CHECKSUM_MISMATCH,
+    ChecksumMismatchError,
+    report_failure,
+    verify_checksums,
+)
 from llmaz.util.logger import Logger
 
-if __name__ == "__main__":
-    model_source_type = os.getenv(ENV_HUB_MODEL_SOURCE_TYPE)
-    start_time = datetime.now()
 
+def load_model(model_source_type: str) -> None:
     if model_source_type == "modelhub":
         hub_name = os.getenv(ENV_HUB_MODEL_HUB_NAME, HUB_HUGGING_FACE)
         revision = os.getenv(ENV_HUB_REVISION)
@@ -51,6 +59,10 @@ if __name__ == "__main__":
             model_allow_patterns_list,
             model_ignore_patterns_list,
         )
+        # The revision is pinned to a commit, whose files are immutable on the hub.
+        verify = os.getenv(ENV_HUB_VERIFY_CHECKSUMS) == "true"
+        if verify and hub_name == HUB_HUGGING_FACE:
+            hub.verify_model(model_id, model_file_name, revision)
     elif model_source_type == "objstore":
         provider = os.getenv(ENV_OBJ_PROVIDER)
         endpoint = os.getenv(ENV_OBJ_ENDPOINT)
@@ -58,9 +70,39 @@ if __name__ == "__main__":
         src = os.getenv(ENV_OBJ_MODEL_PATH)
 
         model_download(provider=provider, endpoint=endpoint, bucket=bucket, src=src)
+    elif model_source_type == "http":
+        url = os.getenv(ENV_HTTP_MODEL_URL)
+        checksum = os.getenv(ENV_HTTP_MODEL_CHECKSUM)
+
+        https.model_download(url=url, checksum=checksum)
+    elif model_source_type == "oci":
+        registry = os.getenv(ENV_OBJ_ENDPOINT)
+        repository = os.getenv(ENV_OBJ_MODEL_PATH)
+        reference = os.getenv(ENV_OCI_REFERENCE)
+
+        oci.model_download(
+            registry=registry, repository=repository, reference=reference
+        )
     else:
         raise EnvironmentError(f"unknown model source type {model_source_type}")
 
+
+if __name__ == "__main__":
+    model_source_type = os.getenv(ENV_HUB_MODEL_SOURCE_TYPE)
+    start_time = datetime.now()
+
+    try:
+        load_model(model_source_type)
+
+        checksums = os.getenv(ENV_MODEL_CHECKSUMS)
+        if checksums:
+            verify_checksums(os.getenv(ENV_MODEL_LOCAL_PATH), json.loads(checksums))
+    except ChecksumMismatchError as e:
+        # Retrying will not help, report the reason to the controller.
+        Logger.error(f"Failed to verify the model files: {e}")
+        report_failure(REASON_CHECKSUM_MISMATCH, str(e))
+        sys.exit(1)
+
     Logger.info(
         f"loading models from {model_source_type} takes {(datetime.now() - start_time).total_seconds()}s"
     )
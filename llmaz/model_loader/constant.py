@@ -9,8 +9,34 @@ ENV_HUB_MODEL_ID = "MODEL_ID"
 ENV_HUB_MODEL_FILENAME = "MODEL_FILENAME"
 ENV_HUB_MODEL_ALLOW_PATTERNS = "MODEL_ALLOW_PATTERNS"
 ENV_HUB_MODEL_IGNORE_PATTERNS = "MODEL_IGNORE_PATTERNS"
+ENV_HUB_VERIFY_CHECKSUMS = "VERIFY_HUB_CHECKSUMS"
 
 ENV_OBJ_PROVIDER = "PROVIDER"
 ENV_OBJ_ENDPOINT = "ENDPOINT"
 ENV_OBJ_BUCKET = "BUCKET"
 ENV_OBJ_MODEL_PATH = "MODEL_PATH"
+
+PROVIDER_S3 = "S3"
+PROVIDER_GCS = "GCS"
+PROVIDER_AZURE = "AZURE"
+
+ENV_AWS_REGION = "AWS_REGION"
+ENV_AWS_ENDPOINT_URL = "AWS_ENDPOINT_URL"
+ENV_AWS_S3_ADDRESSING_STYLE = "AWS_S3_ADDRESSING_STYLE"
+ENV_GCS_CREDENTIALS_FILE = "GCS_CREDENTIALS_FILE"
+ENV_AZURE_STORAGE_ACCOUNT_KEY = "AZURE_STORAGE_ACCOUNT_KEY"
+ENV_AZURE_STORAGE_SAS_TOKEN = "AZURE_STORAGE_SAS_TOKEN"
+ENV_AZURE_CLIENT_ID = "AZURE_CLIENT_ID"
+# Injected by the workload identity webhook of AKS.
+ENV_AZURE_FEDERATED_TOKEN_FILE = "AZURE_FEDERATED_TOKEN_FILE"
+
+ENV_HTTP_MODEL_URL = "MODEL_URL"
+ENV_HTTP_MODEL_CHECKSUM = "MODEL_CHECKSUM"
+ENV_HTTP_AUTH_HEADER = "HTTP_AUTH_HEADER"
+
+ENV_OCI_REFERENCE = "REFERENCE"
+ENV_OCI_USERNAME = "OCI_USERNAME"
+ENV_OCI_PASSWORD = "OCI_PASSWORD"
+
+ENV_MODEL_CHECKSUMS = "MODEL_CHECKSUMS"
+ENV_MODEL_LOCAL_PATH = "MODEL_LOCAL_PATH"
@@ -0,0 +1,80 @@
+"""
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+"""
+
+import hashlib
+import os
+from typing import Optional
+from urllib.parse import urlparse
+
+import requests
+
+from llmaz.model_loader.constant import ENV_HTTP_AUTH_HEADER, MODEL_LOCAL_DIR
+from llmaz.model_loader.objstore.util import downloaded, prepare_dir
+from llmaz.model_loader.verify import file_sha256
+from llmaz.util.logger import Logger
+
+CHUNK_SIZE = 1024 * 1024
+TIMEOUT = 60
+
+
+def model_download(url: str, checksum: Optional[str] = None):
+    session = requests.Session()
+    header = os.getenv(ENV_HTTP_AUTH_HEADER)
+    # requests drops the Authorization header once redirected to other hosts.
+    if header:
+        session.headers["Authorization"] = header
+
+    file_name = os.path.basename(urlparse(url).path)
+    download_file(session, url, MODEL_LOCAL_DIR + file_name, checksum)
+
+
+def download_file(
+    session: requests.Session, url: str, dest: str, checksum: Optional[str] = None
+) -> None:
+    """Download the file into dest, which is verified with the sha256 checksum if
+    provided. The file is written into a temporary path and moved to dest once
+    completed, so a corrupted file will never be served."""
+    if checksum and os.path.isfile(dest) and file_sha256(dest) == checksum:
+        Logger.info(f"Skip downloading {url}, already exists")
+        return
+
+    with session.get(url, stream=True, timeout=TIMEOUT) as resp:
+        resp.raise_for_status()
+
+        size = resp.headers.get("Content-Length")
+        # Without the checksum, files with the same size are regarded as downloaded.
+        if not checksum and size and downloaded(dest, int(size)):
+            Logger.info(f"Skip downloading {url}, already exists")
+            return
+
+        prepare_dir(dest)
+        tmp = dest + ".part"
+        hasher = hashlib.sha256()
+        try:
+            with open(tmp, "wb") as f:
+                for chunk in resp.iter_content(chunk_size=CHUNK_SIZE):
+                    hasher.update(chunk)
+                    f.write(chunk)
+            if checksum and hasher.hexdigest() != checksum:
+                raise ValueError(
+                    f"checksum mismatch of {url}, "
+                    f"expected sha256 {checksum}, got {hasher.hexdigest()}"
+                )
+            os.replace(tmp, dest)
+        finally:
+            if os.path.exists(tmp):
+                os.remove(tmp)
+
@@ -17,7 +17,7 @@ limitations under the License.
 import concurrent.futures
 import os
 
-from huggingface_hub import snapshot_download
+from huggingface_hub import HfApi, snapshot_download
 
 from llmaz.model_loader.constant import MODEL_LOCAL_DIR, HUB_HUGGING_FACE
 from llmaz.model_loader.model_hub.model_hub import (
@@ -25,6 +25,7 @@ from llmaz.model_loader.model_hub.model_hub import (
 )
 from llmaz.util.logger import Logger
 from llmaz.model_loader.model_hub.util import get_folder_total_size
+from llmaz.model_loader.verify import verify_hub_files
 
 from typing import Optional, List
 
@@ -47,13 +48,9 @@ class Huggingface(ModelHub):
             f"Start to download, model_id: {model_id}, filename: {filename}, revision: {revision}"
         )
 
-        local_dir = os.path.join(
-            MODEL_LOCAL_DIR, f"models--{model_id.replace('/', '--')}"
-        )
-
+        local_dir = cls.local_dir(model_id, filename)
         if filename:
             allow_patterns = [filename]
-            local_dir = MODEL_LOCAL_DIR
 
         snapshot_download(
             repo_id=model_id,
@@ -65,3 +62,27 @@ class Huggingface(ModelHub):
 
         total_size = get_folder_total_size(local_dir)
         Logger.info(f"The total size of {local_dir} is {total_size: .2f} GB")
+
+    @classmethod
+    def local_dir(cls, model_id: str, filename: Optional[str]) -> str:
+        if filename:
+            return MODEL_LOCAL_DIR
+        return os.path.join(MODEL_LOCAL_DIR, f"models--{model_id.replace('/', '--')}")
+
+    @classmethod
+    def verify_model(
+        cls, model_id: str, filename: Optional[str], revision: str
+    ) -> None:
+        """Verify the downloaded files against the metadata of the revision, which
+        should be a commit, otherwise the files may change since downloaded."""
+        info = HfApi().model_info(model_id, revision=revision, files_metadata=True)
+        files = [
+            (
+                sibling.rfilename,
+                sibling.lfs.sha256 if sibling.lfs else None,
+                sibling.blob_id,
+            )
+            for sibling in info.siblings or []
+            if not filename or sibling.rfilename == filename
+        ]
+        verify_hub_files(cls.local_dir(model_id, filename), files)
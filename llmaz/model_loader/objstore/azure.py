@@ -0,0 +1,105 @@
+"""
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+"""
+
+import os
+from urllib.parse import urlparse
+
+from azure.core.credentials import AzureNamedKeyCredential
+from azure.core.exceptions import ResourceNotFoundError
+from azure.identity import DefaultAzureCredential
+from azure.storage.blob import ContainerClient
+
+from llmaz.model_loader.constant import (
+    ENV_AZURE_CLIENT_ID,
+    ENV_AZURE_FEDERATED_TOKEN_FILE,
+    ENV_AZURE_STORAGE_ACCOUNT_KEY,
+    ENV_AZURE_STORAGE_SAS_TOKEN,
+)
+from llmaz.model_loader.objstore.util import downloaded, prepare_dir
+from llmaz.util.logger import Logger
+
+
+class AzureClient:
+    """AzureClient downloads the models from Azure Blob Storage with the account key
+    or the SAS token if provided, or the service principal and the workload identity,
+    or anonymously for the public containers."""
+
+    def __init__(self, endpoint: str, bucket: str):
+        self.container = new_container_client(endpoint, bucket)
+
+    def download(self, src: str, dest: str) -> None:
+        try:
+            size = self.container.get_blob_client(src).get_blob_properties().size
+        except ResourceNotFoundError:
+            raise FileNotFoundError(
+                f"blob {self.container.container_name}/{src} not found"
+            )
+        self._download(src, size, dest)
+
+    def download_dir(self, src: str, dest: str) -> None:
+        prefix = src.rstrip("/") + "/"
+        found = False
+
+        for blob in self.container.list_blobs(
+            name_starts_with=prefix, include=["metadata"]
+        ):
+            # Skip the directory placeholders of the hierarchical namespace.
+            if blob.name.endswith("/") or _is_directory(blob):
+                continue
+            found = True
+            self._download(
+                blob.name, blob.size, os.path.join(dest, blob.name[len(prefix) :])
+            )
+
+        if not found:
+            raise FileNotFoundError(
+                f"no blobs found under {self.container.container_name}/{prefix}"
+            )
+
+    def _download(self, name: str, size: int, dest: str) -> None:
+        if downloaded(dest, size):
+            container = self.container.container_name
+            Logger.info(f"Skip downloading {container}/{name}, already exists")
+            return
+        prepare_dir(dest)
+        with open(dest, "wb") as f:
+            self.container.download_blob(name).readinto(f)
+
+
+def _is_directory(blob) -> bool:
+    # ADLS Gen2 marks the directories with the hdi_isfolder metadata.
+    return (blob.metadata or {}).get("hdi_isfolder") == "true"
+
+
+def new_container_client(endpoint: str, bucket: str) -> ContainerClient:
+    return ContainerClient(
+        account_url=endpoint, container_name=bucket, credential=new_credential(endpoint)
+    )
+
+
+def new_credential(endpoint: str):
+    # All the keys of the secret are optional, the first one found wins.
+    account_key = os.getenv(ENV_AZURE_STORAGE_ACCOUNT_KEY)
+    if account_key:
+        account = urlparse(endpoint).hostname.split(".")[0]
+        return AzureNamedKeyCredential(account, account_key)
+    sas_token = os.getenv(ENV_AZURE_STORAGE_SAS_TOKEN)
+    if sas_token:
+        return sas_token.lstrip("?")
+    if os.getenv(ENV_AZURE_CLIENT_ID) or os.getenv(ENV_AZURE_FEDERATED_TOKEN_FILE):
+        return DefaultAzureCredential()
+    Logger.info("No Azure credentials found, access the container anonymously")
+    return None
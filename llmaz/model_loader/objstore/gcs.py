@@ -0,0 +1,76 @@
+"""
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+"""
+
+import os
+
+from google.auth.exceptions import DefaultCredentialsError
+from google.cloud import storage
+
+from llmaz.model_loader.constant import ENV_GCS_CREDENTIALS_FILE
+from llmaz.model_loader.objstore.util import downloaded, prepare_dir
+from llmaz.util.logger import Logger
+
+
+class GCSClient:
+    """GCSClient downloads the models from Google Cloud Storage with the service
+    account key if mounted, or the application default credentials like the workload
+    identity, or anonymously for the public buckets."""
+
+    def __init__(self, bucket: str):
+        self.bucket = new_storage_client().bucket(bucket)
+
+    def download(self, src: str, dest: str) -> None:
+        blob = self.bucket.get_blob(src)
+        if blob is None:
+            raise FileNotFoundError(f"object gs://{self.bucket.name}/{src} not found")
+        self._download(blob, dest)
+
+    def download_dir(self, src: str, dest: str) -> None:
+        prefix = src.rstrip("/") + "/"
+        found = False
+
+        for blob in self.bucket.list_blobs(prefix=prefix):
+            # Skip the directory placeholders.
+            if blob.name.endswith("/"):
+                continue
+            found = True
+            self._download(blob, os.path.join(dest, blob.name[len(prefix) :]))
+
+        if not found:
+            raise FileNotFoundError(
+                f"no objects found under gs://{self.bucket.name}/{prefix}"
+            )
+
+    def _download(self, blob, dest: str) -> None:
+        if downloaded(dest, blob.size):
+            Logger.info(
+                f"Skip downloading gs://{self.bucket.name}/{blob.name}, already exists"
+            )
+            return
+        prepare_dir(dest)
+        blob.download_to_filename(dest)
+
+
+def new_storage_client() -> storage.Client:
+    credentials_file = os.getenv(ENV_GCS_CREDENTIALS_FILE)
+    # The secret of the service account key is optional, so the file may not exist.
+    if credentials_file and os.path.isfile(credentials_file):
+        return storage.Client.from_service_account_json(credentials_file)
+    try:
+        return storage.Client()
+    except (DefaultCredentialsError, OSError):
+        Logger.info("No GCS credentials found, access the bucket anonymously")
+        return storage.Client.create_anonymous_client()
@@ -1,12 +1,34 @@
 from omnistore.objstore import StoreFactory
 
-from llmaz.model_loader.constant import MODEL_LOCAL_DIR
+from llmaz.model_loader.constant import (
+    MODEL_LOCAL_DIR,
+    PROVIDER_AZURE,
+    PROVIDER_GCS,
+    PROVIDER_S3,
+)
+
+
+def new_client(provider: str, endpoint: str, bucket: str):
+    # S3, GCS and Azure are served natively to support the custom endpoints and
+    # the credentials of the cloud providers, others are served by omnistore.
+    # The SDKs are imported lazily, so loading from other sources doesn't rely on them.
+    if provider == PROVIDER_S3:
+        from llmaz.model_loader.objstore.s3 import S3Client
+
+        return S3Client(bucket=bucket)
+    if provider == PROVIDER_GCS:
+        from llmaz.model_loader.objstore.gcs import GCSClient
+
+        return GCSClient(bucket=bucket)
+    if provider == PROVIDER_AZURE:
+        from llmaz.model_loader.objstore.azure import AzureClient
+
+        return AzureClient(endpoint=endpoint, bucket=bucket)
+    return StoreFactory.new_client(provider=provider, endpoint=endpoint, bucket=bucket)
 
 
 def model_download(provider: str, endpoint: str, bucket: str, src: str):
-    client = StoreFactory.new_client(
-        provider=provider, endpoint=endpoint, bucket=bucket
-    )
+    client = new_client(provider=provider, endpoint=endpoint, bucket=bucket)
 
     model_name = src.split("/")[-1]
     # Such as GGUF model
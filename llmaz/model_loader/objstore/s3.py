@@ -0,0 +1,75 @@
+"""
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+"""
+
+import os
+
+import boto3
+from botocore.config import Config
+
+from llmaz.model_loader.constant import (
+    ENV_AWS_ENDPOINT_URL,
+    ENV_AWS_REGION,
+    ENV_AWS_S3_ADDRESSING_STYLE,
+)
+from llmaz.model_loader.objstore.util import downloaded, prepare_dir
+from llmaz.util.logger import Logger
+
+
+class S3Client:
+    """S3Client downloads the models from S3 or the S3 compatible object stores,
+    e.g. MinIO. Credentials are resolved by boto3, e.g. AWS_ACCESS_KEY_ID and
+    AWS_SECRET_ACCESS_KEY, or the IAM roles for service accounts."""
+
+    def __init__(self, bucket: str):
+        self.bucket = bucket
+        addressing_style = os.getenv(ENV_AWS_S3_ADDRESSING_STYLE) or "auto"
+        self.client = boto3.client(
+            "s3",
+            endpoint_url=os.getenv(ENV_AWS_ENDPOINT_URL) or None,
+            region_name=os.getenv(ENV_AWS_REGION) or None,
+            config=Config(s3={"addressing_style": addressing_style}),
+        )
+
+    def download(self, src: str, dest: str) -> None:
+        size = self.client.head_object(Bucket=self.bucket, Key=src)["ContentLength"]
+        self._download(src, dest, size)
+
+    def download_dir(self, src: str, dest: str) -> None:
+        prefix = src.rstrip("/") + "/"
+        found = False
+
+        paginator = self.client.get_paginator("list_objects_v2")
+        for page in paginator.paginate(Bucket=self.bucket, Prefix=prefix):
+            for obj in page.get("Contents", []):
+                key = obj["Key"]
+                # Skip the directory placeholders.
+                if key.endswith("/"):
+                    continue
+                found = True
+                path = os.path.join(dest, key[len(prefix) :])
+                self._download(key, path, obj["Size"])
+
+        if not found:
+            raise FileNotFoundError(
+                f"no objects found under s3://{self.bucket}/{prefix}"
+            )
+
+    def _download(self, key: str, dest: str, size: int) -> None:
+        if downloaded(dest, size):
+            Logger.info(f"Skip downloading s3://{self.bucket}/{key}, already exists")
+            return
+        prepare_dir(dest)
+        self.client.download_file(self.bucket, key, dest)
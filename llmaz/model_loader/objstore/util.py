@@ -0,0 +1,29 @@
+"""
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+"""
+
+import os
+
+
+def downloaded(path: str, size: int) -> bool:
+    """Whether the object is downloaded already, e.g. by a previous attempt or
+    another Pod sharing the model cache, regarded as complete if sizes are equal."""
+    return os.path.isfile(path) and os.path.getsize(path) == size
+
+
+def prepare_dir(path: str) -> None:
+    directory = os.path.dirname(path)
+    if directory:
+        os.makedirs(directory, exist_ok=True)
@@ -0,0 +1,182 @@
+"""
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+"""
+
+import os
+import tarfile
+from typing import Optional
+
+import requests
+
+from llmaz.model_loader.constant import (
+    ENV_OCI_PASSWORD,
+    ENV_OCI_USERNAME,
+    MODEL_LOCAL_DIR,
+)
+from llmaz.model_loader.https.https import TIMEOUT, download_file
+from llmaz.util.logger import Logger
+
+MEDIA_TYPE_OCI_MANIFEST = "application/vnd.oci.image.manifest.v1+json"
+MEDIA_TYPE_OCI_INDEX = "application/vnd.oci.image.index.v1+json"
+MEDIA_TYPE_DOCKER_MANIFEST = "application/vnd.docker.distribution.manifest.v2+json"
+MEDIA_TYPE_DOCKER_LIST = "application/vnd.docker.distribution.manifest.list.v2+json"
+
+# The file name of the layer, set by the tools like ORAS.
+ANNOTATION_TITLE = "org.opencontainers.image.title"
+# Directories are pushed as tarballs by ORAS, which should be unpacked.
+ANNOTATION_UNPACK = "io.deis.oras.content.unpack"
+
+
+def model_download(registry: str, repository: str, reference: str):
+    client = OCIClient(registry, repository)
+    client.download(
+        reference, MODEL_LOCAL_DIR + "models--" + repository.split("/")[-1]
+    )
+
+
+class OCIClient:
+    """OCIClient pulls the model files stored as the layers of the OCI artifacts
+    following the OCI distribution spec, the registries are accessed anonymously
+    or with OCI_USERNAME and OCI_PASSWORD."""
+
+    def __init__(self, registry: str, repository: str, scheme: str = "https"):
+        self.repository = repository
+        self.base_url = f"{scheme}://{registry}/v2/{repository}"
+        self.username = os.getenv(ENV_OCI_USERNAME)
+        self.password = os.getenv(ENV_OCI_PASSWORD)
+        self.session = requests.Session()
+
+    def download(self, reference: str, dest: str) -> None:
+        manifest = self.manifest(reference)
+        found = False
+
+        for layer in manifest.get("layers", []):
+            annotations = layer.get("annotations") or {}
+            name = annotations.get(ANNOTATION_TITLE)
+            if not name:
+                Logger.info(f"Skip the layer {layer['digest']} without a title")
+                continue
+            found = True
+
+            url = f"{self.base_url}/blobs/{layer['digest']}"
+            checksum = sha256_of(layer["digest"])
+            if annotations.get(ANNOTATION_UNPACK) == "true":
+                tarball = os.path.join(dest, f".{name}.tar.gz")
+                download_file(self.session, url, tarball, checksum)
+                unpack(tarball, dest)
+                os.remove(tarball)
+            else:
+                download_file(self.session, url, safe_join(dest, name), checksum)
+
+        if not found:
+            raise FileNotFoundError(
+                f"no model files found in {self.repository}:{reference}"
+            )
+
+    def manifest(self, reference: str) -> dict:
+        resp = self._get(
+            f"{self.base_url}/manifests/{reference}",
+            headers={
+                "Accept": ", ".join(
+                    [
+                        MEDIA_TYPE_OCI_MANIFEST,
+                        MEDIA_TYPE_OCI_INDEX,
+                        MEDIA_TYPE_DOCKER_MANIFEST,
+                        MEDIA_TYPE_DOCKER_LIST,
+                    ]
+                )
+            },
+        )
+        manifest = resp.json()
+
+        media_type = manifest.get("mediaType") or resp.headers.get("Content-Type")
+        if media_type in (MEDIA_TYPE_OCI_INDEX, MEDIA_TYPE_DOCKER_LIST):
+            # Model files are platform independent, take the first manifest.
+            manifests = manifest.get("manifests") or []
+            if not manifests:
+                raise FileNotFoundError(
+                    f"no manifests found in {self.repository}:{reference}"
+                )
+            return self.manifest(manifests[0]["digest"])
+        return manifest
+
+    def _get(self, url: str, headers: Optional[dict] = None) -> requests.Response:
+        resp = self.session.get(url, headers=headers, timeout=TIMEOUT)
+        if resp.status_code == 401:
+            self._authorize(resp.headers.get("WWW-Authenticate", ""))
+            resp = self.session.get(url, headers=headers, timeout=TIMEOUT)
+        resp.raise_for_status()
+        return resp
+
+    def _authorize(self, challenge: str) -> None:
+        """Answer the challenge of the registry, the token is kept in the session
+        and dropped by requests once redirected to other hosts, e.g. the CDNs."""
+        scheme, _, params = challenge.partition(" ")
+        credentials = None
+        if self.username or self.password:
+            credentials = (self.username or "", self.password or "")
+
+        if scheme.lower() == "basic" and credentials:
+            self.session.auth = credentials
+            return
+        if scheme.lower() != "bearer":
+            return
+
+        values = parse_challenge_params(params)
+        query = {key: values[key] for key in ("service", "scope") if key in values}
+        resp = requests.get(
+            values.get("realm", ""), params=query, auth=credentials, timeout=TIMEOUT
+        )
+        resp.raise_for_status()
+        token = resp.json()
+        token = token.get("token") or token.get("access_token")
+        if token:
+            self.session.headers["Authorization"] = f"Bearer {token}"
+
+
+def parse_challenge_params(params: str) -> dict:
+    """Parse the params of the WWW-Authenticate header, e.g.
+    realm="https://ghcr.io/token",service="ghcr.io",scope="repository:a/b:pull"."""
+    values = {}
+    while params:
+        key, _, params = params.lstrip(", ").partition("=")
+        if params.startswith('"'):
+            value, _, params = params[1:].partition('"')
+        else:
+            value, _, params = params.partition(",")
+        values[key.strip().lower()] = value
+    return values
+
+
+def sha256_of(digest: str) -> Optional[str]:
+    algorithm, _, value = digest.partition(":")
+    return value if algorithm == "sha256" else None
+
+
+def safe_join(dest: str, name: str) -> str:
+    root = os.path.abspath(dest)
+    path = os.path.abspath(os.path.join(dest, name))
+    if os.path.commonpath([root, path]) != root:
+        raise ValueError(f"layer title {name} is out of the model directory")
+    return path
+
+
+def unpack(tarball: str, dest: str) -> None:
+    with tarfile.open(tarball) as tar:
+        for member in tar.getmembers():
+            if not (member.isfile() or member.isdir()):
+                raise ValueError(f"unsupported member {member.name} in {tarball}")
+            safe_join(dest, member.name)
+        tar.extractall(dest)
@@ -0,0 +1,104 @@
+"""
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+"""
+
+import hashlib
+import os
+from typing import Dict, Iterable, Optional, Tuple
+
+from llmaz.util.logger import Logger
+
+# Reported in the termination message, recognized by the controller.
+REASON_CHECKSUM_MISMATCH = "ChecksumMismatch"
+TERMINATION_LOG = "/dev/termination-log"
+
+CHUNK_SIZE = 1024 * 1024
+
+
+class ChecksumMismatchError(Exception):
+    pass
+
+
+def verify_checksums(path: str, checksums: Dict[str, str]) -> None:
+    """Verify the files against the sha256 checksums, the file paths are relative
+    to the model directory, or to the parent directory if the model is a file."""
+    base = path if os.path.isdir(path) else os.path.dirname(path)
+    for name, expected in sorted(checksums.items()):
+        file_path = local_file(base, name)
+        if not os.path.isfile(file_path):
+            raise ChecksumMismatchError(f"{name} not found in {base}")
+        actual = file_sha256(file_path)
+        if actual != expected.lower():
+            raise ChecksumMismatchError(
+                f"{name} expected sha256 {expected.lower()}, got {actual}"
+            )
+    Logger.info(f"Verified the checksums of {len(checksums)} files in {base}")
+
+
+def verify_hub_files(
+    local_dir: str, files: Iterable[Tuple[str, Optional[str], Optional[str]]]
+) -> None:
+    """Verify the downloaded files against the metadata of the model hub, each
+    file is a tuple of the path, the sha256 of the LFS file and the git blob id.
+    Files not downloaded, e.g. filtered by the patterns, are skipped."""
+    verified = 0
+    for name, lfs_sha256, blob_id in files:
+        file_path = local_file(local_dir, name)
+        if not os.path.isfile(file_path):
+            continue
+        if lfs_sha256:
+            expected, actual = lfs_sha256, file_sha256(file_path)
+        elif blob_id:
+            expected, actual = blob_id, git_blob_sha1(file_path)
+        else:
+            continue
+        if actual != expected:
+            raise ChecksumMismatchError(f"{name} expected {expected}, got {actual}")
+        verified += 1
+    Logger.info(f"Verified {verified} files in {local_dir} against the model hub")
+
+
+def local_file(base: str, name: str) -> str:
+    file_path = os.path.normpath(os.path.join(base, name))
+    if os.path.commonpath([base, file_path]) != os.path.normpath(base):
+        raise ChecksumMismatchError(f"{name} is out of {base}")
+    return file_path
+
+
+def file_sha256(path: str) -> str:
+    hasher = hashlib.sha256()
+    with open(path, "rb") as f:
+        for chunk in iter(lambda: f.read(CHUNK_SIZE), b""):
+            hasher.update(chunk)
+    return hasher.hexdigest()
+
+
+def git_blob_sha1(path: str) -> str:
+    """The object id of the file in git, used by the hub for the non-LFS files."""
+    hasher = hashlib.sha1()
+    hasher.update(f"blob {os.path.getsize(path)}\0".encode())
+    with open(path, "rb") as f:
+        for chunk in iter(lambda: f.read(CHUNK_SIZE), b""):
+            hasher.update(chunk)
+    return hasher.hexdigest()
+
+
+def report_failure(reason: str, message: str) -> None:
+    """Write the failure into the termination message of the container."""
+    try:
+        with open(TERMINATION_LOG, "w") as f:
+            f.write(f"{reason}: {message}")
+    except OSError as e:
+        Logger.error(f"Failed to write the termination message, err is {e}")
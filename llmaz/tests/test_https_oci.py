@@ -0,0 +1,179 @@
+"""
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+"""
+
+import hashlib
+import io
+import json
+import tarfile
+import threading
+from http.server import BaseHTTPRequestHandler, ThreadingHTTPServer
+
+import pytest
+
+from llmaz.model_loader.https import https
+from llmaz.model_loader.oci.oci import OCIClient
+
+MODEL = b"gguf weights"
+CONFIG = b"{}"
+
+
+def sha256(data: bytes) -> str:
+    return hashlib.sha256(data).hexdigest()
+
+
+def tarball(name: str, files: dict) -> bytes:
+    buf = io.BytesIO()
+    with tarfile.open(fileobj=buf, mode="w:gz") as tar:
+        for path, data in files.items():
+            info = tarfile.TarInfo(f"{name}/{path}")
+            info.size = len(data)
+            tar.addfile(info, io.BytesIO(data))
+    return buf.getvalue()
+
+
+TOKENIZER = tarball("tokenizer", {"tokenizer.json": b"[]"})
+
+BLOBS = {f"sha256:{sha256(data)}": data for data in (MODEL, CONFIG, TOKENIZER)}
+
+MANIFEST = json.dumps(
+    {
+        "schemaVersion": 2,
+        "mediaType": "application/vnd.oci.image.manifest.v1+json",
+        "config": {"digest": f"sha256:{sha256(CONFIG)}", "size": len(CONFIG)},
+        "layers": [
+            {
+                "digest": f"sha256:{sha256(MODEL)}",
+                "annotations": {"org.opencontainers.image.title": "qwen2.gguf"},
+            },
+            {
+                "digest": f"sha256:{sha256(CONFIG)}",
+                "annotations": {"org.opencontainers.image.title": "config.json"},
+            },
+            {
+                "digest": f"sha256:{sha256(TOKENIZER)}",
+                "annotations": {
+                    "org.opencontainers.image.title": "tokenizer",
+                    "io.deis.oras.content.unpack": "true",
+                },
+            },
+        ],
+    }
+).encode()
+
+INDEX = json.dumps(
+    {
+        "schemaVersion": 2,
+        "mediaType": "application/vnd.oci.image.index.v1+json",
+        "manifests": [{"digest": f"sha256:{sha256(MANIFEST)}"}],
+    }
+).encode()
+
+
+class Handler(BaseHTTPRequestHandler):
+    def log_message(self, *args):
+        pass
+
+    def reply(self, status: int, body: bytes = b"", headers: dict = None):
+        self.send_response(status)
+        for key, value in (headers or {}).items():
+            self.send_header(key, value)
+        self.send_header("Content-Length", str(len(body)))
+        self.end_headers()
+        self.wfile.write(body)
+
+    def do_GET(self):
+        port = self.server.server_address[1]
+        path = self.path.split("?")[0]
+
+        # HTTPS files
+        if path == "/models/qwen2.gguf":
+            if self.headers.get("Authorization") != "Bearer http-token":
+                return self.reply(401)
+            return self.reply(200, MODEL)
+
+        # OCI registry
+        if path == "/token":
+            if "scope=repository%3Ainftyai%2Fqwen2%3Apull" not in self.path:
+                return self.reply(400)
+            return self.reply(200, json.dumps({"token": "registry-token"}).encode())
+        if self.headers.get("Authorization") != "Bearer registry-token":
+            challenge = (
+                f'Bearer realm="http://127.0.0.1:{port}/token",'
+                'service="registry",scope="repository:inftyai/qwen2:pull"'
+            )
+            return self.reply(401, headers={"WWW-Authenticate": challenge})
+        if path == "/v2/inftyai/qwen2/manifests/latest":
+            return self.reply(200, INDEX)
+        if path == f"/v2/inftyai/qwen2/manifests/sha256:{sha256(MANIFEST)}":
+            return self.reply(200, MANIFEST)
+        if path.startswith("/v2/inftyai/qwen2/blobs/"):
+            digest = path.rsplit("/", 1)[-1]
+            if digest in BLOBS:
+                return self.reply(200, BLOBS[digest])
+        self.reply(404)
+
+
+@pytest.fixture(scope="module")
+def server():
+    server = ThreadingHTTPServer(("127.0.0.1", 0), Handler)
+    thread = threading.Thread(target=server.serve_forever, daemon=True)
+    thread.start()
+    yield f"127.0.0.1:{server.server_address[1]}"
+    server.shutdown()
+
+
+class TestHTTPS:
+    @pytest.fixture(autouse=True)
+    def local_dir(self, tmp_path, monkeypatch):
+        monkeypatch.setattr(https, "MODEL_LOCAL_DIR", str(tmp_path) + "/")
+        monkeypatch.setenv("HTTP_AUTH_HEADER", "Bearer http-token")
+        self.dir = tmp_path
+
+    def test_download_with_checksum(self, server):
+        https.model_download(f"http://{server}/models/qwen2.gguf", sha256(MODEL))
+
+        assert (self.dir / "qwen2.gguf").read_bytes() == MODEL
+
+    def test_checksum_mismatch(self, server):
+        with pytest.raises(ValueError, match="checksum mismatch"):
+            https.model_download(f"http://{server}/models/qwen2.gguf", sha256(b""))
+
+        # Corrupted files are never left in the model path.
+        assert list(self.dir.iterdir()) == []
+
+    def test_unauthorized(self, server, monkeypatch):
+        monkeypatch.delenv("HTTP_AUTH_HEADER")
+        with pytest.raises(Exception, match="401"):
+            https.model_download(f"http://{server}/models/qwen2.gguf")
+
+
+class TestOCI:
+    def test_download(self, server, tmp_path):
+        client = OCIClient(server, "inftyai/qwen2", scheme="http")
+        client.download("latest", str(tmp_path))
+
+        assert (tmp_path / "qwen2.gguf").read_bytes() == MODEL
+        assert (tmp_path / "config.json").read_bytes() == CONFIG
+        assert (tmp_path / "tokenizer" / "tokenizer.json").read_bytes() == b"[]"
+        assert not (tmp_path / ".tokenizer.tar.gz").exists()
+
+        # Downloaded files are verified and skipped.
+        client.download("latest", str(tmp_path))
+
+    def test_not_found(self, server, tmp_path):
+        client = OCIClient(server, "inftyai/qwen2", scheme="http")
+        with pytest.raises(Exception, match="404"):
+            client.download("v2", str(tmp_path))
@@ -0,0 +1,240 @@
+"""
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+"""
+
+import os
+
+import boto3
+import pytest
+from moto.server import ThreadedMotoServer
+
+from llmaz.model_loader.objstore import objstore
+from azure.core.exceptions import ResourceNotFoundError
+
+from llmaz.model_loader.objstore.azure import AzureClient
+from llmaz.model_loader.objstore.gcs import GCSClient
+
+BUCKET = "models"
+
+
+@pytest.fixture
+def s3_server(monkeypatch):
+    # A local S3 compatible server, like MinIO, served with path style requests.
+    server = ThreadedMotoServer(ip_address="127.0.0.1", port=0, verbose=False)
+    server.start()
+    host, port = server.get_host_and_port()
+    endpoint = f"http://{host}:{port}"
+
+    monkeypatch.setenv("AWS_ACCESS_KEY_ID", "access-key")
+    monkeypatch.setenv("AWS_SECRET_ACCESS_KEY", "secret-key")
+    monkeypatch.setenv("AWS_REGION", "us-east-1")
+    monkeypatch.setenv("AWS_ENDPOINT_URL", endpoint)
+    monkeypatch.setenv("AWS_S3_ADDRESSING_STYLE", "path")
+
+    client = boto3.client("s3", endpoint_url=endpoint, region_name="us-east-1")
+    client.create_bucket(Bucket=BUCKET)
+    client.put_object(Bucket=BUCKET, Key="llama3/config.json", Body=b"{}")
+    client.put_object(Bucket=BUCKET, Key="llama3/weights/model.safetensors", Body=b"w")
+    client.put_object(Bucket=BUCKET, Key="llama3/weights/", Body=b"")
+    client.put_object(Bucket=BUCKET, Key="llama3-70b/config.json", Body=b"{}")
+    client.put_object(Bucket=BUCKET, Key="gguf/qwen2.gguf", Body=b"gguf")
+    yield client
+    server.stop()
+
+
+@pytest.fixture
+def local_dir(tmp_path, monkeypatch):
+    monkeypatch.setattr(objstore, "MODEL_LOCAL_DIR", str(tmp_path) + "/")
+    return tmp_path
+
+
+class TestS3:
+    def test_download_dir(self, s3_server, local_dir):
+        objstore.model_download("S3", "", BUCKET, "llama3")
+
+        model_dir = local_dir / "models--llama3"
+        assert (model_dir / "config.json").read_bytes() == b"{}"
+        assert (model_dir / "weights" / "model.safetensors").read_bytes() == b"w"
+        # Objects of other models sharing the prefix are not downloaded.
+        assert sorted(os.listdir(model_dir)) == ["config.json", "weights"]
+
+    def test_download_file(self, s3_server, local_dir):
+        objstore.model_download("S3", "", BUCKET, "gguf/qwen2.gguf")
+
+        assert (local_dir / "qwen2.gguf").read_bytes() == b"gguf"
+
+    def test_skip_downloaded(self, s3_server, local_dir):
+        objstore.model_download("S3", "", BUCKET, "llama3")
+        s3_server.delete_object(Bucket=BUCKET, Key="llama3/config.json")
+        s3_server.put_object(Bucket=BUCKET, Key="llama3/config.json", Body=b"[]")
+
+        objstore.model_download("S3", "", BUCKET, "llama3")
+
+        # Files with the same size are regarded as downloaded.
+        assert (local_dir / "models--llama3" / "config.json").read_bytes() == b"{}"
+
+    def test_not_found(self, s3_server, local_dir):
+        with pytest.raises(FileNotFoundError):
+            objstore.model_download("S3", "", BUCKET, "qwen2")
+
+
+class FakeBlob:
+    def __init__(self, name: str, data: bytes):
+        self.name = name
+        self.data = data
+        self.size = len(data)
+
+    def download_to_filename(self, filename: str):
+        with open(filename, "wb") as f:
+            f.write(self.data)
+
+
+class FakeBucket:
+    name = BUCKET
+
+    def __init__(self, blobs):
+        self.blobs = {blob.name: blob for blob in blobs}
+
+    def get_blob(self, name):
+        return self.blobs.get(name)
+
+    def list_blobs(self, prefix):
+        return [blob for name, blob in self.blobs.items() if name.startswith(prefix)]
+
+
+class TestGCS:
+    @pytest.fixture
+    def gcs_client(self, monkeypatch):
+        bucket = FakeBucket(
+            [
+                FakeBlob("llama3/config.json", b"{}"),
+                FakeBlob("llama3/weights/model.safetensors", b"w"),
+                FakeBlob("gguf/qwen2.gguf", b"gguf"),
+            ]
+        )
+
+        class FakeStorageClient:
+            def bucket(self, name):
+                assert name == BUCKET
+                return bucket
+
+        monkeypatch.setattr(
+            "llmaz.model_loader.objstore.gcs.new_storage_client",
+            lambda: FakeStorageClient(),
+        )
+        return GCSClient(BUCKET)
+
+    def test_download_dir(self, gcs_client, tmp_path):
+        gcs_client.download_dir("llama3", str(tmp_path / "models--llama3"))
+
+        model_dir = tmp_path / "models--llama3"
+        assert (model_dir / "config.json").read_bytes() == b"{}"
+        assert (model_dir / "weights" / "model.safetensors").read_bytes() == b"w"
+
+    def test_download_file(self, gcs_client, tmp_path):
+        gcs_client.download("gguf/qwen2.gguf", str(tmp_path / "qwen2.gguf"))
+
+        assert (tmp_path / "qwen2.gguf").read_bytes() == b"gguf"
+
+    def test_not_found(self, gcs_client, tmp_path):
+        with pytest.raises(FileNotFoundError):
+            gcs_client.download_dir("qwen2", str(tmp_path / "models--qwen2"))
+        with pytest.raises(FileNotFoundError):
+            gcs_client.download("gguf/qwen2-7b.gguf", str(tmp_path / "qwen2-7b.gguf"))
+
+
+class FakeBlobProperties:
+    def __init__(self, name, data, metadata=None):
+        self.name = name
+        self.data = data
+        self.size = len(data)
+        self.metadata = metadata
+
+
+class FakeContainerClient:
+    container_name = BUCKET
+
+    def __init__(self, blobs):
+        self.blobs = {blob.name: blob for blob in blobs}
+
+    def get_blob_client(self, name):
+        container = self
+
+        class FakeBlobClient:
+            def get_blob_properties(self):
+                if name not in container.blobs:
+                    raise ResourceNotFoundError("blob not found")
+                return container.blobs[name]
+
+        return FakeBlobClient()
+
+    def list_blobs(self, name_starts_with, include=None):
+        return [b for n, b in self.blobs.items() if n.startswith(name_starts_with)]
+
+    def download_blob(self, name):
+        data = self.blobs[name].data
+
+        class FakeDownloader:
+            def readinto(self, stream):
+                return stream.write(data)
+
+        return FakeDownloader()
+
+
+class TestAzure:
+    @pytest.fixture
+    def azure_client(self, monkeypatch):
+        container = FakeContainerClient(
+            [
+                FakeBlobProperties("llama3/config.json", b"{}"),
+                FakeBlobProperties("llama3/weights", b"", {"hdi_isfolder": "true"}),
+                FakeBlobProperties("llama3/weights/model.safetensors", b"w"),
+                FakeBlobProperties("gguf/qwen2.gguf", b"gguf"),
+            ]
+        )
+
+        def new_container_client(endpoint, bucket):
+            assert endpoint == "https://account.blob.core.windows.net"
+            assert bucket == BUCKET
+            return container
+
+        monkeypatch.setattr(
+            "llmaz.model_loader.objstore.azure.new_container_client",
+            new_container_client,
+        )
+        return objstore.new_client(
+            "AZURE", "https://account.blob.core.windows.net", BUCKET
+        )
+
+    def test_client(self, azure_client):
+        assert isinstance(azure_client, AzureClient)
+
+    def test_download_dir(self, azure_client, tmp_path):
+        azure_client.download_dir("llama3", str(tmp_path / "models--llama3"))
+
+        model_dir = tmp_path / "models--llama3"
+        assert (model_dir / "config.json").read_bytes() == b"{}"
+        assert (model_dir / "weights" / "model.safetensors").read_bytes() == b"w"
+
+    def test_download_file(self, azure_client, tmp_path):
+        azure_client.download("gguf/qwen2.gguf", str(tmp_path / "qwen2.gguf"))
+
+        assert (tmp_path / "qwen2.gguf").read_bytes() == b"gguf"
+
+    def test_not_found(self, azure_client, tmp_path):
+        with pytest.raises(FileNotFoundError):
+            azure_client.download_dir("qwen2", str(tmp_path / "models--qwen2"))
+        with pytest.raises(FileNotFoundError):
+            azure_client.download("gguf/qwen2-7b.gguf", str(tmp_path / "qwen2-7b.gguf"))
@@ -0,0 +1,108 @@
+"""
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+"""
+
+import hashlib
+import os
+
+import pytest
+
+from llmaz.model_loader import verify
+from llmaz.model_loader.verify import (
+    ChecksumMismatchError,
+    git_blob_sha1,
+    verify_checksums,
+    verify_hub_files,
+)
+
+MODEL = b"safetensors weights"
+CONFIG = b"{}"
+# The same as `git hash-object config.json`.
+CONFIG_BLOB_ID = hashlib.sha1(b"blob 2\0{}").hexdigest()
+
+
+def sha256(data: bytes) -> str:
+    return hashlib.sha256(data).hexdigest()
+
+
+def prepare_model(tmp_path) -> str:
+    model_dir = tmp_path / "models--llama3"
+    (model_dir / "tokenizer").mkdir(parents=True)
+    (model_dir / "model.safetensors").write_bytes(MODEL)
+    (model_dir / "tokenizer" / "config.json").write_bytes(CONFIG)
+    return str(model_dir)
+
+
+class TestVerifyChecksums:
+    def test_verified(self, tmp_path):
+        model_dir = prepare_model(tmp_path)
+        verify_checksums(
+            model_dir,
+            {
+                "model.safetensors": sha256(MODEL).upper(),
+                "tokenizer/config.json": sha256(CONFIG),
+            },
+        )
+
+    def test_single_file(self, tmp_path):
+        model_dir = prepare_model(tmp_path)
+        model_file = os.path.join(model_dir, "model.safetensors")
+        verify_checksums(model_file, {"model.safetensors": sha256(MODEL)})
+
+    def test_mismatched(self, tmp_path):
+        model_dir = prepare_model(tmp_path)
+        with pytest.raises(ChecksumMismatchError, match="model.safetensors expected"):
+            verify_checksums(model_dir, {"model.safetensors": sha256(CONFIG)})
+
+    def test_missing(self, tmp_path):
+        model_dir = prepare_model(tmp_path)
+        with pytest.raises(ChecksumMismatchError, match="not found"):
+            verify_checksums(model_dir, {"config.json": sha256(CONFIG)})
+
+    def test_out_of_model(self, tmp_path):
+        model_dir = prepare_model(tmp_path)
+        (tmp_path / "secret").write_bytes(CONFIG)
+        with pytest.raises(ChecksumMismatchError, match="out of"):
+            verify_checksums(model_dir, {"../secret": sha256(CONFIG)})
+
+
+class TestVerifyHubFiles:
+    def test_git_blob_sha1(self, tmp_path):
+        (tmp_path / "config.json").write_bytes(CONFIG)
+        assert git_blob_sha1(str(tmp_path / "config.json")) == CONFIG_BLOB_ID
+
+    def test_verified(self, tmp_path):
+        model_dir = prepare_model(tmp_path)
+        verify_hub_files(
+            model_dir,
+            [
+                ("model.safetensors", sha256(MODEL), None),
+                ("tokenizer/config.json", None, CONFIG_BLOB_ID),
+                # Filtered by the patterns, not downloaded.
+                ("README.md", None, "0" * 40),
+            ],
+        )
+
+    def test_mismatched(self, tmp_path):
+        model_dir = prepare_model(tmp_path)
+        with pytest.raises(ChecksumMismatchError, match="model.safetensors expected"):
+            verify_hub_files(model_dir, [("model.safetensors", sha256(CONFIG), None)])
+
+
+def test_report_failure(tmp_path, monkeypatch):
+    termination_log = tmp_path / "termination-log"
+    monkeypatch.setattr(verify, "TERMINATION_LOG", str(termination_log))
+    verify.report_failure(verify.REASON_CHECKSUM_MISMATCH, "model.safetensors")
+    assert termination_log.read_text() == "ChecksumMismatch: model.safetensors"
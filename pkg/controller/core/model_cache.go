@@ -0,0 +1,284 @@
+/*
+Copyright 2025 The InftyAI Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	coreapi "github.com/inftyai/llmaz/api/core/v1alpha1"
+	helper "github.com/inftyai/llmaz/pkg/controller_helper"
+	modelSource "github.com/inftyai/llmaz/pkg/controller_helper/modelsource"
+)
+
+const (
+	// modelSourceHashAnnoKey records the hash of the model source downloaded by the cache Job,
+	// the Job will be recreated once the source changes.
+	modelSourceHashAnnoKey = "llmaz.io/model-source-hash"
+	// cacheSizePercent reserves additional storage for the cache beyond the weights,
+	// e.g. the tokenizer and the config files.
+	cacheSizePercent     = 120
+	cacheJobBackoffLimit = 3
+)
+
+// reconcileCache downloads the model into the PersistentVolumeClaim in the namespace of llmaz with
+// a Job once the model source is validated, and reports the progress in the status. Host path caches
+// are ready once the model is preheated on any node, otherwise they're populated by the model loaders
+// of the workloads on the nodes they run.
+func (r *ModelReconciler) reconcileCache(ctx context.Context, model *coreapi.OpenModel) error {
+	if !modelSource.ModelCacheEnabled(model) || modelSource.ModelCacheType(model) != coreapi.PersistentVolumeModelCache {
+		// The cache resources are garbage collected with the model, cleanup is only required once
+		// the persistent volume cache is disabled.
+		if model.Status.Cache != nil && model.Status.Cache.ClaimName != "" {
+			if err := r.cleanupCache(ctx, model); err != nil {
+				return err
+			}
+		}
+		model.Status.Cache = nil
+		if modelSource.ModelCacheEnabled(model) {
+			status, err := r.hostPathCacheStatus(ctx, model)
+			if err != nil {
+				return err
+			}
+			model.Status.Cache = status
+		}
+		return nil
+	}
+
+	name := modelSource.ModelCacheName(model.Name)
+	status := &coreapi.ModelCacheStatus{Phase: coreapi.ModelCachePending, ClaimName: name}
+	model.Status.Cache = status
+
+	if !apimeta.IsStatusConditionTrue(model.Status.Conditions, coreapi.ModelReady) {
+		status.Message = "Waiting for the model source to be validated"
+		return nil
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: helper.GlobalConfigMapNamespace}, pvc); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		size := cacheSize(model)
+		if size == nil {
+			status.Message = "Storage size is unknown, please set the size of the cache"
+			return nil
+		}
+		if pvc, err = r.createCacheClaim(ctx, model, *size); err != nil {
+			return err
+		}
+	}
+
+	hash := modelSource.SourceHash(model)
+	job := &batchv1.Job{}
+	if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: helper.GlobalConfigMapNamespace}, job); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		if job, err = r.createCacheJob(ctx, model, hash); err != nil {
+			return err
+		}
+	}
+	if job.Annotations[modelSourceHashAnnoKey] != hash {
+		// The source changed, download the model again once the outdated Job is deleted.
+		status.Phase = coreapi.ModelCacheDownloading
+		status.Message = "Model source changed, downloading the model again"
+		return client.IgnoreNotFound(r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)))
+	}
+
+	switch {
+	case job.Status.Succeeded > 0:
+		status.Phase = coreapi.ModelCacheReady
+		status.Message = ""
+	case jobFailed(job):
+		status.Phase = coreapi.ModelCacheFailed
+		status.Message = fmt.Sprintf("Job %s/%s failed to download the model", job.Namespace, job.Name)
+	default:
+		status.Phase = coreapi.ModelCacheDownloading
+		status.Message = fmt.Sprintf("Downloading the model into PersistentVolumeClaim %s/%s", pvc.Namespace, pvc.Name)
+	}
+	return nil
+}
+
+// hostPathCacheStatus reports the host path cache ready once the nodes are labeled as preheated
+// with the current model source.
+func (r *ModelReconciler) hostPathCacheStatus(ctx context.Context, model *coreapi.OpenModel) (*coreapi.ModelCacheStatus, error) {
+	status := &coreapi.ModelCacheStatus{Phase: coreapi.ModelCachePending, Path: modelSource.ModelCacheHostPath(model.Name)}
+
+	nodes := &corev1.NodeList{}
+	if err := r.List(ctx, nodes, client.MatchingLabels{modelSource.ModelPreheatedLabelKey(model.Name): modelSource.SourceHash(model)}); err != nil {
+		return nil, err
+	}
+	if len(nodes.Items) == 0 {
+		status.Message = "Waiting for the model to be cached on the nodes"
+		return status, nil
+	}
+	status.Phase = coreapi.ModelCacheReady
+	status.Message = fmt.Sprintf("Model is cached on %d nodes", len(nodes.Items))
+	return status, nil
+}
+
+func (r *ModelReconciler) createCacheClaim(ctx context.Context, model *coreapi.OpenModel, size resource.Quantity) (*corev1.PersistentVolumeClaim, error) {
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      modelSource.ModelCacheName(model.Name),
+			Namespace: helper.GlobalConfigMapNamespace,
+			Labels:    map[string]string{coreapi.ModelCacheLabelKey: model.Name},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+			StorageClassName: model.Spec.Cache.StorageClassName,
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: size},
+			},
+		},
+	}
+	if err := controllerutil.SetControllerReference(model, pvc, r.Scheme); err != nil {
+		return nil, err
+	}
+	return pvc, r.Create(ctx, pvc)
+}
+
+func (r *ModelReconciler) createCacheJob(ctx context.Context, model *coreapi.OpenModel, hash string) (*batchv1.Job, error) {
+	name := modelSource.ModelCacheName(model.Name)
+	job, err := r.newLoaderJob(ctx, model, name, hash, corev1.VolumeSource{
+		PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: name},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return job, r.Create(ctx, job)
+}
+
+// newLoaderJob returns the Job downloading the model into the volume with the model loader,
+// the Job is owned by the model and annotated with the hash of the model source.
+func (r *ModelReconciler) newLoaderJob(ctx context.Context, model *coreapi.OpenModel, name, hash string, volumeSource corev1.VolumeSource) (*batchv1.Job, error) {
+	configs, err := r.globalConfigs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	loader, err := modelSource.ModelCacheLoader(model, configs.InitContainerImage)
+	if err != nil {
+		return nil, err
+	}
+	if loader == nil {
+		return nil, fmt.Errorf("model %s could not be cached", model.Name)
+	}
+	podSpec := corev1.PodSpec{}
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(loader)
+	if err != nil {
+		return nil, err
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj, &podSpec); err != nil {
+		return nil, err
+	}
+	podSpec.RestartPolicy = corev1.RestartPolicyNever
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{Name: modelSource.MODEL_VOLUME_NAME, VolumeSource: volumeSource})
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   helper.GlobalConfigMapNamespace,
+			Labels:      map[string]string{coreapi.ModelCacheLabelKey: model.Name},
+			Annotations: map[string]string{modelSourceHashAnnoKey: hash},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: ptr.To[int32](cacheJobBackoffLimit),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{coreapi.ModelCacheLabelKey: model.Name},
+				},
+				Spec: podSpec,
+			},
+		},
+	}
+	if err := controllerutil.SetControllerReference(model, job, r.Scheme); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// cleanupCache deletes the cache Job and the claims of the model in all the namespaces,
+// including the volumes cloned for the workloads out of the namespace of llmaz.
+func (r *ModelReconciler) cleanupCache(ctx context.Context, model *coreapi.OpenModel) error {
+	job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: modelSource.ModelCacheName(model.Name), Namespace: helper.GlobalConfigMapNamespace}}
+	if err := r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+		return err
+	}
+
+	selector := client.MatchingLabels{coreapi.ModelCacheLabelKey: model.Name}
+	pvcs := &corev1.PersistentVolumeClaimList{}
+	if err := r.List(ctx, pvcs, selector); err != nil {
+		return err
+	}
+	for i := range pvcs.Items {
+		if err := r.Delete(ctx, &pvcs.Items[i]); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	pvs := &corev1.PersistentVolumeList{}
+	if err := r.List(ctx, pvs, selector); err != nil {
+		return err
+	}
+	for i := range pvs.Items {
+		if err := r.Delete(ctx, &pvs.Items[i]); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *ModelReconciler) globalConfigs(ctx context.Context) (*helper.GlobalConfigs, error) {
+	cm := &corev1.ConfigMap{}
+	if err := r.Get(ctx, types.NamespacedName{Name: helper.GlobalConfigMapName, Namespace: helper.GlobalConfigMapNamespace}, cm); err != nil {
+		return nil, err
+	}
+	return helper.ParseGlobalConfigmap(cm)
+}
+
+// cacheSize returns the storage size of the cache, nil if unknown.
+func cacheSize(model *coreapi.OpenModel) *resource.Quantity {
+	if model.Spec.Cache.Size != nil {
+		return model.Spec.Cache.Size
+	}
+	weightSize := helper.ModelWeightSize(model)
+	if weightSize == nil {
+		return nil
+	}
+	return resource.NewQuantity(weightSize.Value()*cacheSizePercent/100, resource.BinarySI)
+}
+
+func jobFailed(job *batchv1.Job) bool {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
@@ -18,30 +18,54 @@ package controller
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
 
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	coreapi "github.com/inftyai/llmaz/api/core/v1alpha1"
+	helper "github.com/inftyai/llmaz/pkg/controller_helper"
+	modelSource "github.com/inftyai/llmaz/pkg/controller_helper/modelsource"
 )
 
+// failedModelRevalidateInterval is the interval to validate the failed models again,
+// the models may be created or the credentials may be provided later.
+const failedModelRevalidateInterval = 10 * time.Minute
+
 // OpenModelReconciler reconciles a Model object
 type ModelReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
 	Record record.EventRecorder
+	// Validator validates the model sources and discovers the metadata, default to the public endpoints.
+	Validator *modelSource.SourceValidator
 }
 
 func NewModelReconciler(client client.Client, scheme *runtime.Scheme, record record.EventRecorder) *ModelReconciler {
 	return &ModelReconciler{
-		Client: client,
-		Scheme: scheme,
-		Record: record,
+		Client:    client,
+		Scheme:    scheme,
+		Record:    record,
+		Validator: modelSource.NewSourceValidator(),
 	}
 }
 
@@ -49,6 +73,11 @@ func NewModelReconciler(client client.Client, scheme *runtime.Scheme, record rec
 //+kubebuilder:rbac:groups=llmaz.io,resources=openmodels/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=llmaz.io,resources=openmodels/finalizers,verbs=update
 //+kubebuilder:rbac:groups=manta.io,resources=torrents,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch;create;delete
+//+kubebuilder:rbac:groups="",resources=persistentvolumes,verbs=get;list;watch;create;delete
+//+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete
+//+kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -64,12 +93,183 @@ func (r *ModelReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 
 	logger.V(10).Info("reconcile Model", "Model", klog.KObj(model))
 
-	return ctrl.Result{}, nil
+	if !model.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	oldStatus := model.Status.DeepCopy()
+
+	result, validateErr := r.validateSource(ctx, model, model)
+
+	// The host path caches are reported ready by the preheated nodes.
+	if err := r.reconcilePreheat(ctx, model); err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.reconcileCache(ctx, model); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if !apiequality.Semantic.DeepEqual(oldStatus, &model.Status) {
+		if err := r.Status().Update(ctx, model); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	if validateErr != nil {
+		return ctrl.Result{}, validateErr
+	}
+	return result, nil
+}
+
+// validateSource validates the model source unless the model is ready, which will not be
+// validated again until the spec changes. The source errors are reported in the conditions
+// and validated again periodically, others are returned to be retried with exponential backoff.
+func (r *ModelReconciler) validateSource(ctx context.Context, obj client.Object, model *coreapi.OpenModel) (ctrl.Result, error) {
+	if condition := apimeta.FindStatusCondition(model.Status.Conditions, coreapi.ModelReady); condition != nil &&
+		condition.Status == metav1.ConditionTrue && condition.ObservedGeneration == model.Generation {
+		return ctrl.Result{}, nil
+	}
+
+	result, err := r.reconcileSource(ctx, obj, model)
+	if err != nil && !isSourceError(err) {
+		log.FromContext(ctx).V(4).Info("failed to validate the model source", "Model", klog.KObj(obj), "error", err.Error())
+		return result, err
+	}
+	return result, nil
+}
+
+// reconcileSource validates the model source and discovers the metadata, the conditions
+// of the model will be updated accordingly. The events are recorded on the obj, which
+// is the namespaced Model once the model is converted from it.
+func (r *ModelReconciler) reconcileSource(ctx context.Context, obj client.Object, model *coreapi.OpenModel) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	var result ctrl.Result
+	credentials, validateErr := r.credentials(ctx, model)
+	if validateErr == nil {
+		validateErr = r.validator().Validate(ctx, model, credentials)
+	}
+	if validateErr == nil {
+		validateErr = r.pinRevision(ctx, model, credentials)
+	}
+	var sourceErr *modelSource.SourceError
+	switch {
+	case validateErr == nil:
+		// Metadata is optional, failing to discover it should not block serving the model.
+		metadata, err := r.validator().Discover(ctx, model, credentials)
+		if err != nil {
+			logger.Error(err, "failed to discover the model metadata", "Model", klog.KObj(model))
+		}
+		model.Status.Metadata = metadata
+		setModelCondition(model, coreapi.ModelReady, "SourceValidated", "Model source is validated")
+	case errors.As(validateErr, &sourceErr):
+		setModelCondition(model, coreapi.ModelFailed, sourceErr.Reason, sourceErr.Message)
+		r.Record.Event(obj, corev1.EventTypeWarning, sourceErr.Reason, sourceErr.Message)
+		result.RequeueAfter = failedModelRevalidateInterval
+	default:
+		setModelCondition(model, coreapi.ModelPending, "SourceUnreachable", validateErr.Error())
+	}
+	return result, validateErr
+}
+
+func isSourceError(err error) bool {
+	var sourceErr *modelSource.SourceError
+	return errors.As(err, &sourceErr)
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ModelReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&coreapi.OpenModel{}).
+		For(&coreapi.OpenModel{}, builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{}))).
+		Owns(&batchv1.Job{}).
+		Watches(&corev1.Node{}, handler.EnqueueRequestsFromMapFunc(r.nodeToPreheatModels),
+			builder.WithPredicates(predicate.Funcs{
+				UpdateFunc: func(e event.UpdateEvent) bool {
+					oldNode := e.ObjectOld.(*corev1.Node)
+					newNode := e.ObjectNew.(*corev1.Node)
+					return oldNode.Spec.Unschedulable != newNode.Spec.Unschedulable ||
+						!reflect.DeepEqual(oldNode.Labels, newNode.Labels) ||
+						!reflect.DeepEqual(oldNode.Status.Allocatable, newNode.Status.Allocatable)
+				},
+				GenericFunc: func(e event.GenericEvent) bool { return false },
+			})).
 		Complete(r)
 }
+
+func (r *ModelReconciler) validator() *modelSource.SourceValidator {
+	if r.Validator == nil {
+		r.Validator = modelSource.NewSourceValidator()
+	}
+	return r.Validator
+}
+
+// pinRevision resolves the revision of the model hub to the commit once pinned, the commit
+// is kept until the revision changes, so the workloads are not rolled once the branch moves forward.
+func (r *ModelReconciler) pinRevision(ctx context.Context, model *coreapi.OpenModel, credentials map[string][]byte) error {
+	hub := model.Spec.Source.ModelHub
+	if hub == nil || !ptr.Deref(hub.PinRevision, false) {
+		model.Status.PinnedRevision = nil
+		return nil
+	}
+
+	revision := ptr.Deref(hub.Revision, "main")
+	if pinned := model.Status.PinnedRevision; pinned != nil && pinned.Revision == revision {
+		return nil
+	}
+	commit, err := r.validator().ResolveRevision(ctx, model, credentials)
+	if err != nil {
+		return err
+	}
+	model.Status.PinnedRevision = &coreapi.PinnedRevision{Revision: revision, Commit: commit}
+	return nil
+}
+
+// credentials returns the credentials to access the model source from the secret, the secret
+// is looked up in the namespace of the namespaced Model, or in the namespace of llmaz for the
+// cluster scoped OpenModel, whose workloads read the secret with the same name from their own
+// namespaces instead. The default secrets are optional for the public models, while the one
+// referenced by the secretRef is required.
+func (r *ModelReconciler) credentials(ctx context.Context, model *coreapi.OpenModel) (map[string][]byte, error) {
+	name := modelSource.CredentialsSecretName(model)
+	if name == "" {
+		return nil, nil
+	}
+
+	namespace := model.Namespace
+	if namespace == "" {
+		namespace = helper.GlobalConfigMapNamespace
+	}
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, secret); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		if model.Spec.Source.SecretRef != nil {
+			message := fmt.Sprintf("secret %s not found in namespace %s", name, namespace)
+			if model.Namespace == "" {
+				message += ", which is required by the cluster scoped OpenModel to validate the source"
+			}
+			return nil, &modelSource.SourceError{Reason: modelSource.ReasonSecretNotFound, Message: message}
+		}
+		return nil, nil
+	}
+	return modelSource.CredentialsData(model, secret.Data), nil
+}
+
+// setModelCondition sets the condition of the conditionType to true, and the other ones to false.
+func setModelCondition(model *coreapi.OpenModel, conditionType, reason, message string) {
+	for _, t := range []string{coreapi.ModelPending, coreapi.ModelReady, coreapi.ModelFailed} {
+		status := metav1.ConditionFalse
+		if t == conditionType {
+			status = metav1.ConditionTrue
+		} else if apimeta.FindStatusCondition(model.Status.Conditions, t) == nil {
+			continue
+		}
+		apimeta.SetStatusCondition(&model.Status.Conditions, metav1.Condition{
+			Type:               t,
+			Status:             status,
+			Reason:             reason,
+			Message:            message,
+			ObservedGeneration: model.Generation,
+		})
+	}
+}
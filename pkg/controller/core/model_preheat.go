@@ -0,0 +1,239 @@
+/*
+Copyright 2025 The InftyAI Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	coreapi "github.com/inftyai/llmaz/api/core/v1alpha1"
+	helper "github.com/inftyai/llmaz/pkg/controller_helper"
+	modelSource "github.com/inftyai/llmaz/pkg/controller_helper/modelsource"
+	"github.com/inftyai/llmaz/pkg/util"
+)
+
+const modelPreheatNamePrefix = "model-preheat-"
+
+// reconcilePreheat downloads the model into the host model path of every candidate node with a Job,
+// the nodes are labeled once preheated so the workloads will be scheduled onto them first.
+func (r *ModelReconciler) reconcilePreheat(ctx context.Context, model *coreapi.OpenModel) error {
+	jobs, err := r.preheatJobs(ctx, model)
+	if err != nil {
+		return err
+	}
+
+	if !modelSource.ModelPreheatEnabled(model) || !modelSource.ModelCachedOnHost(model) || !modelSource.ModelCacheSupported(model) {
+		if model.Status.Preheat == nil {
+			return nil
+		}
+		if err := r.cleanupPreheat(ctx, model, jobs); err != nil {
+			return err
+		}
+		model.Status.Preheat = nil
+		return nil
+	}
+
+	if !apimeta.IsStatusConditionTrue(model.Status.Conditions, coreapi.ModelReady) {
+		return nil
+	}
+
+	nodes := &corev1.NodeList{}
+	if err := r.List(ctx, nodes); err != nil {
+		return err
+	}
+
+	hash := modelSource.SourceHash(model)
+	labelKey := modelSource.ModelPreheatedLabelKey(model.Name)
+	statuses := []coreapi.NodePreheatStatus{}
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		if !preheatCandidate(node, model) {
+			continue
+		}
+
+		status := coreapi.NodePreheatStatus{NodeName: node.Name, Phase: coreapi.ModelCachePending}
+		job, exists := jobs[node.Name]
+		delete(jobs, node.Name)
+
+		switch {
+		case node.Labels[labelKey] == hash:
+			status.Phase = coreapi.ModelCacheReady
+		case !exists:
+			if job, err = r.newLoaderJob(ctx, model, preheatJobName(model, node), hash, corev1.VolumeSource{
+				HostPath: &corev1.HostPathVolumeSource{
+					Path: modelSource.ModelCacheHostPath(model.Name),
+					Type: ptr.To(corev1.HostPathDirectoryOrCreate),
+				},
+			}); err != nil {
+				return err
+			}
+			job.Spec.Template.Spec.NodeName = node.Name
+			// The candidate nodes are usually tainted, e.g. with the accelerators.
+			job.Spec.Template.Spec.Tolerations = preheatTolerations(node, model)
+			if err := r.Create(ctx, job); err != nil {
+				return err
+			}
+		case job.Annotations[modelSourceHashAnnoKey] != hash:
+			// The source changed, preheat again once the outdated Job is deleted.
+			if err := r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+				return err
+			}
+		case job.Status.Succeeded > 0:
+			patch := client.MergeFrom(node.DeepCopy())
+			if node.Labels == nil {
+				node.Labels = map[string]string{}
+			}
+			node.Labels[labelKey] = hash
+			if err := r.Patch(ctx, node, patch); err != nil {
+				return err
+			}
+			status.Phase = coreapi.ModelCacheReady
+		case jobFailed(job):
+			status.Phase = coreapi.ModelCacheFailed
+			status.Message = fmt.Sprintf("Job %s/%s failed to preheat the model", job.Namespace, job.Name)
+		case job.Status.Active > 0:
+			status.Phase = coreapi.ModelCacheDownloading
+		}
+		statuses = append(statuses, status)
+	}
+
+	// Jobs of the nodes no longer candidates.
+	for _, job := range jobs {
+		if err := r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+
+	slices.SortFunc(statuses, func(a, b coreapi.NodePreheatStatus) int { return strings.Compare(a.NodeName, b.NodeName) })
+	model.Status.Preheat = statuses
+	return nil
+}
+
+// cleanupPreheat deletes the preheat Jobs and the labels of the preheated nodes,
+// the weights in the host path are left to the nodes.
+func (r *ModelReconciler) cleanupPreheat(ctx context.Context, model *coreapi.OpenModel, jobs map[string]*batchv1.Job) error {
+	for _, job := range jobs {
+		if err := r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+
+	labelKey := modelSource.ModelPreheatedLabelKey(model.Name)
+	nodes := &corev1.NodeList{}
+	if err := r.List(ctx, nodes, client.HasLabels{labelKey}); err != nil {
+		return err
+	}
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		patch := client.MergeFrom(node.DeepCopy())
+		delete(node.Labels, labelKey)
+		if err := r.Patch(ctx, node, patch); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// preheatJobs returns the preheat Jobs of the model indexed by the node names.
+func (r *ModelReconciler) preheatJobs(ctx context.Context, model *coreapi.OpenModel) (map[string]*batchv1.Job, error) {
+	jobs := &batchv1.JobList{}
+	if err := r.List(ctx, jobs, client.InNamespace(helper.GlobalConfigMapNamespace), client.MatchingLabels{coreapi.ModelCacheLabelKey: model.Name}); err != nil {
+		return nil, err
+	}
+	result := map[string]*batchv1.Job{}
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+		if strings.HasPrefix(job.Name, modelPreheatNamePrefix) && job.Spec.Template.Spec.NodeName != "" {
+			result[job.Spec.Template.Spec.NodeName] = job
+		}
+	}
+	return result, nil
+}
+
+// nodeToPreheatModels enqueues the models to preheat once the candidate nodes may change, and
+// the models cached on the hosts, whose readiness is reported by the preheated nodes.
+func (r *ModelReconciler) nodeToPreheatModels(ctx context.Context, _ client.Object) []reconcile.Request {
+	models := &coreapi.OpenModelList{}
+	if err := r.List(ctx, models); err != nil {
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, model := range models.Items {
+		if modelSource.ModelPreheatEnabled(&model) || modelSource.ModelCachedOnHost(&model) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&model)})
+		}
+	}
+	return requests
+}
+
+// preheatCandidate returns whether the model should be preheated on the node, that is the node
+// is schedulable and matches any of the flavors, or all the nodes if no flavor is specified.
+func preheatCandidate(node *corev1.Node, model *coreapi.OpenModel) bool {
+	if node.Spec.Unschedulable {
+		return false
+	}
+	if model.Spec.InferenceConfig == nil || len(model.Spec.InferenceConfig.Flavors) == 0 {
+		return true
+	}
+	for i := range model.Spec.InferenceConfig.Flavors {
+		if helper.NodeMatchesFlavor(node, &model.Spec.InferenceConfig.Flavors[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// preheatTolerations returns the tolerations of the flavors matching the node, only the taints
+// the flavors are expected to land on are tolerated rather than all of them, e.g. NoExecute.
+func preheatTolerations(node *corev1.Node, model *coreapi.OpenModel) []corev1.Toleration {
+	if model.Spec.InferenceConfig == nil {
+		return nil
+	}
+	tolerations := []corev1.Toleration{}
+	for i := range model.Spec.InferenceConfig.Flavors {
+		flavor := &model.Spec.InferenceConfig.Flavors[i]
+		if !helper.NodeMatchesFlavor(node, flavor) {
+			continue
+		}
+		for _, toleration := range helper.FlavorTolerations(flavor) {
+			if !slices.ContainsFunc(tolerations, func(t corev1.Toleration) bool { return t.MatchToleration(&toleration) }) {
+				tolerations = append(tolerations, toleration)
+			}
+		}
+	}
+	return tolerations
+}
+
+// preheatJobName hashes the node name to keep the Job name short, the name is shortened
+// again for long model names to fit in a label value.
+func preheatJobName(model *coreapi.OpenModel, node *corev1.Node) string {
+	hasher := fnv.New32a()
+	_, _ = hasher.Write([]byte(node.Name))
+	return util.ShortName(fmt.Sprintf("%s%s-%d", modelPreheatNamePrefix, model.Name, hasher.Sum32()), validation.DNS1123LabelMaxLength)
+}
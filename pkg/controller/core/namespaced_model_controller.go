@@ -0,0 +1,96 @@
+/*
+Copyright 2025 The InftyAI Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	coreapi "github.com/inftyai/llmaz/api/core/v1alpha1"
+	helper "github.com/inftyai/llmaz/pkg/controller_helper"
+)
+
+// NamespacedModelReconciler reconciles the namespaced Model objects, the sources are validated
+// the same as the OpenModels once enabled, while the cache and preheat are not supported.
+type NamespacedModelReconciler struct {
+	*ModelReconciler
+	// ValidateSources enables validating the sources over the network. It's disabled by default
+	// because the endpoints, e.g. the HTTPS URIs or the S3 endpoint in the secret, are controlled
+	// by the namespace users, while the requests are sent from the controller.
+	ValidateSources bool
+}
+
+func NewNamespacedModelReconciler(client client.Client, scheme *runtime.Scheme, record record.EventRecorder) *NamespacedModelReconciler {
+	return &NamespacedModelReconciler{ModelReconciler: NewModelReconciler(client, scheme, record)}
+}
+
+//+kubebuilder:rbac:groups=llmaz.io,resources=models,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=llmaz.io,resources=models/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=llmaz.io,resources=models/finalizers,verbs=update
+
+func (r *NamespacedModelReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	model := &coreapi.Model{}
+	if err := r.Get(ctx, req.NamespacedName, model); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	logger.V(10).Info("reconcile Model", "Model", klog.KObj(model))
+
+	if !model.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	// Reconcile the converted model, then write the status back.
+	openModel := helper.OpenModelFrom(model)
+
+	var result ctrl.Result
+	var validateErr error
+	if r.ValidateSources {
+		result, validateErr = r.validateSource(ctx, model, openModel)
+	} else {
+		setModelCondition(openModel, coreapi.ModelReady, "SourceValidationSkipped", "Model source is not validated for the namespaced Models")
+	}
+
+	if !apiequality.Semantic.DeepEqual(model.Status, openModel.Status) {
+		model.Status = openModel.Status
+		if err := r.Status().Update(ctx, model); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	if validateErr != nil {
+		return ctrl.Result{}, validateErr
+	}
+	return result, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *NamespacedModelReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&coreapi.Model{}, builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{}))).
+		Complete(r)
+}
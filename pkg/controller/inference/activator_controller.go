@@ -17,11 +17,13 @@ limitations under the License.
 package inference
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net"
+	"net/http"
 	"sync"
 	"time"
 
@@ -29,10 +31,12 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -43,6 +47,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	llmazcorev1alpha1 "github.com/inftyai/llmaz/api/core/v1alpha1"
+	inferenceapi "github.com/inftyai/llmaz/api/inference/v1alpha1"
+	helper "github.com/inftyai/llmaz/pkg/controller_helper"
+	"github.com/inftyai/llmaz/pkg/metrics"
+	"github.com/inftyai/llmaz/pkg/util"
 )
 
 var (
@@ -51,21 +59,73 @@ var (
 
 const (
 	playgroundsResource     = "playgrounds"
+	servicesResource        = "services"
 	activatorControllerName = "activator-controller"
+
+	// defaultColdStartTimeout is the maximum time waiting for the model to be ready
+	// once scaled up from zero, unless specified by the scaleToZero policy.
+	defaultColdStartTimeout = 5 * time.Minute
+	// idleCheckInterval is the interval to check whether the model is idle.
+	idleCheckInterval = 30 * time.Second
+	// metricsScrapeTimeout is the timeout of scraping metrics from the inference engine.
+	metricsScrapeTimeout = 5 * time.Second
+
+	// Event reasons recorded by the activator.
+	ScaledToZeroReason      = "ScaledToZero"
+	ScaledFromZeroReason    = "ScaledFromZero"
+	ActivationTimeoutReason = "ActivationTimeout"
+	ActivationFailedReason  = "ActivationFailed"
+)
+
+var (
+	// inflightRequestMetrics are the metrics exposed by the inference engines
+	// representing the running or waiting requests.
+	inflightRequestMetrics = []string{
+		"vllm:num_requests_running",
+		"vllm:num_requests_waiting",
+		"sglang:num_running_reqs",
+		"sglang:num_queue_reqs",
+		"llamacpp:requests_processing",
+		"llamacpp:requests_deferred",
+		"tgi_queue_size",
+		"tgi_batch_current_size",
+	}
+	// processedRequestMetrics are the metrics exposed by the inference engines
+	// which keep increasing as long as requests are served.
+	processedRequestMetrics = []string{
+		"vllm:prompt_tokens_total",
+		"sglang:prompt_tokens_total",
+		"llamacpp:prompt_tokens_total",
+		"tgi_request_count",
+	}
 )
 
+// activity records the latest observed activity of the model.
+type activity struct {
+	processed  float64
+	lastActive time.Time
+}
+
 type ActivatorReconciler struct {
 	client.Client
 	dynamicClient dynamic.Interface
+	record        record.EventRecorder
 	portManager   *PortManager
 	ip            string
+	httpClient    *http.Client
+
+	mut        sync.Mutex
+	activities map[types.NamespacedName]*activity
 }
 
 func NewActivatorReconciler(mgr ctrl.Manager, dynamicClient dynamic.Interface, ip string) *ActivatorReconciler {
 	reconciler := &ActivatorReconciler{
 		Client:        mgr.GetClient(),
 		dynamicClient: dynamicClient,
+		record:        mgr.GetEventRecorderFor(activatorControllerName),
 		ip:            ip,
+		httpClient:    &http.Client{Timeout: metricsScrapeTimeout},
+		activities:    map[types.NamespacedName]*activity{},
 	}
 	reconciler.portManager = NewPortManager(reconciler.scaleUp)
 	return reconciler
@@ -73,6 +133,9 @@ func NewActivatorReconciler(mgr ctrl.Manager, dynamicClient dynamic.Interface, i
 
 // +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=endpoints,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=inference.llmaz.io,resources=playgrounds;services,verbs=get;list;watch;update;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -116,7 +179,11 @@ func (r *ActivatorReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	} else if len(ep.Subsets[0].Addresses) > 0 &&
 		ep.Subsets[0].Addresses[0].IP != r.ip {
 		// If the endpoints are not empty and not the activator IP, forward the traffic
-		return ctrl.Result{}, r.forwardEndpoint(ctx, ep, ports)
+		if err := r.forwardEndpoint(ctx, ep, ports); err != nil {
+			return ctrl.Result{}, err
+		}
+		// The model is serving, scale it to zero once idle.
+		return r.scaleDownIfIdle(ctx, svc, ep)
 	}
 
 	return ctrl.Result{}, nil
@@ -175,8 +242,9 @@ func (r *ActivatorReconciler) restoreSelectorIfNeeded(ctx context.Context, svc *
 func (r *ActivatorReconciler) injectEndpoint(ctx context.Context, ep *corev1.Endpoints, svc *corev1.Service, ports []corev1.ServicePort) error {
 	// nolint:staticcheck
 	subsets := make([]corev1.EndpointSubset, 0, len(ports))
+	model := svc.Annotations[llmazcorev1alpha1.ModelActivatorAnnoKey]
 	for _, port := range ports {
-		ds, err := r.portManager.AddTarget(ep.Name, ep.Namespace, int(port.Port))
+		ds, err := r.portManager.AddTarget(ep.Name, ep.Namespace, model, int(port.Port))
 		if err != nil {
 			return err
 		}
@@ -211,10 +279,20 @@ func (r *ActivatorReconciler) injectEndpoint(ctx context.Context, ep *corev1.End
 	}
 	updatedSvc.Annotations[llmazcorev1alpha1.CachedModelActivatorAnnoKey] = string(selectorBytes)
 	updatedSvc.Spec.Selector = nil
-	return r.Update(ctx, updatedSvc)
+	if err := r.Update(ctx, updatedSvc); err != nil {
+		return err
+	}
+
+	r.recordEvent(ctx, svc, corev1.EventTypeNormal, ScaledToZeroReason,
+		"No ready endpoints, traffic is buffered by the activator until the model is scaled up")
+	return nil
 }
 
 func (r *ActivatorReconciler) handleServiceDeletion(namespace, name string) {
+	r.mut.Lock()
+	delete(r.activities, types.NamespacedName{Namespace: namespace, Name: name})
+	r.mut.Unlock()
+
 	pis := r.portManager.RemoveTargetForAllPorts(name, namespace)
 	for _, pi := range pis {
 		activatorControllerLog.Info("Cleaning up endpoints after service deletion",
@@ -222,9 +300,7 @@ func (r *ActivatorReconciler) handleServiceDeletion(namespace, name string) {
 			"listenerPort", pi.Listener.Port(),
 		)
 		_ = pi.Listener.Close()
-		for _, conn := range pi.Connections {
-			_ = conn.Close()
-		}
+		dropConnections(pi)
 	}
 }
 
@@ -242,13 +318,16 @@ func (r *ActivatorReconciler) forwardEndpoint(_ context.Context, ep *corev1.Endp
 			continue
 		}
 
+		// Stop accepting before forwarding, so no connection is left behind in the listener.
+		closeErr := ds.Listener.Close()
+		conns := ds.TakeConnections()
 		activatorControllerLog.Info("Forwarding traffic to real endpoint",
 			"port", port.Port,
 			"address", address,
-			"connections", len(ds.Connections),
+			"connections", len(conns),
 		)
 
-		for _, conn := range ds.Connections {
+		for _, conn := range conns {
 			targetConn, err := net.Dial("tcp", address)
 			if err != nil {
 				activatorControllerLog.Error(err, "Failed to dial target")
@@ -256,10 +335,10 @@ func (r *ActivatorReconciler) forwardEndpoint(_ context.Context, ep *corev1.Endp
 			}
 			tunnel(conn, targetConn)
 		}
-		err = ds.Listener.Close()
-		if err != nil {
-			activatorControllerLog.Error(err, "Failed to close listener")
-			return err
+		metrics.BufferedConnectionsSub(ds.Target.Namespace, ds.Model, len(conns))
+		if closeErr != nil {
+			activatorControllerLog.Error(closeErr, "Failed to close listener")
+			return closeErr
 		}
 	}
 	return nil
@@ -288,58 +367,255 @@ func (r *ActivatorReconciler) getEndpointAddress(ep *corev1.Endpoints, ports []c
 
 func (r *ActivatorReconciler) scaleUp(pi *PortInformation) {
 	ctx := context.Background()
-	activatorControllerLog.Info("Scaling up target Playground", "service", pi.Target.Name)
+	start := time.Now()
+	activatorControllerLog.Info("Scaling up target", "service", pi.Target.Name)
+
+	activated := false
+	defer func() {
+		if !activated {
+			// Clients will never be served once the activation failed, close the buffered
+			// connections rather than leaving them hanging, the next connection will retry.
+			dropConnections(pi)
+			pi.ResetActivation()
+		}
+	}()
 
 	svc := &corev1.Service{}
 	key := types.NamespacedName{Namespace: pi.Target.Namespace, Name: pi.Target.Name}
 	if err := r.Get(ctx, key, svc); err != nil {
 		activatorControllerLog.Error(err, "Failed to get service")
+		metrics.ActivationFailuresInc(pi.Target.Namespace, pi.Model, metrics.ActivationFailureReasonScaleUp)
+		return
+	}
+
+	gvr, name, err := r.scaleTarget(ctx, svc)
+	if err != nil {
+		activatorControllerLog.Error(err, "Failed to find the scale target")
+		metrics.ActivationFailuresInc(pi.Target.Namespace, pi.Model, metrics.ActivationFailureReasonScaleUp)
 		return
 	}
 
-	name := svc.Annotations[llmazcorev1alpha1.ModelActivatorAnnoKey]
-	if name == "" {
-		activatorControllerLog.Error(nil, "Scale annotation not found")
+	activatorControllerLog.Info("Scaling up from zero", "resource", gvr.Resource, "name", name)
+	if err := r.scaleTo(ctx, gvr, pi.Target.Namespace, name, 1); err != nil {
+		activatorControllerLog.Error(err, "Failed to scale up", "resource", gvr.Resource, "name", name)
+		metrics.ActivationFailuresInc(pi.Target.Namespace, pi.Model, metrics.ActivationFailureReasonScaleUp)
+		r.recordEvent(ctx, svc, corev1.EventTypeWarning, ActivationFailedReason,
+			fmt.Sprintf("Failed to scale up from zero: %v", err))
 		return
 	}
 
-	gvr := llmazcorev1alpha1.GroupVersion.WithResource(playgroundsResource)
+	timeout := durationFromAnnotation(svc, llmazcorev1alpha1.ModelActivatorColdStartTimeoutAnnoKey, defaultColdStartTimeout)
+	// The workload name is always the same with the inference Service, no matter
+	// scaled via the Playground or the Service.
+	if err := r.waitUntilLeaderPodIsReady(ctx, metav1.GetControllerOf(svc).Name, pi.Target.Namespace, timeout); err != nil {
+		activatorControllerLog.Error(err, "Failed waiting for the leader pod")
+		if wait.Interrupted(err) {
+			metrics.ActivationFailuresInc(pi.Target.Namespace, pi.Model, metrics.ActivationFailureReasonTimeout)
+			r.recordEvent(ctx, svc, corev1.EventTypeWarning, ActivationTimeoutReason,
+				fmt.Sprintf("Model is not ready after %s, %d buffered connections are still waiting", timeout, pi.ConnectionCount()))
+		} else {
+			metrics.ActivationFailuresInc(pi.Target.Namespace, pi.Model, metrics.ActivationFailureReasonScaleUp)
+			r.recordEvent(ctx, svc, corev1.EventTypeWarning, ActivationFailedReason,
+				fmt.Sprintf("Failed waiting for the model ready: %v", err))
+		}
+		return
+	}
+
+	duration := time.Since(start)
+	metrics.ColdStartObserve(pi.Target.Namespace, pi.Model, duration)
+	r.recordEvent(ctx, svc, corev1.EventTypeNormal, ScaledFromZeroReason,
+		fmt.Sprintf("Scaled up from zero in %s", duration.Round(time.Second)))
 
-	activatorControllerLog.Info("Scaling up Playground", "playground", name)
-	retryErr := retry.RetryOnConflict(retry.DefaultRetry, func() error {
-		playground, err := r.dynamicClient.Resource(gvr).Namespace(pi.Target.Namespace).Get(ctx, name, metav1.GetOptions{})
+	// Restore the service selector
+	restoreSelectorIfNeededErr := r.restoreSelectorIfNeeded(ctx, svc)
+	if restoreSelectorIfNeededErr != nil {
+		activatorControllerLog.Error(restoreSelectorIfNeededErr, "Failed to restore service selector")
+		metrics.ActivationFailuresInc(pi.Target.Namespace, pi.Model, metrics.ActivationFailureReasonRestore)
+		return
+	}
+	activated = true
+}
+
+// dropConnections closes the buffered connections of the port.
+func dropConnections(pi *PortInformation) {
+	conns := pi.TakeConnections()
+	for _, conn := range conns {
+		_ = conn.Close()
+	}
+	metrics.BufferedConnectionsSub(pi.Target.Namespace, pi.Model, len(conns))
+}
+
+// scaleDownIfIdle scales the model to zero once it has no requests to serve for longer
+// than the idle timeout, it's a no-op if the idle timeout is not configured or during
+// the warm-up windows.
+// nolint:staticcheck
+func (r *ActivatorReconciler) scaleDownIfIdle(ctx context.Context, svc *corev1.Service, ep *corev1.Endpoints) (ctrl.Result, error) {
+	key := types.NamespacedName{Namespace: svc.Namespace, Name: svc.Name}
+	idleTimeout := durationFromAnnotation(svc, llmazcorev1alpha1.ModelActivatorIdleTimeoutAnnoKey, 0)
+	if idleTimeout <= 0 {
+		r.mut.Lock()
+		delete(r.activities, key)
+		r.mut.Unlock()
+		return ctrl.Result{}, nil
+	}
+
+	inflight, processed, found := r.modelActivity(ctx, ep)
+	now := time.Now()
+
+	r.mut.Lock()
+	act, ok := r.activities[key]
+	if !ok {
+		act = &activity{processed: processed, lastActive: now}
+		r.activities[key] = act
+	}
+	// Models without known metrics will never be regarded as idle.
+	if !found || inflight > 0 || processed != act.processed {
+		act.lastActive = now
+	}
+	act.processed = processed
+	idle := now.Sub(act.lastActive)
+	r.mut.Unlock()
+
+	if idle < idleTimeout {
+		return ctrl.Result{RequeueAfter: min(idleCheckInterval, idleTimeout-idle)}, nil
+	}
+
+	gvr, name, err := r.scaleTarget(ctx, svc)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	// The model is kept serving during the warm-up windows of the Playground.
+	if gvr.Resource == playgroundsResource {
+		playground := &inferenceapi.Playground{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: svc.Namespace, Name: name}, playground); err != nil {
+			return ctrl.Result{}, err
+		}
+		if warmupReplicas, requeueAfter := helper.WarmupReplicas(playground, now); warmupReplicas > 0 {
+			activatorControllerLog.V(4).Info("Skipping scaling down during the warm-up window", "playground", name)
+			return ctrl.Result{RequeueAfter: requeueAfter}, nil
+		}
+	}
+	activatorControllerLog.Info("Scaling down to zero after idle", "resource", gvr.Resource, "name", name, "idle", idle.Round(time.Second))
+	if err := r.scaleTo(ctx, gvr, svc.Namespace, name, 0); err != nil {
+		return ctrl.Result{}, err
+	}
+	metrics.IdleScaleDownsInc(svc.Namespace, svc.Annotations[llmazcorev1alpha1.ModelActivatorAnnoKey])
+
+	r.mut.Lock()
+	delete(r.activities, key)
+	r.mut.Unlock()
+	return ctrl.Result{}, nil
+}
+
+// modelActivity scrapes the metrics of the inference engines behind the endpoints and
+// returns the number of inflight requests and the processed requests, found is false
+// if any of the endpoints fails to report the known metrics.
+// nolint:staticcheck
+func (r *ActivatorReconciler) modelActivity(ctx context.Context, ep *corev1.Endpoints) (inflight, processed float64, found bool) {
+	for _, subset := range ep.Subsets {
+		for _, address := range subset.Addresses {
+			for _, port := range subset.Ports {
+				url := fmt.Sprintf("http://%s/metrics", net.JoinHostPort(address.IP, fmt.Sprint(port.Port)))
+				body, err := r.scrapeMetrics(ctx, url)
+				if err != nil {
+					activatorControllerLog.V(4).Info("Failed to scrape metrics", "url", url, "error", err.Error())
+					return 0, 0, false
+				}
+
+				running, ok, err := util.SumMetrics(bytes.NewReader(body), inflightRequestMetrics)
+				if err != nil || !ok {
+					return 0, 0, false
+				}
+				total, _, err := util.SumMetrics(bytes.NewReader(body), processedRequestMetrics)
+				if err != nil {
+					return 0, 0, false
+				}
+				inflight += running
+				processed += total
+			}
+		}
+	}
+	return inflight, processed, true
+}
+
+func (r *ActivatorReconciler) scrapeMetrics(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// scaleTarget returns the resource and the name of the object to scale, which is the Playground
+// if the inference Service is created by a Playground, or the inference Service itself.
+func (r *ActivatorReconciler) scaleTarget(ctx context.Context, svc *corev1.Service) (schema.GroupVersionResource, string, error) {
+	owner := metav1.GetControllerOf(svc)
+	if owner == nil || owner.Kind != "Service" || owner.APIVersion != inferenceapi.GroupVersion.String() {
+		return schema.GroupVersionResource{}, "", fmt.Errorf("service %s is not controlled by an inference Service", svc.Name)
+	}
+
+	service := &inferenceapi.Service{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: svc.Namespace, Name: owner.Name}, service); err != nil {
+		return schema.GroupVersionResource{}, "", err
+	}
+
+	if owner := metav1.GetControllerOf(service); owner != nil && owner.Kind == "Playground" && owner.APIVersion == inferenceapi.GroupVersion.String() {
+		return inferenceapi.GroupVersion.WithResource(playgroundsResource), owner.Name, nil
+	}
+	return inferenceapi.GroupVersion.WithResource(servicesResource), service.Name, nil
+}
+
+func (r *ActivatorReconciler) scaleTo(ctx context.Context, gvr schema.GroupVersionResource, namespace, name string, replicas int64) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		obj, err := r.dynamicClient.Resource(gvr).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
 		if err != nil {
 			return err
 		}
-		if err := unstructured.SetNestedField(playground.Object, int64(1), "spec", "replicas"); err != nil {
+		if err := unstructured.SetNestedField(obj.Object, replicas, "spec", "replicas"); err != nil {
 			return err
 		}
-		_, err = r.dynamicClient.Resource(gvr).Namespace(pi.Target.Namespace).Update(ctx, playground, metav1.UpdateOptions{})
+		_, err = r.dynamicClient.Resource(gvr).Namespace(namespace).Update(ctx, obj, metav1.UpdateOptions{})
 		return err
 	})
+}
 
-	if retryErr != nil {
-		activatorControllerLog.Error(retryErr, "Failed to scale Playground")
+// recordEvent records the event to the Playground or the inference Service serving the model,
+// if neither is found, the event will be recorded to the load balancing Service instead.
+func (r *ActivatorReconciler) recordEvent(ctx context.Context, svc *corev1.Service, eventType, reason, message string) {
+	if r.record == nil {
 		return
 	}
 
-	if err := r.waitUntilPlaygroundPodIsReady(ctx, name, pi.Target.Namespace); err != nil {
-		activatorControllerLog.Error(err, "Failed waiting for Playground pod")
+	gvr, name, err := r.scaleTarget(ctx, svc)
+	if err != nil {
+		r.record.Event(svc, eventType, reason, message)
 		return
 	}
 
-	// Restore the service selector
-	restoreSelectorIfNeededErr := r.restoreSelectorIfNeeded(ctx, svc)
-	if restoreSelectorIfNeededErr != nil {
-		activatorControllerLog.Error(restoreSelectorIfNeededErr, "Failed to restore service selector")
+	var obj client.Object = &inferenceapi.Service{}
+	if gvr.Resource == playgroundsResource {
+		obj = &inferenceapi.Playground{}
+	}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: svc.Namespace, Name: name}, obj); err != nil {
+		r.record.Event(svc, eventType, reason, message)
 		return
 	}
+	r.record.Event(obj, eventType, reason, message)
 }
 
-func (r *ActivatorReconciler) waitUntilPlaygroundPodIsReady(ctx context.Context, name, namespace string) error {
-	// The pod name is always playground name + "-0"
+func (r *ActivatorReconciler) waitUntilLeaderPodIsReady(ctx context.Context, name, namespace string, timeout time.Duration) error {
+	// The leader pod name is always workload name + "-0"
 	podName := name + "-0"
-	return wait.PollUntilContextTimeout(ctx, time.Second, 5*time.Minute, true, func(ctx context.Context) (bool, error) {
+	return wait.PollUntilContextTimeout(ctx, time.Second, timeout, true, func(ctx context.Context) (bool, error) {
 		pod := &corev1.Pod{}
 		if err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: podName}, pod); err != nil {
 			if errors.IsNotFound(err) {
@@ -356,6 +632,21 @@ func (r *ActivatorReconciler) waitUntilPlaygroundPodIsReady(ctx context.Context,
 	})
 }
 
+// durationFromAnnotation parses the duration from the annotation of the service,
+// defaultValue will be returned if not found or malformed.
+func durationFromAnnotation(svc *corev1.Service, key string, defaultValue time.Duration) time.Duration {
+	value, ok := svc.Annotations[key]
+	if !ok {
+		return defaultValue
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		activatorControllerLog.Error(err, "Failed to parse duration annotation", "service", svc.Name, "annotation", key)
+		return defaultValue
+	}
+	return duration
+}
+
 func (r *ActivatorReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	hasActivatorAnnotation := func(obj client.Object) bool {
 		// Make sure the object has the activator annotation
@@ -461,9 +752,59 @@ type Target struct {
 }
 
 type PortInformation struct {
-	Target      Target
-	Listener    Listener
-	Connections []net.Conn
+	Target Target
+	// Model is the model name activated by the activator, used for observability.
+	Model    string
+	Listener Listener
+
+	// mut guards the connections, which are appended by the listener goroutine
+	// while read by the reconciler.
+	mut         sync.Mutex
+	connections []net.Conn
+	// activating is true once the scaling up is triggered, until it fails.
+	activating bool
+}
+
+// AddConnection buffers the accepted connection and returns the number of buffered connections.
+func (pi *PortInformation) AddConnection(conn net.Conn) int {
+	pi.mut.Lock()
+	defer pi.mut.Unlock()
+	pi.connections = append(pi.connections, conn)
+	return len(pi.connections)
+}
+
+// ConnectionCount returns the number of buffered connections.
+func (pi *PortInformation) ConnectionCount() int {
+	pi.mut.Lock()
+	defer pi.mut.Unlock()
+	return len(pi.connections)
+}
+
+// TakeConnections returns the buffered connections and resets the buffer.
+func (pi *PortInformation) TakeConnections() []net.Conn {
+	pi.mut.Lock()
+	defer pi.mut.Unlock()
+	conns := pi.connections
+	pi.connections = nil
+	return conns
+}
+
+// StartActivation marks the port as activating and returns whether it was not yet.
+func (pi *PortInformation) StartActivation() bool {
+	pi.mut.Lock()
+	defer pi.mut.Unlock()
+	if pi.activating {
+		return false
+	}
+	pi.activating = true
+	return true
+}
+
+// ResetActivation allows the next connection to trigger the scaling up again.
+func (pi *PortInformation) ResetActivation() {
+	pi.mut.Lock()
+	defer pi.mut.Unlock()
+	pi.activating = false
 }
 
 type PortManager struct {
@@ -482,7 +823,7 @@ func NewPortManager(cb func(*PortInformation)) *PortManager {
 	}
 }
 
-func (pm *PortManager) AddTarget(name string, namespace string, port int) (*PortInformation, error) {
+func (pm *PortManager) AddTarget(name string, namespace string, model string, port int) (*PortInformation, error) {
 	pm.mut.Lock()
 	defer pm.mut.Unlock()
 
@@ -504,6 +845,7 @@ func (pm *PortManager) AddTarget(name string, namespace string, port int) (*Port
 	port = listener.Port()
 	downstream := &PortInformation{
 		Target:   target,
+		Model:    model,
 		Listener: listener,
 	}
 	pm.portMap[port] = downstream
@@ -549,16 +891,15 @@ func (pm *PortManager) RemoveTargetForAllPorts(name string, namespace string) []
 }
 
 func (pm *PortManager) startListener(downstream *PortInformation) {
-	start := false
 	for {
 		conn, err := downstream.Listener.Accept()
 		if err != nil {
 			return
 		}
-		downstream.Connections = append(downstream.Connections, conn)
-		if !start {
+		downstream.AddConnection(conn)
+		metrics.BufferedConnectionsInc(downstream.Target.Namespace, downstream.Model)
+		if downstream.StartActivation() {
 			go pm.cb(downstream)
-			start = true
 		}
 	}
 }
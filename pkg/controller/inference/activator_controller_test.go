@@ -0,0 +1,199 @@
+/*
+Copyright 2025 The InftyAI Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inference
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	coreapi "github.com/inftyai/llmaz/api/core/v1alpha1"
+	inferenceapi "github.com/inftyai/llmaz/api/inference/v1alpha1"
+	"github.com/inftyai/llmaz/pkg/metrics"
+	"github.com/inftyai/llmaz/test/util/wrapper"
+)
+
+func TestPortInformationConnections(t *testing.T) {
+	pi := &PortInformation{}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			client, server := net.Pipe()
+			defer func() { _ = client.Close() }()
+			pi.AddConnection(server)
+		}()
+		go func() {
+			defer wg.Done()
+			_ = pi.ConnectionCount()
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 10, pi.ConnectionCount())
+	conns := pi.TakeConnections()
+	assert.Len(t, conns, 10)
+	assert.Equal(t, 0, pi.ConnectionCount())
+	assert.Equal(t, 1, pi.AddConnection(conns[0]))
+}
+
+// newTestActivator returns the activator serving the model of the playground, the load balancing
+// Service is controlled by the inference Service, which is controlled by the playground.
+func newTestActivator(t *testing.T, playground *inferenceapi.Playground, annotations map[string]string) (*ActivatorReconciler, *corev1.Service) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(scheme))
+	assert.NoError(t, inferenceapi.AddToScheme(scheme))
+
+	service := &inferenceapi.Service{ObjectMeta: metav1.ObjectMeta{Name: playground.Name, Namespace: playground.Namespace,
+		OwnerReferences: []metav1.OwnerReference{{APIVersion: inferenceapi.GroupVersion.String(), Kind: "Playground", Name: playground.Name, Controller: ptr.To(true)}},
+	}}
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: playground.Name + "-lb", Namespace: playground.Namespace,
+		Annotations:     annotations,
+		OwnerReferences: []metav1.OwnerReference{{APIVersion: inferenceapi.GroupVersion.String(), Kind: "Service", Name: service.Name, Controller: ptr.To(true)}},
+	}}
+
+	r := &ActivatorReconciler{
+		Client:        fake.NewClientBuilder().WithScheme(scheme).WithObjects(playground, service, svc).Build(),
+		dynamicClient: dynamicfake.NewSimpleDynamicClient(scheme, playground.DeepCopy()),
+		httpClient:    &http.Client{Timeout: metricsScrapeTimeout},
+		activities:    map[types.NamespacedName]*activity{},
+	}
+	r.portManager = NewPortManager(r.scaleUp)
+	return r, svc
+}
+
+func playgroundReplicas(t *testing.T, r *ActivatorReconciler, playground *inferenceapi.Playground) int64 {
+	obj, err := r.dynamicClient.Resource(inferenceapi.GroupVersion.WithResource(playgroundsResource)).
+		Namespace(playground.Namespace).Get(context.Background(), playground.Name, metav1.GetOptions{})
+	assert.NoError(t, err)
+	replicas, _, _ := unstructured.NestedInt64(obj.Object, "spec", "replicas")
+	return replicas
+}
+
+func TestScaleDownIfIdle(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, "vllm:num_requests_running 0\nvllm:num_requests_waiting 0\nvllm:prompt_tokens_total 10\n")
+	}))
+	defer server.Close()
+	host, port, _ := net.SplitHostPort(server.Listener.Addr().String())
+	portNum, _ := strconv.Atoi(port)
+	// nolint:staticcheck
+	ep := &corev1.Endpoints{Subsets: []corev1.EndpointSubset{{
+		Addresses: []corev1.EndpointAddress{{IP: host}},
+		Ports:     []corev1.EndpointPort{{Port: int32(portNum)}},
+	}}}
+
+	testCases := []struct {
+		name         string
+		playground   *inferenceapi.Playground
+		idleFor      time.Duration
+		wantReplicas int64
+		wantRequeue  bool
+	}{
+		{
+			name:         "scaled to zero once idle",
+			playground:   wrapper.MakePlayground("llama3-idle", "default").ModelClaim("llama3-8b").Replicas(2).Obj(),
+			idleFor:      2 * time.Minute,
+			wantReplicas: 0,
+		},
+		{
+			name:         "not idle long enough",
+			playground:   wrapper.MakePlayground("llama3-active", "default").ModelClaim("llama3-8b").Replicas(2).Obj(),
+			idleFor:      30 * time.Second,
+			wantReplicas: 2,
+			wantRequeue:  true,
+		},
+		{
+			name: "kept during the warm-up window",
+			playground: wrapper.MakePlayground("llama3-warmup", "default").ModelClaim("llama3-8b").Replicas(2).
+				WarmupSchedule("0 0 * * *", "", "24h", 1).Obj(),
+			idleFor:      2 * time.Minute,
+			wantReplicas: 2,
+			wantRequeue:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, svc := newTestActivator(t, tc.playground, map[string]string{
+				coreapi.ModelActivatorAnnoKey:            tc.playground.Name,
+				coreapi.ModelActivatorIdleTimeoutAnnoKey: "1m",
+			})
+			r.activities[types.NamespacedName{Namespace: svc.Namespace, Name: svc.Name}] = &activity{processed: 10, lastActive: time.Now().Add(-tc.idleFor)}
+			scaleDowns := testutil.ToFloat64(metrics.ActivatorIdleScaleDowns.WithLabelValues(svc.Namespace, tc.playground.Name))
+
+			result, err := r.scaleDownIfIdle(context.Background(), svc, ep)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantRequeue, result.RequeueAfter > 0)
+			assert.Equal(t, tc.wantReplicas, playgroundReplicas(t, r, tc.playground))
+
+			wantScaleDowns := scaleDowns
+			if tc.wantReplicas == 0 {
+				wantScaleDowns++
+			}
+			assert.Equal(t, wantScaleDowns, testutil.ToFloat64(metrics.ActivatorIdleScaleDowns.WithLabelValues(svc.Namespace, tc.playground.Name)))
+		})
+	}
+}
+
+func TestScaleUpColdStartTimeout(t *testing.T) {
+	playground := wrapper.MakePlayground("llama3-cold", "default").ModelClaim("llama3-8b").Replicas(0).Obj()
+	r, svc := newTestActivator(t, playground, map[string]string{
+		coreapi.ModelActivatorAnnoKey:                 playground.Name,
+		coreapi.ModelActivatorColdStartTimeoutAnnoKey: "100ms",
+	})
+
+	pi := &PortInformation{Target: Target{Name: svc.Name, Namespace: svc.Namespace, Port: 8080}, Model: playground.Name}
+	assert.True(t, pi.StartActivation())
+	client, server := net.Pipe()
+	defer func() { _ = client.Close() }()
+	pi.AddConnection(server)
+	metrics.BufferedConnectionsInc(svc.Namespace, playground.Name)
+	timeouts := testutil.ToFloat64(metrics.ActivatorActivationFailures.WithLabelValues(svc.Namespace, playground.Name, metrics.ActivationFailureReasonTimeout))
+
+	r.scaleUp(pi)
+
+	assert.Equal(t, int64(1), playgroundReplicas(t, r, playground))
+	assert.Equal(t, timeouts+1, testutil.ToFloat64(metrics.ActivatorActivationFailures.WithLabelValues(svc.Namespace, playground.Name, metrics.ActivationFailureReasonTimeout)))
+	assert.Equal(t, 0, pi.ConnectionCount())
+	assert.Equal(t, float64(0), testutil.ToFloat64(metrics.ActivatorBufferedConnections.WithLabelValues(svc.Namespace, playground.Name)))
+	// The buffered connection is closed.
+	_, err := client.Read(make([]byte, 1))
+	assert.Error(t, err)
+	// The next connection is able to trigger the scaling up again.
+	assert.True(t, pi.StartActivation())
+}
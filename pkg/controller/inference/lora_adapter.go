@@ -0,0 +1,411 @@
+/*
+Copyright 2025 The InftyAI Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inference
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	coreapplyv1 "k8s.io/client-go/applyconfigurations/core/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	lws "sigs.k8s.io/lws/api/leaderworkerset/v1"
+
+	coreapi "github.com/inftyai/llmaz/api/core/v1alpha1"
+	inferenceapi "github.com/inftyai/llmaz/api/inference/v1alpha1"
+	helper "github.com/inftyai/llmaz/pkg/controller_helper"
+	modelSource "github.com/inftyai/llmaz/pkg/controller_helper/modelsource"
+)
+
+const (
+	loraLoaderContainerPrefix = "lora-loader-"
+	defaultLoRALoadPath       = "/v1/load_lora_adapter"
+	defaultLoRAUnloadPath     = "/v1/unload_lora_adapter"
+	loraAdapterRequestTimeout = time.Minute
+	// loraAdapterSyncInterval is the interval to recheck the adapters not loaded yet.
+	loraAdapterSyncInterval = 10 * time.Second
+	// maxLoRALoaderAttempts is the max number of model loaders per adapter in a pod, the failed
+	// ephemeral containers can't be restarted or removed, so every retry adds a new one.
+	maxLoRALoaderAttempts = 5
+)
+
+// reconcileLoRAAdapters loads the lora models into every ready leader pod of the Playground
+// via the API of the backendRuntime, the weights are downloaded by an ephemeral container
+// sharing the model volume, so no pod restart is required. Adapters no longer claimed will be
+// unloaded. The per-pod adapter state is tracked in the Playground status.
+func (r *PlaygroundReconciler) reconcileLoRAAdapters(ctx context.Context, playground *inferenceapi.Playground, backendRuntime *inferenceapi.BackendRuntime, models []*coreapi.OpenModel) (time.Duration, error) {
+	logger := log.FromContext(ctx)
+
+	if !helper.DynamicLoRAEnabled(playground) || backendRuntime.Spec.DynamicLoRA == nil {
+		playground.Status.LoRAAdapters = nil
+		return 0, nil
+	}
+
+	roles := helper.ModelRoles(helper.ModelRefsByPlayground(playground))
+	var adapters []*coreapi.OpenModel
+	for _, model := range models {
+		if roles[coreapi.ModelName(model.Name)] == coreapi.LoraRole {
+			adapters = append(adapters, model)
+		}
+	}
+
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(playground.Namespace), client.MatchingLabels{
+		lws.SetNameLabelKey:     playground.Name,
+		lws.WorkerIndexLabelKey: "0",
+	}); err != nil {
+		return 0, err
+	}
+	sort.Slice(pods.Items, func(i, j int) bool { return pods.Items[i].Name < pods.Items[j].Name })
+
+	previous := map[string]inferenceapi.PodLoRAAdapters{}
+	for _, status := range playground.Status.LoRAAdapters {
+		previous[status.PodName] = status
+	}
+
+	var initContainerImage string
+	if !helper.SkipModelLoader(playground) && len(adapters) > 0 {
+		configs, err := r.globalConfigs(ctx)
+		if err != nil {
+			return 0, err
+		}
+		initContainerImage = configs.InitContainerImage
+	}
+
+	var requeueAfter time.Duration
+	var statuses []inferenceapi.PodLoRAAdapters
+	served := sets.New[loraPod]()
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.DeletionTimestamp != nil || !podReady(pod) {
+			continue
+		}
+		runner := loraPod{uid: pod.UID, restartCount: modelRunnerRestartCount(pod)}
+		served.Insert(runner)
+
+		current := map[coreapi.ModelName]inferenceapi.LoRAAdapterStatus{}
+		if status, ok := previous[pod.Name]; ok && status.PodUID == runner.uid && status.RestartCount == runner.restartCount {
+			for _, adapter := range status.Adapters {
+				current[adapter.Name] = adapter
+			}
+		}
+
+		podStatus := inferenceapi.PodLoRAAdapters{PodName: pod.Name, PodUID: runner.uid, RestartCount: runner.restartCount}
+		desired := map[coreapi.ModelName]bool{}
+		for _, adapter := range adapters {
+			name := coreapi.ModelName(adapter.Name)
+			desired[name] = true
+
+			status := r.syncLoRAAdapter(ctx, pod, runner, playground, backendRuntime.Spec.DynamicLoRA, adapter, current[name], initContainerImage)
+			if status.State != inferenceapi.LoRAAdapterLoaded {
+				requeueAfter = loraAdapterSyncInterval
+			}
+			podStatus.Adapters = append(podStatus.Adapters, status)
+		}
+
+		for name, status := range current {
+			if desired[name] || status.State != inferenceapi.LoRAAdapterLoaded {
+				continue
+			}
+			key := loraRequestKey{pod: runner, adapter: name, unload: true}
+			done, err := r.loraRequests.request(key, playground, loraAdapterURL(pod, pathOrDefault(backendRuntime.Spec.DynamicLoRA.UnloadPath, defaultLoRAUnloadPath)), map[string]string{"lora_name": string(name)})
+			if !done || err != nil {
+				if err != nil {
+					logger.Error(err, "failed to unload lora adapter", "Pod", klog.KObj(pod), "adapter", name)
+				}
+				// Keep tracking the adapter until unloaded.
+				podStatus.Adapters = append(podStatus.Adapters, status)
+				requeueAfter = loraAdapterSyncInterval
+				continue
+			}
+			logger.Info("lora adapter unloaded", "Pod", klog.KObj(pod), "adapter", name)
+		}
+
+		statuses = append(statuses, podStatus)
+	}
+
+	r.loraRequests.forget(playground, served)
+	playground.Status.LoRAAdapters = statuses
+	return requeueAfter, nil
+}
+
+// syncLoRAAdapter moves the adapter in the pod towards the Loaded state, it will be downloaded
+// first unless the model loader is skipped, then loaded by the backendRuntime. The requests to
+// the backendRuntime are sent in the background, the Playground is enqueued once completed.
+func (r *PlaygroundReconciler) syncLoRAAdapter(ctx context.Context, pod *corev1.Pod, runner loraPod, playground *inferenceapi.Playground, dynamicLoRA *inferenceapi.DynamicLoRA, model *coreapi.OpenModel, status inferenceapi.LoRAAdapterStatus, initContainerImage string) inferenceapi.LoRAAdapterStatus {
+	logger := log.FromContext(ctx)
+
+	name := coreapi.ModelName(model.Name)
+	if status.State == inferenceapi.LoRAAdapterLoaded {
+		return status
+	}
+
+	source, err := modelSource.NewModelSourceProvider(model)
+	if err != nil {
+		return inferenceapi.LoRAAdapterStatus{Name: name, State: inferenceapi.LoRAAdapterFailed, Message: err.Error()}
+	}
+	if !helper.SkipModelLoader(playground) {
+		downloaded, err := r.downloadLoRAAdapter(ctx, pod, model, source, initContainerImage, status)
+		if err != nil {
+			return inferenceapi.LoRAAdapterStatus{Name: name, State: inferenceapi.LoRAAdapterFailed, Message: err.Error()}
+		}
+		if !downloaded {
+			return inferenceapi.LoRAAdapterStatus{Name: name, State: inferenceapi.LoRAAdapterDownloading}
+		}
+	}
+
+	body := map[string]string{
+		"lora_name": source.ModelName(),
+		"lora_path": source.ModelPath(helper.SkipModelLoader(playground)),
+	}
+	key := loraRequestKey{pod: runner, adapter: name}
+	done, err := r.loraRequests.request(key, playground, loraAdapterURL(pod, pathOrDefault(dynamicLoRA.LoadPath, defaultLoRALoadPath)), body)
+	if !done {
+		return inferenceapi.LoRAAdapterStatus{Name: name, State: inferenceapi.LoRAAdapterLoading}
+	}
+	if err != nil {
+		logger.Error(err, "failed to load lora adapter", "Pod", klog.KObj(pod), "adapter", name)
+		return inferenceapi.LoRAAdapterStatus{Name: name, State: inferenceapi.LoRAAdapterFailed, Message: err.Error()}
+	}
+	logger.Info("lora adapter loaded", "Pod", klog.KObj(pod), "adapter", name)
+	return inferenceapi.LoRAAdapterStatus{Name: name, State: inferenceapi.LoRAAdapterLoaded}
+}
+
+// downloadLoRAAdapter downloads the adapter into the model volume of the pod with an ephemeral
+// container running the model loader, returns true once the download completed. A failed
+// download is reported once, then retried with a new container until maxLoRALoaderAttempts.
+func (r *PlaygroundReconciler) downloadLoRAAdapter(ctx context.Context, pod *corev1.Pod, model *coreapi.OpenModel, source modelSource.ModelSourceProvider, initContainerImage string, status inferenceapi.LoRAAdapterStatus) (bool, error) {
+	attempts, latest := loraLoaderAttempts(pod, model.Name)
+	if attempts > 0 {
+		if latest == nil || latest.State.Terminated == nil {
+			// Created but not reported in the status yet, or still running.
+			return false, nil
+		}
+		terminated := latest.State.Terminated
+		if terminated.ExitCode == 0 {
+			return true, nil
+		}
+		err := fmt.Errorf("model loader exited with code %d: %s", terminated.ExitCode, terminated.Reason)
+		if status.State != inferenceapi.LoRAAdapterFailed {
+			return false, err
+		}
+		if attempts >= maxLoRALoaderAttempts {
+			return false, fmt.Errorf("%w, giving up after %d attempts", err, attempts)
+		}
+	}
+
+	container, err := buildLoRALoaderContainer(pod, source, initContainerImage)
+	if err != nil {
+		return false, err
+	}
+	if container == nil {
+		// Nothing to download.
+		return true, nil
+	}
+	container.Name = loraLoaderContainerName(model.Name, attempts)
+
+	pod.Spec.EphemeralContainers = append(pod.Spec.EphemeralContainers, corev1.EphemeralContainer{
+		EphemeralContainerCommon: corev1.EphemeralContainerCommon(*container),
+	})
+	if err := r.SubResource("ephemeralcontainers").Update(ctx, pod); err != nil {
+		return false, err
+	}
+	log.FromContext(ctx).Info("downloading lora adapter", "Pod", klog.KObj(pod), "adapter", model.Name, "attempt", attempts+1)
+	return false, nil
+}
+
+// loraLoaderAttempts returns the number of model loaders created for the adapter in the pod,
+// and the status of the latest one, which is nil until reported.
+func loraLoaderAttempts(pod *corev1.Pod, modelName string) (int, *corev1.ContainerStatus) {
+	containers := sets.New[string]()
+	for _, container := range pod.Spec.EphemeralContainers {
+		containers.Insert(container.Name)
+	}
+
+	attempts := 0
+	for containers.Has(loraLoaderContainerName(modelName, attempts)) {
+		attempts++
+	}
+	if attempts == 0 {
+		return 0, nil
+	}
+
+	latest := loraLoaderContainerName(modelName, attempts-1)
+	for i := range pod.Status.EphemeralContainerStatuses {
+		if pod.Status.EphemeralContainerStatuses[i].Name == latest {
+			return attempts, &pod.Status.EphemeralContainerStatuses[i]
+		}
+	}
+	return attempts, nil
+}
+
+// buildLoRALoaderContainer builds the model loader container the same way as the initContainer
+// injected by the model source provider, nil means no download is required.
+func buildLoRALoaderContainer(pod *corev1.Pod, source modelSource.ModelSourceProvider, initContainerImage string) (*corev1.Container, error) {
+	var runner *corev1.Container
+	for i := range pod.Spec.Containers {
+		if pod.Spec.Containers[i].Name == modelSource.MODEL_RUNNER_CONTAINER_NAME {
+			runner = &pod.Spec.Containers[i]
+		}
+	}
+	if runner == nil {
+		return nil, fmt.Errorf("container %s not found in pod %s", modelSource.MODEL_RUNNER_CONTAINER_NAME, pod.Name)
+	}
+
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&corev1.Container{Name: runner.Name, Env: runner.Env})
+	if err != nil {
+		return nil, err
+	}
+	var runnerApplyConfiguration coreapplyv1.ContainerApplyConfiguration
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj, &runnerApplyConfiguration); err != nil {
+		return nil, err
+	}
+
+	template := coreapplyv1.PodTemplateSpec().WithSpec(coreapplyv1.PodSpec().WithContainers(&runnerApplyConfiguration))
+	source.InjectModelLoader(template, 1, initContainerImage)
+	if len(template.Spec.InitContainers) == 0 {
+		if len(template.Spec.Volumes) > 0 {
+			return nil, fmt.Errorf("model source requires mounting volumes which is not supported by dynamic loading")
+		}
+		return nil, nil
+	}
+
+	var hasModelVolume bool
+	for _, volume := range pod.Spec.Volumes {
+		if volume.Name == modelSource.MODEL_VOLUME_NAME {
+			hasModelVolume = true
+		}
+	}
+	if !hasModelVolume {
+		return nil, fmt.Errorf("volume %s not found in pod %s", modelSource.MODEL_VOLUME_NAME, pod.Name)
+	}
+
+	obj, err = runtime.DefaultUnstructuredConverter.ToUnstructured(&template.Spec.InitContainers[0])
+	if err != nil {
+		return nil, err
+	}
+	container := &corev1.Container{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj, container); err != nil {
+		return nil, err
+	}
+	return container, nil
+}
+
+// loraAdapterURL returns the url of the path served by the backendRuntime in the pod.
+func loraAdapterURL(pod *corev1.Pod, path string) string {
+	return "http://" + net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(modelSource.DEFAULT_BACKEND_PORT)) + path
+}
+
+func pathOrDefault(path, defaultPath string) string {
+	if path == "" {
+		return defaultPath
+	}
+	return path
+}
+
+func (r *PlaygroundReconciler) globalConfigs(ctx context.Context) (*helper.GlobalConfigs, error) {
+	cm := &corev1.ConfigMap{}
+	if err := r.Get(ctx, types.NamespacedName{Name: helper.GlobalConfigMapName, Namespace: helper.GlobalConfigMapNamespace}, cm); err != nil {
+		return nil, err
+	}
+	return helper.ParseGlobalConfigmap(cm)
+}
+
+// loraLoaderContainerName returns the name of the ephemeral container downloading the adapter
+// in the attempt, which is limited to 63 characters. The first attempt has no suffix.
+func loraLoaderContainerName(modelName string, attempt int) string {
+	var suffix string
+	if attempt > 0 {
+		suffix = "-" + strconv.Itoa(attempt)
+	}
+	name := loraLoaderContainerPrefix + modelName
+	if len(name)+len(suffix) > 63 {
+		name = strings.TrimRight(name[:63-len(suffix)], "-.")
+	}
+	return name + suffix
+}
+
+func podReady(pod *corev1.Pod) bool {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// modelRunnerRestartCount returns the restart count of the model runner container in the pod.
+func modelRunnerRestartCount(pod *corev1.Pod) int32 {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == modelSource.MODEL_RUNNER_CONTAINER_NAME {
+			return status.RestartCount
+		}
+	}
+	return 0
+}
+
+// podToPlayground maps the leader pods to the Playground with the same name of the workload,
+// only Playgrounds with dynamic LoRA loading enabled are enqueued.
+func (r *PlaygroundReconciler) podToPlayground(ctx context.Context, obj client.Object) []reconcile.Request {
+	labels := obj.GetLabels()
+	if labels[lws.WorkerIndexLabelKey] != "0" {
+		return nil
+	}
+	name, ok := labels[lws.SetNameLabelKey]
+	if !ok {
+		return nil
+	}
+
+	playground := &inferenceapi.Playground{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: obj.GetNamespace(), Name: name}, playground); err != nil {
+		return nil
+	}
+	if !helper.DynamicLoRAEnabled(playground) {
+		return nil
+	}
+	return []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: obj.GetNamespace(), Name: name}},
+	}
+}
+
+func podLoRAStateChanged(oldPod, newPod *corev1.Pod) bool {
+	return oldPod.UID != newPod.UID ||
+		podReady(oldPod) != podReady(newPod) ||
+		modelRunnerRestartCount(oldPod) != modelRunnerRestartCount(newPod) ||
+		len(oldPod.Status.EphemeralContainerStatuses) != len(newPod.Status.EphemeralContainerStatuses) ||
+		!equalEphemeralContainerStates(oldPod.Status.EphemeralContainerStatuses, newPod.Status.EphemeralContainerStatuses)
+}
+
+func equalEphemeralContainerStates(a, b []corev1.ContainerStatus) bool {
+	for i := range a {
+		if (a[i].State.Terminated == nil) != (b[i].State.Terminated == nil) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,220 @@
+/*
+Copyright 2025 The InftyAI Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inference
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+
+	inferenceapi "github.com/inftyai/llmaz/api/inference/v1alpha1"
+)
+
+func TestLoRALoaderContainerName(t *testing.T) {
+	testCases := []struct {
+		name      string
+		modelName string
+		attempt   int
+		want      string
+	}{
+		{
+			name:      "first attempt",
+			modelName: "llama3-lora",
+			want:      "lora-loader-llama3-lora",
+		},
+		{
+			name:      "retry attempt",
+			modelName: "llama3-lora",
+			attempt:   2,
+			want:      "lora-loader-llama3-lora-2",
+		},
+		{
+			name:      "long model name",
+			modelName: strings.Repeat("a", 60),
+			attempt:   1,
+			want:      "lora-loader-" + strings.Repeat("a", 49) + "-1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := loraLoaderContainerName(tc.modelName, tc.attempt)
+			assert.Equal(t, tc.want, got)
+			assert.LessOrEqual(t, len(got), 63)
+		})
+	}
+}
+
+func TestLoRALoaderAttempts(t *testing.T) {
+	terminated := func(name string, exitCode int32) corev1.ContainerStatus {
+		return corev1.ContainerStatus{Name: name, State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: exitCode}}}
+	}
+	makePod := func(statuses []corev1.ContainerStatus, names ...string) *corev1.Pod {
+		pod := &corev1.Pod{Status: corev1.PodStatus{EphemeralContainerStatuses: statuses}}
+		for _, name := range names {
+			pod.Spec.EphemeralContainers = append(pod.Spec.EphemeralContainers, corev1.EphemeralContainer{
+				EphemeralContainerCommon: corev1.EphemeralContainerCommon{Name: name},
+			})
+		}
+		return pod
+	}
+
+	testCases := []struct {
+		name         string
+		pod          *corev1.Pod
+		wantAttempts int
+		wantExitCode *int32
+	}{
+		{
+			name: "no loaders",
+			pod:  makePod(nil, "lora-loader-qwen2-lora"),
+		},
+		{
+			name:         "created but not reported",
+			pod:          makePod(nil, "lora-loader-llama3-lora"),
+			wantAttempts: 1,
+		},
+		{
+			name: "latest attempt reported",
+			pod: makePod([]corev1.ContainerStatus{terminated("lora-loader-llama3-lora", 1), terminated("lora-loader-llama3-lora-1", 0)},
+				"lora-loader-llama3-lora", "lora-loader-llama3-lora-1"),
+			wantAttempts: 2,
+			wantExitCode: ptr.To[int32](0),
+		},
+		{
+			name:         "latest attempt not reported",
+			pod:          makePod([]corev1.ContainerStatus{terminated("lora-loader-llama3-lora", 1)}, "lora-loader-llama3-lora", "lora-loader-llama3-lora-1"),
+			wantAttempts: 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			attempts, latest := loraLoaderAttempts(tc.pod, "llama3-lora")
+			assert.Equal(t, tc.wantAttempts, attempts)
+			if tc.wantExitCode == nil {
+				assert.Nil(t, latest)
+				return
+			}
+			assert.Equal(t, *tc.wantExitCode, latest.State.Terminated.ExitCode)
+		})
+	}
+}
+
+func TestPostLoRAAdapter(t *testing.T) {
+	testCases := []struct {
+		name       string
+		unload     bool
+		statusCode int
+		response   string
+		wantErr    bool
+	}{
+		{
+			name:       "loaded",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "already loaded",
+			statusCode: http.StatusBadRequest,
+			response:   "The lora adapter 'llama3-lora' has already been loaded.",
+		},
+		{
+			name:       "unloaded already",
+			unload:     true,
+			statusCode: http.StatusBadRequest,
+			response:   "The lora adapter 'llama3-lora' cannot be found.",
+		},
+		{
+			name:       "invalid path",
+			statusCode: http.StatusBadRequest,
+			response:   "No adapter found under /workspace/models",
+			wantErr:    true,
+		},
+		{
+			name:       "server error",
+			statusCode: http.StatusInternalServerError,
+			response:   "The lora adapter 'llama3-lora' has already been loaded.",
+			wantErr:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body := map[string]string{}
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				assert.Equal(t, "llama3-lora", body["lora_name"])
+				w.WriteHeader(tc.statusCode)
+				_, _ = w.Write([]byte(tc.response))
+			}))
+			defer server.Close()
+
+			err := postLoRAAdapter(context.Background(), server.Client(), server.URL+defaultLoRALoadPath, map[string]string{"lora_name": "llama3-lora"}, tc.unload)
+			assert.Equal(t, tc.wantErr, err != nil, "unexpected error: %v", err)
+		})
+	}
+}
+
+func TestLoRARequestTracker(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	tracker := newLoRARequestTracker(server.Client())
+	playground := &inferenceapi.Playground{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "llama3"}}
+	key := loraRequestKey{pod: loraPod{uid: "uid-0"}, adapter: "llama3-lora"}
+	body := map[string]string{"lora_name": "llama3-lora"}
+
+	// The request is sent in the background without blocking the caller.
+	done, err := tracker.request(key, playground, server.URL, body)
+	assert.False(t, done)
+	assert.NoError(t, err)
+	done, _ = tracker.request(key, playground, server.URL, body)
+	assert.False(t, done, "request should be in flight")
+
+	close(release)
+	select {
+	case e := <-tracker.events:
+		assert.Equal(t, "default", e.Object.GetNamespace())
+		assert.Equal(t, "llama3", e.Object.GetName())
+	case <-time.After(10 * time.Second):
+		t.Fatal("the playground is not enqueued after the request completed")
+	}
+
+	done, err = tracker.request(key, playground, server.URL, body)
+	assert.True(t, done)
+	assert.NoError(t, err)
+
+	// Results of the pods not served anymore are dropped, including the restarted ones.
+	_, _ = tracker.request(key, playground, server.URL, body)
+	<-tracker.events
+	tracker.forget(playground, sets.New(loraPod{uid: "uid-0", restartCount: 1}))
+	assert.Empty(t, tracker.results)
+}
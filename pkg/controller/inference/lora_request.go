@@ -0,0 +1,170 @@
+/*
+Copyright 2025 The InftyAI Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inference
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	coreapi "github.com/inftyai/llmaz/api/core/v1alpha1"
+	inferenceapi "github.com/inftyai/llmaz/api/inference/v1alpha1"
+)
+
+// loraPod identifies a run of the model runner container, the loaded adapters are lost
+// once the pod is recreated or the container restarts.
+type loraPod struct {
+	uid          types.UID
+	restartCount int32
+}
+
+// loraRequestKey identifies a request to load or unload an adapter in a pod.
+type loraRequestKey struct {
+	pod     loraPod
+	adapter coreapi.ModelName
+	unload  bool
+}
+
+type loraRequestResult struct {
+	owner types.NamespacedName
+	done  bool
+	err   error
+}
+
+// loraRequestTracker sends the requests to load or unload the adapters in the background, so the
+// reconciler is not blocked by the backendRuntime. The owner Playground is sent to the events
+// channel once the request completes, which enqueues the Playground to consume the result.
+type loraRequestTracker struct {
+	httpClient *http.Client
+	events     chan event.GenericEvent
+
+	mu      sync.Mutex
+	results map[loraRequestKey]*loraRequestResult
+}
+
+func newLoRARequestTracker(httpClient *http.Client) *loraRequestTracker {
+	return &loraRequestTracker{
+		httpClient: httpClient,
+		events:     make(chan event.GenericEvent, 128),
+		results:    map[loraRequestKey]*loraRequestResult{},
+	}
+}
+
+// request returns the result once the request of the key completed, which is then forgotten,
+// so the next call sends the request again. Otherwise, the request is sent in the background
+// unless it's in flight already, and false is returned.
+func (t *loraRequestTracker) request(key loraRequestKey, owner *inferenceapi.Playground, url string, body map[string]string) (bool, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if result, ok := t.results[key]; ok {
+		if !result.done {
+			return false, nil
+		}
+		delete(t.results, key)
+		return true, result.err
+	}
+
+	result := &loraRequestResult{owner: types.NamespacedName{Namespace: owner.Namespace, Name: owner.Name}}
+	t.results[key] = result
+	go func() {
+		err := postLoRAAdapter(context.Background(), t.httpClient, url, body, key.unload)
+
+		t.mu.Lock()
+		result.done = true
+		result.err = err
+		t.mu.Unlock()
+
+		// Never block on a full channel, the Playground is requeued periodically until
+		// the requests complete anyway.
+		select {
+		case t.events <- event.GenericEvent{Object: &inferenceapi.Playground{
+			ObjectMeta: metav1.ObjectMeta{Namespace: result.owner.Namespace, Name: result.owner.Name},
+		}}:
+		default:
+		}
+	}()
+	return false, nil
+}
+
+// forget drops the completed results of the Playground which are not consumed, e.g. the pods
+// are deleted before that, pods are the ones still served by the Playground.
+func (t *loraRequestTracker) forget(owner client.Object, pods sets.Set[loraPod]) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for key, result := range t.results {
+		if result.done && result.owner.Namespace == owner.GetNamespace() && result.owner.Name == owner.GetName() && !pods.Has(key.pod) {
+			delete(t.results, key)
+		}
+	}
+}
+
+// postLoRAAdapter posts the body to the url of the backendRuntime, loading an adapter already
+// loaded or unloading an adapter not found is taken as success, which happens once the status
+// failed to update after the last request succeeded.
+func postLoRAAdapter(ctx context.Context, httpClient *http.Client, url string, body map[string]string, unload bool) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, loraAdapterRequestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+	message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	if resp.StatusCode == http.StatusBadRequest && loraAdapterUnchanged(string(message), unload) {
+		return nil
+	}
+	return fmt.Errorf("unexpected status code %d from %s: %s", resp.StatusCode, req.URL.Path, string(message))
+}
+
+// loraAdapterUnchanged returns true if the message says the adapter is loaded or unloaded already,
+// e.g. vLLM responds with "The lora adapter 'x' has already been loaded." on loading.
+func loraAdapterUnchanged(message string, unload bool) bool {
+	message = strings.ToLower(message)
+	if unload {
+		return strings.Contains(message, "cannot be found") || strings.Contains(message, "not found")
+	}
+	return strings.Contains(message, "already been loaded") || strings.Contains(message, "already loaded")
+}
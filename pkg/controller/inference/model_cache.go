@@ -0,0 +1,140 @@
+/*
+Copyright 2025 The InftyAI Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inference
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	coreapi "github.com/inftyai/llmaz/api/core/v1alpha1"
+	inferenceapi "github.com/inftyai/llmaz/api/inference/v1alpha1"
+	helper "github.com/inftyai/llmaz/pkg/controller_helper"
+	modelSource "github.com/inftyai/llmaz/pkg/controller_helper/modelsource"
+)
+
+// modelCacheRequeueInterval is the interval to check whether the model cache is ready,
+// the workloads load the models by themselves until then.
+const modelCacheRequeueInterval = 30 * time.Second
+
+// reconcileModelCaches makes the persistent volume caches of the models available in the namespace
+// of the service. It returns a positive duration if any cache is not ready yet.
+func (r *ServiceReconciler) reconcileModelCaches(ctx context.Context, service *inferenceapi.Service, models []*coreapi.OpenModel) (time.Duration, error) {
+	if helper.SkipModelLoader(service) {
+		return 0, nil
+	}
+
+	var requeueAfter time.Duration
+	for _, model := range models {
+		if !modelSource.ModelCacheEnabled(model) || modelSource.ModelCacheType(model) != coreapi.PersistentVolumeModelCache {
+			continue
+		}
+		if !modelSource.ModelCachedInVolume(model) {
+			requeueAfter = modelCacheRequeueInterval
+			continue
+		}
+		if err := r.ensureModelCacheClaim(ctx, model, service.Namespace); err != nil {
+			return 0, err
+		}
+	}
+	return requeueAfter, nil
+}
+
+// ensureModelCacheClaim makes sure the claim of the model cache exists in the namespace.
+// Claims are namespaced, so the volume bound to the cache claim in the namespace of llmaz
+// is cloned read-only for other namespaces, sharing the same underlying storage.
+func (r *ServiceReconciler) ensureModelCacheClaim(ctx context.Context, model *coreapi.OpenModel, namespace string) error {
+	name := modelSource.ModelCacheName(model.Name)
+	if namespace == helper.GlobalConfigMapNamespace {
+		return nil
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, pvc); err == nil {
+		return nil
+	} else if !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	source := &corev1.PersistentVolumeClaim{}
+	if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: helper.GlobalConfigMapNamespace}, source); err != nil {
+		return err
+	}
+	if source.Spec.VolumeName == "" {
+		return fmt.Errorf("claim %s/%s of the model cache is not bound", source.Namespace, source.Name)
+	}
+	sourceVolume := &corev1.PersistentVolume{}
+	if err := r.Get(ctx, types.NamespacedName{Name: source.Spec.VolumeName}, sourceVolume); err != nil {
+		return err
+	}
+
+	labels := map[string]string{coreapi.ModelCacheLabelKey: model.Name}
+	volume := &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   sourceVolume.Name + "-" + namespace,
+			Labels: labels,
+		},
+		Spec: corev1.PersistentVolumeSpec{
+			Capacity:                      sourceVolume.Spec.Capacity,
+			PersistentVolumeSource:        sourceVolume.Spec.PersistentVolumeSource,
+			AccessModes:                   []corev1.PersistentVolumeAccessMode{corev1.ReadOnlyMany},
+			PersistentVolumeReclaimPolicy: corev1.PersistentVolumeReclaimRetain,
+			MountOptions:                  sourceVolume.Spec.MountOptions,
+			VolumeMode:                    sourceVolume.Spec.VolumeMode,
+			NodeAffinity:                  sourceVolume.Spec.NodeAffinity,
+			ClaimRef: &corev1.ObjectReference{
+				Kind:       "PersistentVolumeClaim",
+				APIVersion: "v1",
+				Name:       name,
+				Namespace:  namespace,
+			},
+		},
+	}
+	if err := controllerutil.SetControllerReference(model, volume, r.Scheme); err != nil {
+		return err
+	}
+	if err := r.Create(ctx, volume); err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+
+	pvc = &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadOnlyMany},
+			StorageClassName: new(string),
+			VolumeName:       volume.Name,
+			VolumeMode:       sourceVolume.Spec.VolumeMode,
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: sourceVolume.Spec.Capacity,
+			},
+		},
+	}
+	if err := controllerutil.SetControllerReference(model, pvc, r.Scheme); err != nil {
+		return err
+	}
+	return r.Create(ctx, pvc)
+}
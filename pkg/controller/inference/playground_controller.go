@@ -19,7 +19,10 @@ package inference
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"reflect"
+	"slices"
+	"time"
 
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
@@ -40,6 +43,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 	lws "sigs.k8s.io/lws/api/leaderworkerset/v1"
 
 	coreapi "github.com/inftyai/llmaz/api/core/v1alpha1"
@@ -57,13 +61,17 @@ type PlaygroundReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
 	Record record.EventRecorder
+
+	// loraRequests requests the backendRuntime to load LoRA adapters dynamically.
+	loraRequests *loraRequestTracker
 }
 
 func NewPlaygroundReconciler(client client.Client, scheme *runtime.Scheme, record record.EventRecorder) *PlaygroundReconciler {
 	return &PlaygroundReconciler{
-		Client: client,
-		Scheme: scheme,
-		Record: record,
+		Client:       client,
+		Scheme:       scheme,
+		Record:       record,
+		loraRequests: newLoRARequestTracker(&http.Client{Timeout: loraAdapterRequestTimeout}),
 	}
 }
 
@@ -71,6 +79,9 @@ func NewPlaygroundReconciler(client client.Client, scheme *runtime.Scheme, recor
 //+kubebuilder:rbac:groups=inference.llmaz.io,resources=playgrounds/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=inference.llmaz.io,resources=playgrounds/finalizers,verbs=update
 //+kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=pods/ephemeralcontainers,verbs=update
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -116,7 +127,14 @@ func (r *PlaygroundReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, err
 	}
 
-	serviceApplyConfiguration, err := buildServiceApplyConfiguration(models, playground, backendRuntime)
+	// Warm-up schedules raise the replicas ahead of demand, the Playground will be
+	// requeued once the active windows change.
+	warmupReplicas, requeueAfter := helper.WarmupReplicas(playground, time.Now())
+	if warmupReplicas > 0 {
+		logger.V(2).Info("warm-up schedule is active", "Playground", klog.KObj(playground), "replicas", warmupReplicas)
+	}
+
+	serviceApplyConfiguration, err := buildServiceApplyConfiguration(models, playground, backendRuntime, warmupReplicas)
 	if err != nil {
 		logger.Error(err, "failed to build inference Service")
 		return ctrl.Result{}, err
@@ -130,7 +148,8 @@ func (r *PlaygroundReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, err
 	}
 
-	scalingConfiguration := buildScalingConfiguration(playground, backendRuntime)
+	parser := backendruntime.NewBackendRuntimeParser(backendRuntime, models, playground)
+	scalingConfiguration := buildScalingConfiguration(playground, parser, warmupReplicas)
 	if scalingConfiguration != nil {
 		if err := setControllerReferenceForScalingConfiguration(playground, scalingConfiguration, r.Scheme); err != nil {
 			logger.Error(err, "failed to set OwnerReference for scaling workload", "workload", fmt.Sprintf("%s/%s", playground.Namespace, playground.Name), "kind", scalingConfiguration.Kind)
@@ -142,14 +161,25 @@ func (r *PlaygroundReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		}
 	}
 
+	// Load the lora models into the running pods if dynamic loading enabled.
+	loraRequeueAfter, err := r.reconcileLoRAAdapters(ctx, playground, backendRuntime, models)
+	if err != nil {
+		logger.Error(err, "failed to reconcile lora adapters", "Playground", klog.KObj(playground))
+		return ctrl.Result{}, err
+	}
+	if loraRequeueAfter > 0 && (requeueAfter == 0 || loraRequeueAfter < requeueAfter) {
+		requeueAfter = loraRequeueAfter
+	}
+
 	// Handle status.
 	setPlaygroundCondition(playground, service)
+	setPlaygroundStatus(playground, service, backendRuntime)
 	if err := r.Client.Status().Update(ctx, playground); err != nil {
 		logger.Error(err, "failed to update Playground status", "Playground", klog.KObj(playground))
 		return ctrl.Result{}, err
 	}
 
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -159,8 +189,9 @@ func (r *PlaygroundReconciler) SetupWithManager(mgr ctrl.Manager) error {
 
 		modelName := obj.GetName()
 
+		// The namespace is empty for the OpenModels, which lists the playgrounds of all namespaces.
 		playgrounds := &inferenceapi.PlaygroundList{}
-		err := r.List(ctx, playgrounds, client.MatchingLabels{coreapi.ModelNameLabelKey: modelName})
+		err := r.List(ctx, playgrounds, client.InNamespace(obj.GetNamespace()), client.MatchingLabels{coreapi.ModelNameLabelKey: modelName})
 		if err != nil {
 			logger.Error(err, "failed to list playgrounds")
 			return nil
@@ -170,7 +201,8 @@ func (r *PlaygroundReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		for _, playground := range playgrounds.Items {
 			reqs = append(reqs, ctrl.Request{
 				NamespacedName: types.NamespacedName{
-					Name: playground.Name,
+					Namespace: playground.Namespace,
+					Name:      playground.Name,
 				},
 			})
 		}
@@ -188,16 +220,35 @@ func (r *PlaygroundReconciler) SetupWithManager(mgr ctrl.Manager) error {
 					return !reflect.DeepEqual(oldBar.Status, newBar.Status)
 				},
 			})).
+		Watches(&corev1.Pod{}, handler.EnqueueRequestsFromMapFunc(r.podToPlayground),
+			builder.WithPredicates(predicate.Funcs{
+				UpdateFunc: func(e event.UpdateEvent) bool {
+					return podLoRAStateChanged(e.ObjectOld.(*corev1.Pod), e.ObjectNew.(*corev1.Pod))
+				},
+				DeleteFunc:  func(e event.DeleteEvent) bool { return false },
+				GenericFunc: func(e event.GenericEvent) bool { return false },
+			})).
+		WatchesRawSource(source.Channel(r.loraRequests.events, &handler.EnqueueRequestForObject{})).
 		Watches(&coreapi.OpenModel{}, handler.EnqueueRequestsFromMapFunc(mapFn),
 			builder.WithPredicates(predicate.Funcs{
-				UpdateFunc:  func(e event.UpdateEvent) bool { return false },
+				UpdateFunc: func(e event.UpdateEvent) bool {
+					return modelStatusChanged(e.ObjectOld, e.ObjectNew)
+				},
+				DeleteFunc:  func(e event.DeleteEvent) bool { return false },
+				GenericFunc: func(e event.GenericEvent) bool { return false },
+			})).
+		Watches(&coreapi.Model{}, handler.EnqueueRequestsFromMapFunc(mapFn),
+			builder.WithPredicates(predicate.Funcs{
+				UpdateFunc: func(e event.UpdateEvent) bool {
+					return modelStatusChanged(e.ObjectOld, e.ObjectNew)
+				},
 				DeleteFunc:  func(e event.DeleteEvent) bool { return false },
 				GenericFunc: func(e event.GenericEvent) bool { return false },
 			})).
 		Complete(r)
 }
 
-func buildServiceApplyConfiguration(models []*coreapi.OpenModel, playground *inferenceapi.Playground, backendRuntime *inferenceapi.BackendRuntime) (*inferenceclientgo.ServiceApplyConfiguration, error) {
+func buildServiceApplyConfiguration(models []*coreapi.OpenModel, playground *inferenceapi.Playground, backendRuntime *inferenceapi.BackendRuntime, warmupReplicas int32) (*inferenceclientgo.ServiceApplyConfiguration, error) {
 	// Build metadata
 	serviceApplyConfiguration := inferenceclientgo.Service(playground.Name, playground.Namespace)
 
@@ -208,8 +259,20 @@ func buildServiceApplyConfiguration(models []*coreapi.OpenModel, playground *inf
 				inferenceapi.SkipModelLoaderAnnoKey: value,
 			})
 		}
+		// Propagate llmaz.io/flavor-fallback-timeout annotation to Inference Service.
+		if value, exists := annotations[inferenceapi.FlavorFallbackTimeoutAnnoKey]; exists {
+			serviceApplyConfiguration.WithAnnotations(map[string]string{
+				inferenceapi.FlavorFallbackTimeoutAnnoKey: value,
+			})
+		}
 	}
 
+	// The Service reports the resolved backendRuntime in its status.
+	serviceApplyConfiguration.WithAnnotations(map[string]string{
+		inferenceapi.BackendRuntimeNameAnnoKey:    backendRuntime.Name,
+		inferenceapi.BackendRuntimeVersionAnnoKey: backendRuntimeVersion(playground, backendRuntime),
+	})
+
 	// Build spec.
 	spec := inferenceclientgo.ServiceSpec()
 
@@ -225,6 +288,10 @@ func buildServiceApplyConfiguration(models []*coreapi.OpenModel, playground *inf
 			if model.Role != nil {
 				role = *model.Role
 			}
+			// The lora models are loaded into the running pods rather than the pod template.
+			if role == coreapi.LoraRole && helper.DynamicLoRAEnabled(playground) {
+				continue
+			}
 			mr := coreclientgo.ModelRef().WithName(model.Name).WithRole(role)
 			mrs = append(mrs, mr)
 		}
@@ -241,8 +308,13 @@ func buildServiceApplyConfiguration(models []*coreapi.OpenModel, playground *inf
 	}
 
 	spec.WithWorkloadTemplate(template)
-	spec.WithReplicas(*playground.Spec.Replicas)
+	// The replicas will not be less than the warm-up replicas, even the Playground
+	// is scaled to zero by the activator.
+	spec.WithReplicas(max(*playground.Spec.Replicas, warmupReplicas))
 	spec.WithRolloutStrategy(lws.RolloutStrategy{Type: lws.RollingUpdateStrategyType})
+	if playground.Spec.ElasticConfig != nil && playground.Spec.ElasticConfig.ScaleToZero != nil {
+		spec.WithScaleToZero(buildScaleToZeroApplyConfiguration(playground.Spec.ElasticConfig.ScaleToZero))
+	}
 	serviceApplyConfiguration.WithSpec(spec)
 
 	return serviceApplyConfiguration, nil
@@ -250,34 +322,77 @@ func buildServiceApplyConfiguration(models []*coreapi.OpenModel, playground *inf
 	// TODO: handle MultiModelsClaims in the future.
 }
 
+func buildScaleToZeroApplyConfiguration(config *inferenceapi.ScaleToZero) *inferenceclientgo.ScaleToZeroApplyConfiguration {
+	scaleToZero := inferenceclientgo.ScaleToZero()
+	if config.Enabled != nil {
+		scaleToZero.WithEnabled(*config.Enabled)
+	}
+	if config.IdleTimeout != nil {
+		scaleToZero.WithIdleTimeout(*config.IdleTimeout)
+	}
+	if config.ColdStartTimeout != nil {
+		scaleToZero.WithColdStartTimeout(*config.ColdStartTimeout)
+	}
+	return scaleToZero
+}
+
 // We do not want to maintain another workload like deployment for single-host cases so we choose lws here
 // to cover both single-host and multi-host cases. There're some shortages for lws like can not force rolling
 // update when one replica failed, we'll fix this in the kubernetes upstream.
 // Model flavors will not be considered but in inferenceService controller to support accelerator fungibility.
 func buildWorkloadTemplate(models []*coreapi.OpenModel, playground *inferenceapi.Playground, backendRuntime *inferenceapi.BackendRuntime) (lws.LeaderWorkerTemplate, error) {
-	// workload size is 1 for now until we support multi-host in Playground.
-	workload := lws.LeaderWorkerTemplate{}
+	parser := backendruntime.NewBackendRuntimeParser(backendRuntime, models, playground)
+	size := parser.Size()
+	workload := lws.LeaderWorkerTemplate{Size: ptr.To[int32](size)}
+
+	if size == 1 {
+		template, err := buildTemplate(parser, playground, backendRuntime, parser.Command(), parser.Args)
+		if err != nil {
+			return lws.LeaderWorkerTemplate{}, err
+		}
+		workload.WorkerTemplate = template
+		return workload, nil
+	}
 
-	template, err := buildTemplate(models, playground, backendRuntime)
+	// Multi-node inference, the leader serves the requests while the workers join the group.
+	leaderTemplate, err := buildTemplate(parser, playground, backendRuntime, parser.LeaderCommand(), parser.LeaderArgs)
 	if err != nil {
 		return lws.LeaderWorkerTemplate{}, err
 	}
-
-	workload.WorkerTemplate = template
+	workerTemplate, err := buildTemplate(parser, playground, backendRuntime, parser.WorkerCommand(), parser.WorkerArgs)
+	if err != nil {
+		return lws.LeaderWorkerTemplate{}, err
+	}
+	// Workers don't expose the inference endpoint.
+	container := &workerTemplate.Spec.Containers[0]
+	container.Ports = nil
+	container.Lifecycle = nil
+	container.StartupProbe = nil
+	container.LivenessProbe = nil
+	container.ReadinessProbe = nil
+
+	workload.LeaderTemplate = &leaderTemplate
+	workload.WorkerTemplate = workerTemplate
+	// The group is unusable once any pod of it fails, recreate all of them together.
+	workload.RestartPolicy = lws.RecreateGroupOnPodRestart
 	return workload, nil
 }
 
-func buildTemplate(models []*coreapi.OpenModel, playground *inferenceapi.Playground, backendRuntime *inferenceapi.BackendRuntime) (corev1.PodTemplateSpec, error) {
-	parser := backendruntime.NewBackendRuntimeParser(backendRuntime, models, playground)
-
-	// envs
-	envs := parser.Envs()
+func buildTemplate(parser *backendruntime.BackendRuntimeParser, playground *inferenceapi.Playground, backendRuntime *inferenceapi.BackendRuntime, command []string, argsFunc func() ([]string, error)) (corev1.PodTemplateSpec, error) {
+	// envs, cloned not to modify the backendRuntime in the cache.
+	envs := slices.Clone(parser.Envs())
+	if helper.DynamicLoRAEnabled(playground) {
+		if backendRuntime.Spec.DynamicLoRA == nil {
+			return corev1.PodTemplateSpec{}, fmt.Errorf("backendRuntime %s doesn't support dynamic LoRA loading", backendRuntime.Name)
+		}
+		envs = util.MergeEnvs(envs, backendRuntime.Spec.DynamicLoRA.Envs)
+	}
 	if playground.Spec.BackendRuntimeConfig != nil {
 		envs = util.MergeEnvs(envs, playground.Spec.BackendRuntimeConfig.Envs)
 	}
 
 	// args
-	args, err := parser.Args()
+	args, err := argsFunc()
 	if err != nil {
 		return corev1.PodTemplateSpec{}, err
 	}
@@ -319,29 +434,12 @@ func buildTemplate(models []*coreapi.OpenModel, playground *inferenceapi.Playgro
 		version = *playground.Spec.BackendRuntimeConfig.Version
 	}
 
-	// command
-	command := parser.Command()
-
 	// lifecycle
 	lifecycle := parser.Lifecycle()
 
-	// probe
-	var livenessProbe, readinessProbe, startupProbe *corev1.Probe
-	if backendRuntime.Spec.StartupProbe != nil {
-		startupProbe = backendRuntime.Spec.StartupProbe
-	}
-	if backendRuntime.Spec.LivenessProbe != nil {
-		livenessProbe = backendRuntime.Spec.LivenessProbe
-	}
-	if backendRuntime.Spec.ReadinessProbe != nil {
-		readinessProbe = backendRuntime.Spec.ReadinessProbe
-	}
-
 	template := corev1.PodTemplateSpec{
 		Spec: corev1.PodSpec{
-			// TODO: The timeout is mainly quoted from https://github.com/kubernetes-sigs/gateway-api-inference-extension/blob/v0.3.0/config/manifests/vllm/gpu-deployment.yaml#L170-L226.
-			// We should support this in the upstream inference engines in the future.
-			TerminationGracePeriodSeconds: ptr.To[int64](130),
+			TerminationGracePeriodSeconds: ptr.To(parser.TerminationGracePeriodSeconds()),
 			// TODO: should we support image pull secret here?
 			Containers: []corev1.Container{
 				{
@@ -359,9 +457,9 @@ func buildTemplate(models []*coreapi.OpenModel, playground *inferenceapi.Playgro
 							ContainerPort: modelSource.DEFAULT_BACKEND_PORT,
 						},
 					},
-					StartupProbe:   startupProbe,
-					LivenessProbe:  livenessProbe,
-					ReadinessProbe: readinessProbe,
+					StartupProbe:   parser.StartupProbe(),
+					LivenessProbe:  parser.LivenessProbe(),
+					ReadinessProbe: parser.ReadinessProbe(),
 				},
 			},
 		},
@@ -442,8 +540,20 @@ func setPlaygroundCondition(playground *inferenceapi.Playground, service *infere
 		}
 	}()
 
+	serviceAvailable := apimeta.IsStatusConditionTrue(service.Status.Conditions, inferenceapi.ServiceAvailable)
+
+	// The Service stops progressing once the model loaders failed, e.g. the checksums mismatched.
+	if condition := apimeta.FindStatusCondition(service.Status.Conditions, inferenceapi.ServiceProgressing); !serviceAvailable && condition != nil && condition.Status == metav1.ConditionFalse {
+		return apimeta.SetStatusCondition(&playground.Status.Conditions, metav1.Condition{
+			Type:    inferenceapi.PlaygroundProgressing,
+			Status:  metav1.ConditionFalse,
+			Reason:  condition.Reason,
+			Message: condition.Message,
+		})
+	}
+
 	// For the start up or Playground is recovered from AbortProcessing.
-	if len(playground.Status.Conditions) == 0 || apimeta.IsStatusConditionFalse(playground.Status.Conditions, inferenceapi.PlaygroundProgressing) {
+	if len(playground.Status.Conditions) == 0 || (!serviceAvailable && apimeta.IsStatusConditionFalse(playground.Status.Conditions, inferenceapi.PlaygroundProgressing)) {
 		condition := metav1.Condition{
 			Type:    inferenceapi.PlaygroundProgressing,
 			Status:  metav1.ConditionTrue,
@@ -453,14 +563,21 @@ func setPlaygroundCondition(playground *inferenceapi.Playground, service *infere
 		return apimeta.SetStatusCondition(&playground.Status.Conditions, condition)
 	}
 
-	if apimeta.IsStatusConditionTrue(service.Status.Conditions, inferenceapi.ServiceAvailable) {
+	if serviceAvailable {
 		condition := metav1.Condition{
 			Type:    inferenceapi.PlaygroundAvailable,
 			Status:  metav1.ConditionTrue,
 			Reason:  "PlaygroundReady",
 			Message: "Playground is ready",
 		}
-		return apimeta.SetStatusCondition(&playground.Status.Conditions, condition)
+		changed = apimeta.SetStatusCondition(&playground.Status.Conditions, condition)
+
+		// Recovered from the model loader failures of the Service.
+		if apimeta.IsStatusConditionFalse(playground.Status.Conditions, inferenceapi.PlaygroundProgressing) {
+			condition.Type = inferenceapi.PlaygroundProgressing
+			changed = apimeta.SetStatusCondition(&playground.Status.Conditions, condition) || changed
+		}
+		return changed
 	} else {
 		// Still in starting up, no need to populate the condition.
 		if apimeta.FindStatusCondition(playground.Status.Conditions, inferenceapi.PlaygroundAvailable) == nil {
@@ -487,6 +604,17 @@ func setPlaygroundCondition(playground *inferenceapi.Playground, service *infere
 	}
 }
 
+// setPlaygroundStatus reports what is really running behind the Playground, the flavor,
+// image and model path are resolved by the inference Service.
+func setPlaygroundStatus(playground *inferenceapi.Playground, service *inferenceapi.Service, backendRuntime *inferenceapi.BackendRuntime) {
+	playground.Status.ObservedGeneration = playground.Generation
+	playground.Status.BackendRuntimeName = inferenceapi.BackendName(backendRuntime.Name)
+	playground.Status.BackendRuntimeVersion = backendRuntimeVersion(playground, backendRuntime)
+	playground.Status.Flavor = service.Status.Flavor
+	playground.Status.Image = service.Status.Image
+	playground.Status.ModelPath = service.Status.ModelPath
+}
+
 // setControllerReferenceForService set playground as the owner reference for inferenceService.
 func setControllerReferenceForService(owner metav1.Object, saf *inferenceclientgo.ServiceApplyConfiguration, scheme *runtime.Scheme) error {
 	ro, ok := owner.(runtime.Object)
@@ -508,28 +636,26 @@ func setControllerReferenceForService(owner metav1.Object, saf *inferenceclientg
 }
 
 // buildScalingConfiguration supports HPA only now.
-func buildScalingConfiguration(playground *inferenceapi.Playground, backend *inferenceapi.BackendRuntime) *autoscalingv2.HorizontalPodAutoscaler {
+// The scaleTrigger of the backendRuntime comes from the recommended config matched by the parser.
+func buildScalingConfiguration(playground *inferenceapi.Playground, parser *backendruntime.BackendRuntimeParser, warmupReplicas int32) *autoscalingv2.HorizontalPodAutoscaler {
 	if playground.Spec.ElasticConfig == nil {
 		return nil
 	}
 
 	// Prefer the playground config.
 	if playground.Spec.ElasticConfig != nil && playground.Spec.ElasticConfig.ScaleTrigger != nil {
-		hpa := newHPA(playground)
+		hpa := newHPA(playground, warmupReplicas)
 		hpa.Spec.Metrics = playground.Spec.ElasticConfig.ScaleTrigger.HPA.Metrics
 		hpa.Spec.Behavior = playground.Spec.ElasticConfig.ScaleTrigger.HPA.Behavior
 		return hpa
 
 	}
 
-	mode := helper.DetectArgFrom(playground)
-	for _, recommend := range backend.Spec.RecommendedConfigs {
-		if recommend.Name == mode && recommend.ScaleTrigger != nil {
-			hpa := newHPA(playground)
-			hpa.Spec.Metrics = recommend.ScaleTrigger.HPA.Metrics
-			hpa.Spec.Behavior = recommend.ScaleTrigger.HPA.Behavior
-			return hpa
-		}
+	if trigger := parser.ScaleTrigger(); trigger != nil && trigger.HPA != nil {
+		hpa := newHPA(playground, warmupReplicas)
+		hpa.Spec.Metrics = trigger.HPA.Metrics
+		hpa.Spec.Behavior = trigger.HPA.Behavior
+		return hpa
 	}
 
 	return nil
@@ -561,7 +687,7 @@ func setControllerReferenceForScalingConfiguration(owner metav1.Object, hpa *aut
 	return nil
 }
 
-func newHPA(playground *inferenceapi.Playground) *autoscalingv2.HorizontalPodAutoscaler {
+func newHPA(playground *inferenceapi.Playground, warmupReplicas int32) *autoscalingv2.HorizontalPodAutoscaler {
 	hpa := &autoscalingv2.HorizontalPodAutoscaler{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: autoscalingv2.SchemeGroupVersion.String(),
@@ -583,5 +709,18 @@ func newHPA(playground *inferenceapi.Playground) *autoscalingv2.HorizontalPodAut
 	hpa.Spec.MinReplicas = playground.Spec.ElasticConfig.MinReplicas
 	hpa.Spec.MaxReplicas = playground.Spec.ElasticConfig.MaxReplicas
 
+	// Raise the minReplicas during the warm-up window to prevent HPA from scaling down.
+	if warmupReplicas > 0 && (hpa.Spec.MinReplicas == nil || *hpa.Spec.MinReplicas < warmupReplicas) {
+		hpa.Spec.MinReplicas = ptr.To(min(warmupReplicas, hpa.Spec.MaxReplicas))
+	}
+
 	return hpa
 }
+
+// backendRuntimeVersion returns the version of the backendRuntime, which could be overridden by the Playground.
+func backendRuntimeVersion(playground *inferenceapi.Playground, backendRuntime *inferenceapi.BackendRuntime) string {
+	if playground.Spec.BackendRuntimeConfig != nil && playground.Spec.BackendRuntimeConfig.Version != nil {
+		return *playground.Spec.BackendRuntimeConfig.Version
+	}
+	return backendRuntime.Spec.Version
+}
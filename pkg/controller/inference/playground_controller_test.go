@@ -0,0 +1,146 @@
+/*
+Copyright 2025 The InftyAI Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inference
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	coreapi "github.com/inftyai/llmaz/api/core/v1alpha1"
+	inferenceapi "github.com/inftyai/llmaz/api/inference/v1alpha1"
+	backendruntime "github.com/inftyai/llmaz/pkg/controller_helper/backendruntime"
+	modelSource "github.com/inftyai/llmaz/pkg/controller_helper/modelsource"
+	"github.com/inftyai/llmaz/test/util/wrapper"
+)
+
+func TestBuildScalingConfiguration(t *testing.T) {
+	cpuTrigger := func(utilization int32) *inferenceapi.ScaleTrigger {
+		return &inferenceapi.ScaleTrigger{HPA: &inferenceapi.HPATrigger{Metrics: []autoscalingv2.MetricSpec{{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricSource{
+				Name:   corev1.ResourceCPU,
+				Target: autoscalingv2.MetricTarget{Type: autoscalingv2.UtilizationMetricType, AverageUtilization: ptr.To(utilization)},
+			},
+		}}}}
+	}
+
+	backend := wrapper.MakeBackendRuntime("vllm").Obj()
+	backend.Spec.RecommendedConfigs = []inferenceapi.RecommendedConfig{
+		{Name: "default", Flavors: []coreapi.FlavorName{"a100"}, ScaleTrigger: cpuTrigger(50)},
+		{Name: "default", Flavors: []coreapi.FlavorName{"l4"}, ScaleTrigger: cpuTrigger(80)},
+	}
+	model := wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithURI("s3://bucket/llama3-8b").
+		InferenceFlavors(*wrapper.MakeFlavor("a100").SetRequest("nvidia.com/gpu", "1").Obj(), *wrapper.MakeFlavor("l4").SetRequest("nvidia.com/gpu", "1").Obj()).
+		Obj()
+
+	testCases := []struct {
+		name            string
+		playground      *inferenceapi.Playground
+		wantUtilization *int32
+	}{
+		{
+			name:       "no elastic config",
+			playground: wrapper.MakePlayground("llama3", "default").ModelClaim(model.Name, "l4").Obj(),
+		},
+		{
+			name:            "scaleTrigger of the first flavor",
+			playground:      wrapper.MakePlayground("llama3", "default").ModelClaim(model.Name).ElasticConfig(1, 3).Obj(),
+			wantUtilization: ptr.To[int32](50),
+		},
+		{
+			name:            "scaleTrigger of the claimed flavor",
+			playground:      wrapper.MakePlayground("llama3", "default").ModelClaim(model.Name, "l4").ElasticConfig(1, 3).Obj(),
+			wantUtilization: ptr.To[int32](80),
+		},
+		{
+			name: "scaleTrigger of the playground takes precedence",
+			playground: wrapper.MakePlayground("llama3", "default").ModelClaim(model.Name, "l4").ElasticConfig(1, 3).
+				HPA(cpuTrigger(30).HPA).Obj(),
+			wantUtilization: ptr.To[int32](30),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			parser := backendruntime.NewBackendRuntimeParser(backend, []*coreapi.OpenModel{model}, tc.playground)
+			hpa := buildScalingConfiguration(tc.playground, parser, 0)
+			if tc.wantUtilization == nil {
+				assert.Nil(t, hpa)
+				return
+			}
+			if assert.NotNil(t, hpa) && assert.Len(t, hpa.Spec.Metrics, 1) {
+				assert.Equal(t, tc.wantUtilization, hpa.Spec.Metrics[0].Resource.Target.AverageUtilization)
+			}
+		})
+	}
+}
+
+func TestSetPlaygroundConditionModelLoaderFailure(t *testing.T) {
+	playground := wrapper.MakePlayground("llama3", "default").ModelClaim("llama3-8b").Obj()
+	service := wrapper.MakeService("llama3", "default").Obj()
+
+	setPlaygroundCondition(playground, service)
+	assert.True(t, apimeta.IsStatusConditionTrue(playground.Status.Conditions, inferenceapi.PlaygroundProgressing))
+
+	service.Status.Conditions = []metav1.Condition{{
+		Type:   inferenceapi.ServiceProgressing,
+		Status: metav1.ConditionFalse,
+		Reason: modelSource.ReasonChecksumMismatch,
+	}}
+	setPlaygroundCondition(playground, service)
+	progressing := apimeta.FindStatusCondition(playground.Status.Conditions, inferenceapi.PlaygroundProgressing)
+	assert.Equal(t, metav1.ConditionFalse, progressing.Status)
+	assert.Equal(t, modelSource.ReasonChecksumMismatch, progressing.Reason)
+
+	// Recovered once the Service is available.
+	service.Status.Conditions = []metav1.Condition{
+		{Type: inferenceapi.ServiceProgressing, Status: metav1.ConditionTrue, Reason: "ServiceReady"},
+		{Type: inferenceapi.ServiceAvailable, Status: metav1.ConditionTrue, Reason: "ServiceReady"},
+	}
+	assert.True(t, setPlaygroundCondition(playground, service))
+	assert.True(t, apimeta.IsStatusConditionTrue(playground.Status.Conditions, inferenceapi.PlaygroundAvailable))
+	assert.True(t, apimeta.IsStatusConditionTrue(playground.Status.Conditions, inferenceapi.PlaygroundProgressing))
+}
+
+func TestBuildWorkloadTemplateEnvs(t *testing.T) {
+	backend := wrapper.MakeBackendRuntime("sglang").Image("lmsysorg/sglang").Version("v0.4.0").Command([]string{"python3", "-m", "sglang.launch_server"}).
+		Arg("default", []string{"--model-path", "{{ .ModelPath }}"}).
+		MultiNode("default", inferenceapi.MultiNodeConfig{Size: ptr.To[int32](2)}).Obj()
+	// Spare capacity to catch the appends writing into the backendRuntime.
+	backend.Spec.Envs = append(make([]corev1.EnvVar, 0, 4), corev1.EnvVar{Name: "NCCL_DEBUG", Value: "INFO"})
+	model := wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithURI("s3://bucket/llama3-8b").Obj()
+	playground := wrapper.MakePlayground("llama3", "default").ModelClaim(model.Name).Obj()
+
+	workload, err := buildWorkloadTemplate([]*coreapi.OpenModel{model}, playground, backend)
+	if !assert.NoError(t, err) || !assert.NotNil(t, workload.LeaderTemplate) {
+		return
+	}
+	assert.Equal(t, []corev1.EnvVar{{}, {}, {}}, backend.Spec.Envs[1:4], "the backendRuntime should not be modified")
+	// LWS_WORKER_INDEX is injected by LWS.
+	for _, container := range []corev1.Container{workload.LeaderTemplate.Spec.Containers[0], workload.WorkerTemplate.Spec.Containers[0]} {
+		assert.Contains(t, container.Env, corev1.EnvVar{Name: "NCCL_DEBUG", Value: "INFO"})
+		for _, env := range container.Env {
+			assert.NotEqual(t, "LWS_WORKER_INDEX", env.Name)
+		}
+	}
+}
@@ -20,7 +20,9 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"slices"
 	"sync"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
@@ -32,6 +34,7 @@ import (
 	metaapplyv1 "k8s.io/client-go/applyconfigurations/meta/v1"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -51,6 +54,12 @@ import (
 	"github.com/inftyai/llmaz/pkg/util"
 )
 
+const (
+	// defaultFlavorFallbackTimeout is how long the pods could stay unschedulable before
+	// falling back to the next flavor.
+	defaultFlavorFallbackTimeout = 5 * time.Minute
+)
+
 // ServiceReconciler reconciles a Service object
 type ServiceReconciler struct {
 	client.Client
@@ -73,6 +82,10 @@ func NewServiceReconciler(client client.Client, scheme *runtime.Scheme, record r
 //+kubebuilder:rbac:groups=inference.llmaz.io,resources=services/finalizers,verbs=update
 //+kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list
+//+kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+//+kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch;create
+//+kubebuilder:rbac:groups="",resources=persistentvolumes,verbs=get;list;watch;create
 //+kubebuilder:rbac:groups=leaderworkerset.x-k8s.io,resources=leaderworkersets,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=leaderworkerset.x-k8s.io,resources=leaderworkersets/status,verbs=get;update;patch
 
@@ -117,6 +130,20 @@ func (r *ServiceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		return ctrl.Result{}, err
 	}
 
+	// Consider main model only.
+	requeueAfter, err := r.reconcileFlavor(ctx, service, models[0])
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	cacheRequeueAfter, err := r.reconcileModelCaches(ctx, service, models)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if cacheRequeueAfter > 0 && (requeueAfter == 0 || cacheRequeueAfter < requeueAfter) {
+		requeueAfter = cacheRequeueAfter
+	}
+
 	workloadApplyConfiguration, err := buildWorkloadApplyConfiguration(service, models, configs)
 	if err != nil {
 		return ctrl.Result{}, err
@@ -141,12 +168,17 @@ func (r *ServiceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	if err := r.Get(ctx, types.NamespacedName{Name: service.Name, Namespace: service.Namespace}, workload); err != nil {
 		return ctrl.Result{}, err
 	}
-	setServiceCondition(service, workload)
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(service.Namespace), client.MatchingLabels{lws.SetNameLabelKey: service.Name}); err != nil {
+		return ctrl.Result{}, err
+	}
+	setServiceCondition(service, workload, pods.Items)
+	setServiceStatus(service, pods.Items, models[0])
 	if err := r.Status().Update(ctx, service); err != nil {
 		return ctrl.Result{}, err
 	}
 
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -161,6 +193,21 @@ func (r *ServiceReconciler) SetupWithManager(mgr ctrl.Manager) error {
 					return !reflect.DeepEqual(oldBar.Status, newBar.Status)
 				},
 			})).
+		Watches(&corev1.Pod{}, handler.EnqueueRequestsFromMapFunc(podToService),
+			builder.WithPredicates(predicate.Funcs{
+				CreateFunc: func(e event.CreateEvent) bool { return false },
+				UpdateFunc: func(e event.UpdateEvent) bool {
+					oldPod := e.ObjectOld.(*corev1.Pod)
+					newPod := e.ObjectNew.(*corev1.Pod)
+					return podScheduledChanged(oldPod, newPod) || modelLoaderFailureChanged(oldPod, newPod) || runningImage(oldPod) != runningImage(newPod)
+				},
+				DeleteFunc:  func(e event.DeleteEvent) bool { return false },
+				GenericFunc: func(e event.GenericEvent) bool { return false },
+			})).
+		Watches(&coreapi.OpenModel{}, handler.EnqueueRequestsFromMapFunc(r.modelToServices),
+			builder.WithPredicates(modelStatusPredicate())).
+		Watches(&coreapi.Model{}, handler.EnqueueRequestsFromMapFunc(r.modelToServices),
+			builder.WithPredicates(modelStatusPredicate())).
 		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.updateGlobalConfig),
 			builder.WithPredicates(predicate.Funcs{
 				UpdateFunc: func(e event.UpdateEvent) bool {
@@ -175,6 +222,168 @@ func (r *ServiceReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Complete(r)
 }
 
+// modelToServices maps the model to the Services claiming it, the OpenModels are cluster
+// scoped, so the Services of all namespaces are listed.
+func (r *ServiceReconciler) modelToServices(ctx context.Context, obj client.Object) []reconcile.Request {
+	services := &inferenceapi.ServiceList{}
+	if err := r.List(ctx, services, client.InNamespace(obj.GetNamespace())); err != nil {
+		log.FromContext(ctx).Error(err, "failed to list services")
+		return nil
+	}
+
+	var reqs []reconcile.Request
+	for _, service := range services.Items {
+		if slices.ContainsFunc(service.Spec.ModelClaims.Models, func(ref coreapi.ModelRef) bool {
+			return string(ref.Name) == obj.GetName()
+		}) {
+			reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: service.Namespace, Name: service.Name}})
+		}
+	}
+	return reqs
+}
+
+// modelStatusPredicate only accepts the model updates which would change the rendered workloads.
+func modelStatusPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return modelStatusChanged(e.ObjectOld, e.ObjectNew)
+		},
+		DeleteFunc:  func(e event.DeleteEvent) bool { return false },
+		GenericFunc: func(e event.GenericEvent) bool { return false },
+	}
+}
+
+// modelStatusChanged returns true if the discovered metadata, the cache phase or the pinned
+// revision of the model changed, all of them are rendered into the workloads.
+func modelStatusChanged(oldObj, newObj client.Object) bool {
+	status := func(obj client.Object) *coreapi.ModelStatus {
+		switch model := obj.(type) {
+		case *coreapi.OpenModel:
+			return &model.Status
+		case *coreapi.Model:
+			return &model.Status
+		}
+		return nil
+	}
+	oldStatus, newStatus := status(oldObj), status(newObj)
+	if oldStatus == nil || newStatus == nil {
+		return false
+	}
+
+	cachePhase := func(status *coreapi.ModelStatus) coreapi.ModelCachePhase {
+		if status.Cache == nil {
+			return ""
+		}
+		return status.Cache.Phase
+	}
+	return !reflect.DeepEqual(oldStatus.Metadata, newStatus.Metadata) ||
+		cachePhase(oldStatus) != cachePhase(newStatus) ||
+		!reflect.DeepEqual(oldStatus.PinnedRevision, newStatus.PinnedRevision)
+}
+
+// podToService maps the pods created by the inference Service to the Service.
+func podToService(ctx context.Context, obj client.Object) []reconcile.Request {
+	labels := obj.GetLabels()
+	if _, ok := labels[coreapi.ModelNameLabelKey]; !ok {
+		return nil
+	}
+	name, ok := labels[lws.SetNameLabelKey]
+	if !ok {
+		return nil
+	}
+	return []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: obj.GetNamespace(), Name: name}},
+	}
+}
+
+func podScheduledChanged(oldPod, newPod *corev1.Pod) bool {
+	var oldCond, newCond *corev1.PodCondition
+	for i := range oldPod.Status.Conditions {
+		if oldPod.Status.Conditions[i].Type == corev1.PodScheduled {
+			oldCond = &oldPod.Status.Conditions[i]
+		}
+	}
+	for i := range newPod.Status.Conditions {
+		if newPod.Status.Conditions[i].Type == corev1.PodScheduled {
+			newCond = &newPod.Status.Conditions[i]
+		}
+	}
+	if oldCond == nil || newCond == nil {
+		return oldCond != newCond
+	}
+	return oldCond.Status != newCond.Status || oldCond.Reason != newCond.Reason
+}
+
+func modelLoaderFailureChanged(oldPod, newPod *corev1.Pod) bool {
+	oldReason, _ := modelSource.ModelLoaderFailure([]corev1.Pod{*oldPod})
+	newReason, _ := modelSource.ModelLoaderFailure([]corev1.Pod{*newPod})
+	return oldReason != newReason
+}
+
+// reconcileFlavor decides the flavor applied to the workloads, it falls back to the next
+// flavor in the preference list once the pods stay unschedulable longer than the timeout.
+// The returned duration indicates when to check the pods again.
+func (r *ServiceReconciler) reconcileFlavor(ctx context.Context, service *inferenceapi.Service, model *coreapi.OpenModel) (time.Duration, error) {
+	candidates := helper.FlavorCandidates(model, service.Spec.ModelClaims.InferenceFlavors)
+	if len(candidates) == 0 {
+		service.Status.Flavor = ""
+		service.Status.FlavorTransitionTime = nil
+		return 0, nil
+	}
+
+	index := slices.Index(candidates, service.Status.Flavor)
+	if index == -1 {
+		// Newly created or the flavors are changed, start from the most preferred one.
+		setServiceFlavor(service, candidates[0])
+		return 0, nil
+	}
+	// No more flavors to fall back to.
+	if index == len(candidates)-1 {
+		return 0, nil
+	}
+
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(service.Namespace), client.MatchingLabels{lws.SetNameLabelKey: service.Name}); err != nil {
+		return 0, err
+	}
+
+	var transitionTime time.Time
+	if service.Status.FlavorTransitionTime != nil {
+		transitionTime = service.Status.FlavorTransitionTime.Time
+	}
+	since := helper.UnschedulableSince(pods.Items, transitionTime)
+	if since == nil {
+		return 0, nil
+	}
+
+	timeout := flavorFallbackTimeout(service)
+	if elapsed := time.Since(*since); elapsed < timeout {
+		return timeout - elapsed, nil
+	}
+
+	next := candidates[index+1]
+	r.Record.Eventf(service, corev1.EventTypeWarning, "FlavorFallback",
+		"Pods are unschedulable with flavor %s for more than %s, falling back to flavor %s", service.Status.Flavor, timeout, next)
+	setServiceFlavor(service, next)
+	return 0, nil
+}
+
+func setServiceFlavor(service *inferenceapi.Service, flavor coreapi.FlavorName) {
+	service.Status.Flavor = flavor
+	service.Status.FlavorTransitionTime = ptr.To(metav1.Now())
+}
+
+// flavorFallbackTimeout returns the timeout configured via annotation, or the default one.
+func flavorFallbackTimeout(service *inferenceapi.Service) time.Duration {
+	if value, ok := service.Annotations[inferenceapi.FlavorFallbackTimeoutAnnoKey]; ok {
+		if timeout, err := time.ParseDuration(value); err == nil && timeout > 0 {
+			return timeout
+		}
+	}
+	return defaultFlavorFallbackTimeout
+}
+
 func (r *ServiceReconciler) updateGlobalConfig(ctx context.Context, obj client.Object) []reconcile.Request {
 	logger := log.FromContext(ctx)
 	cm, ok := obj.(*corev1.ConfigMap)
@@ -225,11 +434,16 @@ func buildWorkloadApplyConfiguration(service *inferenceapi.Service, models []*co
 	leaderWorkerTemplate.WithWorkerTemplate(&podTemplateSpecApplyConfiguration)
 
 	// The core logic to inject additional configurations.
-	injectModelProperties(leaderWorkerTemplate, models, service, configs)
+	if err := injectModelProperties(leaderWorkerTemplate, models, service, configs); err != nil {
+		return nil, err
+	}
 
 	spec := applyconfigurationv1.LeaderWorkerSetSpec()
 	spec.WithLeaderWorkerTemplate(leaderWorkerTemplate)
 	spec.LeaderWorkerTemplate.WithSize(*service.Spec.WorkloadTemplate.Size)
+	if service.Spec.WorkloadTemplate.RestartPolicy != "" {
+		spec.LeaderWorkerTemplate.WithRestartPolicy(service.Spec.WorkloadTemplate.RestartPolicy)
+	}
 	spec.WithReplicas(*service.Spec.Replicas)
 	if service.Spec.RolloutStrategy != nil {
 		spec.WithRolloutStrategy(applyconfigurationv1.RolloutStrategy().WithType(service.Spec.RolloutStrategy.Type))
@@ -247,17 +461,35 @@ func buildWorkloadApplyConfiguration(service *inferenceapi.Service, models []*co
 	return workload, nil
 }
 
-func injectModelProperties(template *applyconfigurationv1.LeaderWorkerTemplateApplyConfiguration, models []*coreapi.OpenModel, service *inferenceapi.Service, configs *helper.GlobalConfigs) {
+func injectModelProperties(template *applyconfigurationv1.LeaderWorkerTemplateApplyConfiguration, models []*coreapi.OpenModel, service *inferenceapi.Service, configs *helper.GlobalConfigs) error {
 	isMultiNodesInference := template.LeaderTemplate != nil
 
 	for i, model := range models {
-		source := modelSource.NewModelSourceProvider(model)
+		source, err := modelSource.NewModelSourceProvider(model)
+		if err != nil {
+			return err
+		}
 		// Skip model-loader initContainer if llmaz.io/skip-model-loader annotation is set.
 		if !helper.SkipModelLoader(service) {
-			if isMultiNodesInference {
-				source.InjectModelLoader(template.LeaderTemplate, i, configs.InitContainerImage)
+			// Models cached in the persistent volume are mounted directly without loading again,
+			// models not cached yet are loaded by the workloads as usual.
+			cachedInVolume := modelSource.ModelCachedInVolume(model)
+			if !cachedInVolume {
+				if isMultiNodesInference {
+					source.InjectModelLoader(template.LeaderTemplate, i, configs.InitContainerImage)
+				}
+				source.InjectModelLoader(template.WorkerTemplate, i, configs.InitContainerImage)
+			}
+			if cachedInVolume || modelSource.ModelCachedOnHost(model) {
+				if isMultiNodesInference {
+					if err := modelSource.InjectModelCache(template.LeaderTemplate, model, i); err != nil {
+						return err
+					}
+				}
+				if err := modelSource.InjectModelCache(template.WorkerTemplate, model, i); err != nil {
+					return err
+				}
 			}
-			source.InjectModelLoader(template.WorkerTemplate, i, configs.InitContainerImage)
 		} else {
 			if isMultiNodesInference {
 				source.InjectModelEnvVars(template.LeaderTemplate)
@@ -283,16 +515,30 @@ func injectModelProperties(template *applyconfigurationv1.LeaderWorkerTemplateAp
 		template.WorkerTemplate.Annotations = util.MergeKVs(template.WorkerTemplate.Annotations, modelAnnotations(service))
 	}
 
-	// Consider main model only.
-	injectModelFlavor(template.WorkerTemplate, models[0], service)
+	// Prefer the nodes the main model is preheated on.
+	if !helper.SkipModelLoader(service) && modelSource.ModelPreheatEnabled(models[0]) && modelSource.ModelCachedOnHost(models[0]) {
+		if isMultiNodesInference {
+			modelSource.InjectPreheatAffinity(template.LeaderTemplate, models[0])
+		}
+		modelSource.InjectPreheatAffinity(template.WorkerTemplate, models[0])
+	}
+
+	// Consider main model only. The model is served by the leader in multi-node inference,
+	// the workers only join the cluster of the leader.
+	if err := injectModelFlavor(template.WorkerTemplate, models[0], service, !isMultiNodesInference); err != nil {
+		return err
+	}
 	if isMultiNodesInference {
-		injectModelFlavor(template.LeaderTemplate, models[0], service)
+		return injectModelFlavor(template.LeaderTemplate, models[0], service, true)
 	}
+	return nil
 }
 
-func injectModelFlavor(template *coreapplyv1.PodTemplateSpecApplyConfiguration, model *coreapi.OpenModel, service *inferenceapi.Service) {
+// injectModelFlavor applies the resources and placement of the flavor to the template,
+// the flavor args are only applied to the serving template.
+func injectModelFlavor(template *coreapplyv1.PodTemplateSpecApplyConfiguration, model *coreapi.OpenModel, service *inferenceapi.Service, serving bool) error {
 	if model.Spec.InferenceConfig == nil || len(model.Spec.InferenceConfig.Flavors) == 0 {
-		return
+		return nil
 	}
 
 	container := &coreapplyv1.ContainerApplyConfiguration{}
@@ -302,10 +548,10 @@ func injectModelFlavor(template *coreapplyv1.PodTemplateSpecApplyConfiguration,
 		}
 	}
 
-	flavorName := model.Spec.InferenceConfig.Flavors[0].Name
-	if len(service.Spec.ModelClaims.InferenceFlavors) > 0 {
-		// We only support the same resource request right now, so 0-index flavor is enough.
-		flavorName = service.Spec.ModelClaims.InferenceFlavors[0]
+	// The flavor is resolved by reconcileFlavor, which may fall back to a less preferred one.
+	flavorName := service.Status.Flavor
+	if flavorName == "" {
+		flavorName = helper.FlavorCandidates(model, service.Spec.ModelClaims.InferenceFlavors)[0]
 	}
 
 	for i, flavor := range model.Spec.InferenceConfig.Flavors {
@@ -327,9 +573,20 @@ func injectModelFlavor(template *coreapplyv1.PodTemplateSpecApplyConfiguration,
 				// overwrite the requests and limits.
 				(*container.Resources.Limits)[k] = v
 			}
-			break
+			// Flavor specific args take precedence over the backend args. They can't be merged
+			// into the scripts of the shell commands, e.g. sh -c "ray start ... && vllm serve ...".
+			if serving && len(flavor.Args) > 0 && container.Name != nil && !shellCommand(container.Command) {
+				container.Args = util.MergeFlags(container.Args, flavor.Args)
+			}
+			return helper.ApplyFlavorPlacement(template, &model.Spec.InferenceConfig.Flavors[i])
 		}
 	}
+	return nil
+}
+
+// shellCommand returns true if the command runs a script in the shell, like sh -c.
+func shellCommand(command []string) bool {
+	return slices.Contains(command, "-c")
 }
 
 func modelLabels(model *coreapi.OpenModel) map[string]string {
@@ -357,13 +614,55 @@ func modelAnnotations(service *inferenceapi.Service) map[string]string {
 	return nil
 }
 
-func activatorAnnotations(model *coreapi.OpenModel) map[string]string {
-	return map[string]string{
+// activatorAnnotations returns the annotations consumed by the activator, it returns
+// nil if the service doesn't opt in scaling to zero.
+func activatorAnnotations(model *coreapi.OpenModel, service *inferenceapi.Service) map[string]string {
+	config := service.Spec.ScaleToZero
+	if !helper.ScaleToZeroEnabled(config) {
+		return nil
+	}
+
+	annotations := map[string]string{
 		coreapi.ModelActivatorAnnoKey: model.Name,
 	}
+	if config.IdleTimeout != nil {
+		annotations[coreapi.ModelActivatorIdleTimeoutAnnoKey] = config.IdleTimeout.Duration.String()
+	}
+	if config.ColdStartTimeout != nil {
+		annotations[coreapi.ModelActivatorColdStartTimeoutAnnoKey] = config.ColdStartTimeout.Duration.String()
+	}
+	return annotations
+}
+
+// syncActivatorAnnotations makes the activator annotations of the load balancing service
+// consistent with the desired ones, it returns true if the annotations are changed.
+func syncActivatorAnnotations(svc *corev1.Service, desired map[string]string) bool {
+	changed := false
+	for _, key := range []string{
+		coreapi.ModelActivatorAnnoKey,
+		coreapi.ModelActivatorIdleTimeoutAnnoKey,
+		coreapi.ModelActivatorColdStartTimeoutAnnoKey,
+	} {
+		value, ok := desired[key]
+		current, exist := svc.Annotations[key]
+		if ok == exist && value == current {
+			continue
+		}
+
+		changed = true
+		if !ok {
+			delete(svc.Annotations, key)
+			continue
+		}
+		if svc.Annotations == nil {
+			svc.Annotations = map[string]string{}
+		}
+		svc.Annotations[key] = value
+	}
+	return changed
 }
 
-func setServiceCondition(service *inferenceapi.Service, workload *lws.LeaderWorkerSet) {
+func setServiceCondition(service *inferenceapi.Service, workload *lws.LeaderWorkerSet, pods []corev1.Pod) {
 	defer func() {
 		if service.Status.Selector != workload.Status.HPAPodSelector {
 			service.Status.Selector = workload.Status.HPAPodSelector
@@ -381,6 +680,12 @@ func setServiceCondition(service *inferenceapi.Service, workload *lws.LeaderWork
 			Message: "Inference Service is ready",
 		}
 		apimeta.SetStatusCondition(&service.Status.Conditions, condition)
+
+		// Recovered from the model loader failures, e.g. the checksums are corrected.
+		if apimeta.IsStatusConditionFalse(service.Status.Conditions, inferenceapi.ServiceProgressing) {
+			condition.Type = inferenceapi.ServiceProgressing
+			apimeta.SetStatusCondition(&service.Status.Conditions, condition)
+		}
 	} else {
 		condition := metav1.Condition{
 			Type:    inferenceapi.ServiceProgressing,
@@ -388,6 +693,13 @@ func setServiceCondition(service *inferenceapi.Service, workload *lws.LeaderWork
 			Reason:  "ServiceInProgress",
 			Message: "Inference Service is progressing",
 		}
+		// The model loaders failed with the reasons reported by themselves, e.g. the checksums
+		// mismatched, which will not recover by restarting.
+		if reason, message := modelSource.ModelLoaderFailure(pods); reason != "" {
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = reason
+			condition.Message = "Model loader failed: " + message
+		}
 		apimeta.SetStatusCondition(&service.Status.Conditions, condition)
 
 		if apimeta.FindStatusCondition(service.Status.Conditions, inferenceapi.ServiceAvailable) == nil {
@@ -405,6 +717,47 @@ func setServiceCondition(service *inferenceapi.Service, workload *lws.LeaderWork
 	}
 }
 
+// setServiceStatus reports what is really running behind the Service, the backendRuntime
+// is resolved by the Playground and passed down with the annotations.
+func setServiceStatus(service *inferenceapi.Service, pods []corev1.Pod, model *coreapi.OpenModel) {
+	service.Status.ObservedGeneration = service.Generation
+	// The workloads are not built with an unsupported source, which is reported by the reconciliation.
+	service.Status.ModelPath = ""
+	if source, err := modelSource.NewModelSourceProvider(model); err == nil {
+		service.Status.ModelPath = source.ModelPath(helper.SkipModelLoader(service))
+	}
+	service.Status.BackendRuntimeName = inferenceapi.BackendName(service.Annotations[inferenceapi.BackendRuntimeNameAnnoKey])
+	service.Status.BackendRuntimeVersion = service.Annotations[inferenceapi.BackendRuntimeVersionAnnoKey]
+	service.Status.Image = servingImage(pods)
+}
+
+// servingImage returns the image of the inference engine running in the serving pods, which are
+// the leader pods in multi-nodes inference. The newest pod wins during the rolling update.
+func servingImage(pods []corev1.Pod) string {
+	var image string
+	var createdAt metav1.Time
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil || pod.Labels[lws.WorkerIndexLabelKey] != "0" {
+			continue
+		}
+		if running := runningImage(&pod); running != "" && (image == "" || createdAt.Before(&pod.CreationTimestamp)) {
+			image = running
+			createdAt = pod.CreationTimestamp
+		}
+	}
+	return image
+}
+
+// runningImage returns the image of the running model-runner container in the pod.
+func runningImage(pod *corev1.Pod) string {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == modelSource.MODEL_RUNNER_CONTAINER_NAME && status.State.Running != nil {
+			return status.Image
+		}
+	}
+	return ""
+}
+
 // setControllerReferenceForWorkload set service as the owner reference for the workload.
 func setControllerReferenceForWorkload(owner metav1.Object, lws *applyconfigurationv1.LeaderWorkerSetApplyConfiguration, scheme *runtime.Scheme) error {
 	ro, ok := owner.(runtime.Object)
@@ -439,8 +792,8 @@ func CreateServiceIfNotExists(ctx context.Context, k8sClient client.Client, Sche
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      svcName,
 				Namespace: service.Namespace,
-				// For activator service, we can ignore it if serverless config is not enabled.
-				Annotations: activatorAnnotations(model[0]),
+				// Only services opted in scaling to zero will be taken over by the activator.
+				Annotations: activatorAnnotations(model[0], service),
 			},
 			Spec: corev1.ServiceSpec{
 				Ports: []corev1.ServicePort{
@@ -468,6 +821,15 @@ func CreateServiceIfNotExists(ctx context.Context, k8sClient client.Client, Sche
 		if err := k8sClient.Create(ctx, &svc); err != nil {
 			return err
 		}
+		return nil
+	}
+
+	// The scaleToZero policy may be changed after the service created.
+	if syncActivatorAnnotations(&svc, activatorAnnotations(model[0], service)) {
+		log.V(2).Info("Updating activator annotations of service.")
+		if err := k8sClient.Update(ctx, &svc); err != nil {
+			return err
+		}
 	}
 	return nil
 }
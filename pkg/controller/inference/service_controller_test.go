@@ -0,0 +1,284 @@
+/*
+Copyright 2025 The InftyAI Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inference
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	coreapplyv1 "k8s.io/client-go/applyconfigurations/core/v1"
+	"k8s.io/utils/ptr"
+	lws "sigs.k8s.io/lws/api/leaderworkerset/v1"
+
+	coreapi "github.com/inftyai/llmaz/api/core/v1alpha1"
+	inferenceapi "github.com/inftyai/llmaz/api/inference/v1alpha1"
+	modelSource "github.com/inftyai/llmaz/pkg/controller_helper/modelsource"
+	"github.com/inftyai/llmaz/test/util/wrapper"
+)
+
+func TestInjectModelFlavorArgs(t *testing.T) {
+	model := wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithURI("s3://bucket/llama3-8b").
+		InferenceFlavors(*wrapper.MakeFlavor("a100").SetRequest("nvidia.com/gpu", "1").Args("--max-model-len", "4096").Obj()).
+		Obj()
+	service := wrapper.MakeService("llama3-8b", "default").ModelClaims([]string{model.Name}, []string{"main"}).Obj()
+
+	testCases := []struct {
+		name     string
+		command  []string
+		args     []string
+		serving  bool
+		wantArgs []string
+	}{
+		{
+			name:     "serving template",
+			args:     []string{"--model", "/workspace/models", "--port", "8080"},
+			serving:  true,
+			wantArgs: []string{"--model", "/workspace/models", "--port", "8080", "--max-model-len", "4096"},
+		},
+		{
+			name:     "backend args overridden by the flavor",
+			args:     []string{"--model", "/workspace/models", "--max-model-len", "8192", "--port", "8080"},
+			serving:  true,
+			wantArgs: []string{"--model", "/workspace/models", "--port", "8080", "--max-model-len", "4096"},
+		},
+		{
+			name:     "worker template of multi-node inference",
+			args:     []string{"ray", "start"},
+			serving:  false,
+			wantArgs: []string{"ray", "start"},
+		},
+		{
+			name:     "shell command",
+			command:  []string{"sh", "-c"},
+			args:     []string{"ray start --head && vllm serve /workspace/models"},
+			serving:  true,
+			wantArgs: []string{"ray start --head && vllm serve /workspace/models"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			template := coreapplyv1.PodTemplateSpec().WithSpec(coreapplyv1.PodSpec().WithContainers(
+				coreapplyv1.Container().WithName(modelSource.MODEL_RUNNER_CONTAINER_NAME).WithCommand(tc.command...).WithArgs(tc.args...),
+			))
+			assert.NoError(t, injectModelFlavor(template, model, service, tc.serving))
+
+			container := template.Spec.Containers[0]
+			assert.Equal(t, tc.wantArgs, container.Args)
+			// Resources are required by both the leader and the workers.
+			gpu := (*container.Resources.Limits)["nvidia.com/gpu"]
+			assert.Equal(t, int64(1), gpu.Value())
+		})
+	}
+}
+
+func TestServingImage(t *testing.T) {
+	now := time.Now()
+	makePod := func(name, workerIndex, image string, running bool, createdAt time.Time) corev1.Pod {
+		state := corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ContainerCreating"}}
+		if running {
+			state = corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}
+		}
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Labels:            map[string]string{lws.WorkerIndexLabelKey: workerIndex},
+				CreationTimestamp: metav1.NewTime(createdAt),
+			},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{{Name: modelSource.MODEL_RUNNER_CONTAINER_NAME, Image: image, State: state}},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name string
+		pods []corev1.Pod
+		want string
+	}{
+		{
+			name: "no pods",
+		},
+		{
+			name: "container not running yet",
+			pods: []corev1.Pod{makePod("llama3-0", "0", "vllm:v0.7.3", false, now)},
+		},
+		{
+			name: "running leader pod",
+			pods: []corev1.Pod{makePod("llama3-0", "0", "vllm:v0.7.3", true, now)},
+			want: "vllm:v0.7.3",
+		},
+		{
+			name: "worker pods are ignored",
+			pods: []corev1.Pod{
+				makePod("llama3-0", "0", "vllm:v0.7.3", true, now),
+				makePod("llama3-0-1", "1", "ray:2.40", true, now.Add(time.Minute)),
+			},
+			want: "vllm:v0.7.3",
+		},
+		{
+			name: "newest pod wins during rolling update",
+			pods: []corev1.Pod{
+				makePod("llama3-1", "0", "vllm:v0.8.0", true, now.Add(time.Minute)),
+				makePod("llama3-0", "0", "vllm:v0.7.3", true, now),
+			},
+			want: "vllm:v0.8.0",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, servingImage(tc.pods))
+		})
+	}
+}
+
+func TestSetServiceStatus(t *testing.T) {
+	model := wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithURI("s3://bucket/llama3-8b").Obj()
+	service := wrapper.MakeService("llama3", "default").
+		Annotation(inferenceapi.BackendRuntimeNameAnnoKey, "vllm").
+		Annotation(inferenceapi.BackendRuntimeVersionAnnoKey, "v0.8.0").
+		Obj()
+	service.Generation = 2
+
+	setServiceStatus(service, nil, model)
+	assert.Equal(t, int64(2), service.Status.ObservedGeneration)
+	assert.Equal(t, inferenceapi.BackendName("vllm"), service.Status.BackendRuntimeName)
+	assert.Equal(t, "v0.8.0", service.Status.BackendRuntimeVersion)
+	assert.NotEmpty(t, service.Status.ModelPath)
+	assert.Empty(t, service.Status.Image)
+}
+
+func TestSyncActivatorAnnotations(t *testing.T) {
+	testCases := []struct {
+		name        string
+		annotations map[string]string
+		desired     map[string]string
+		want        map[string]string
+		wantChanged bool
+	}{
+		{
+			name:        "set on a service without annotations",
+			desired:     map[string]string{coreapi.ModelActivatorAnnoKey: "llama3-8b", coreapi.ModelActivatorIdleTimeoutAnnoKey: "10m"},
+			want:        map[string]string{coreapi.ModelActivatorAnnoKey: "llama3-8b", coreapi.ModelActivatorIdleTimeoutAnnoKey: "10m"},
+			wantChanged: true,
+		},
+		{
+			name:        "unchanged",
+			annotations: map[string]string{coreapi.ModelActivatorAnnoKey: "llama3-8b", "foo": "bar"},
+			desired:     map[string]string{coreapi.ModelActivatorAnnoKey: "llama3-8b"},
+			want:        map[string]string{coreapi.ModelActivatorAnnoKey: "llama3-8b", "foo": "bar"},
+		},
+		{
+			name:        "timeout updated",
+			annotations: map[string]string{coreapi.ModelActivatorAnnoKey: "llama3-8b", coreapi.ModelActivatorColdStartTimeoutAnnoKey: "5m"},
+			desired:     map[string]string{coreapi.ModelActivatorAnnoKey: "llama3-8b", coreapi.ModelActivatorColdStartTimeoutAnnoKey: "10m"},
+			want:        map[string]string{coreapi.ModelActivatorAnnoKey: "llama3-8b", coreapi.ModelActivatorColdStartTimeoutAnnoKey: "10m"},
+			wantChanged: true,
+		},
+		{
+			name: "removed once scale to zero is disabled",
+			annotations: map[string]string{
+				coreapi.ModelActivatorAnnoKey:            "llama3-8b",
+				coreapi.ModelActivatorIdleTimeoutAnnoKey: "10m",
+				coreapi.CachedModelActivatorAnnoKey:      "{}",
+				"foo":                                    "bar",
+			},
+			want:        map[string]string{coreapi.CachedModelActivatorAnnoKey: "{}", "foo": "bar"},
+			wantChanged: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}}
+			assert.Equal(t, tc.wantChanged, syncActivatorAnnotations(svc, tc.desired))
+			assert.Equal(t, tc.want, svc.Annotations)
+		})
+	}
+}
+
+func TestModelStatusChanged(t *testing.T) {
+	model := wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithURI("s3://bucket/llama3-8b").Obj()
+
+	testCases := []struct {
+		name   string
+		update func(*coreapi.OpenModel)
+		want   bool
+	}{
+		{
+			name: "conditions changed",
+			update: func(model *coreapi.OpenModel) {
+				model.Status.Conditions = []metav1.Condition{{Type: coreapi.ModelReady, Status: metav1.ConditionTrue}}
+			},
+		},
+		{
+			name: "metadata discovered",
+			update: func(model *coreapi.OpenModel) {
+				model.Status.Metadata = &coreapi.ModelMetadata{WeightSize: ptr.To(resource.MustParse("16Gi"))}
+			},
+			want: true,
+		},
+		{
+			name: "cache ready",
+			update: func(model *coreapi.OpenModel) {
+				model.Status.Cache = &coreapi.ModelCacheStatus{Phase: coreapi.ModelCacheReady}
+			},
+			want: true,
+		},
+		{
+			name: "revision pinned",
+			update: func(model *coreapi.OpenModel) {
+				model.Status.PinnedRevision = &coreapi.PinnedRevision{Revision: "main", Commit: "abc"}
+			},
+			want: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			newModel := model.DeepCopy()
+			tc.update(newModel)
+			assert.Equal(t, tc.want, modelStatusChanged(model, newModel))
+		})
+	}
+}
+
+func TestSetServiceConditionModelLoaderFailure(t *testing.T) {
+	service := wrapper.MakeService("llama3-8b", "default").Obj()
+	workload := &lws.LeaderWorkerSet{}
+	mismatched := []corev1.Pod{{Status: corev1.PodStatus{InitContainerStatuses: []corev1.ContainerStatus{{
+		Name:  modelSource.MODEL_LOADER_CONTAINER_NAME,
+		State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 1, Message: "ChecksumMismatch: model.safetensors mismatched"}},
+	}}}}}
+
+	setServiceCondition(service, workload, mismatched)
+	progressing := apimeta.FindStatusCondition(service.Status.Conditions, inferenceapi.ServiceProgressing)
+	assert.Equal(t, metav1.ConditionFalse, progressing.Status)
+	assert.Equal(t, modelSource.ReasonChecksumMismatch, progressing.Reason)
+
+	// Recovered once the checksums are corrected and the workload is available.
+	workload.Status.Conditions = []metav1.Condition{{Type: string(lws.LeaderWorkerSetAvailable), Status: metav1.ConditionTrue}}
+	setServiceCondition(service, workload, nil)
+	assert.True(t, apimeta.IsStatusConditionTrue(service.Status.Conditions, inferenceapi.ServiceAvailable))
+	assert.True(t, apimeta.IsStatusConditionTrue(service.Status.Conditions, inferenceapi.ServiceProgressing))
+}
@@ -18,11 +18,12 @@ package helper
 
 import (
 	"fmt"
-	"regexp"
-	"strings"
+	"slices"
+	"strconv"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
 
 	coreapi "github.com/inftyai/llmaz/api/core/v1alpha1"
 	inferenceapi "github.com/inftyai/llmaz/api/inference/v1alpha1"
@@ -30,6 +31,11 @@ import (
 	modelSource "github.com/inftyai/llmaz/pkg/controller_helper/modelsource"
 )
 
+const (
+	minSharedMemorySize int64 = 1 << 30
+	maxSharedMemorySize int64 = 16 << 30
+)
+
 // TODO: add unit tests.
 type BackendRuntimeParser struct {
 	backendRuntime      *inferenceapi.BackendRuntime
@@ -56,33 +62,219 @@ func (p *BackendRuntimeParser) Envs() []corev1.EnvVar {
 	return p.backendRuntime.Spec.Envs
 }
 
+// Lifecycle returns the lifecycle of the backendRuntime, with a preStop hook draining
+// the in-flight requests synthesized if not specified.
 func (p *BackendRuntimeParser) Lifecycle() *corev1.Lifecycle {
-	return p.backendRuntime.Spec.Lifecycle
+	lifecycle := p.backendRuntime.Spec.Lifecycle
+	if lifecycle != nil && lifecycle.PreStop != nil {
+		return lifecycle
+	}
+	preStop := p.preStopDrain()
+	if preStop == nil {
+		return lifecycle
+	}
+
+	res := &corev1.Lifecycle{}
+	if lifecycle != nil {
+		res = lifecycle.DeepCopy()
+	}
+	res.PreStop = preStop
+	return res
 }
 
 func (p *BackendRuntimeParser) Args() ([]string, error) {
+	if config := p.recommendedConfig(); config != nil {
+		return p.renderArgs(config.Args)
+	}
+
+	// We should not reach here.
+	return nil, fmt.Errorf("failed to parse backendRuntime %s", p.backendRuntime.Name)
+}
+
+// renderArgs renders the args with the RenderContext.
+func (p *BackendRuntimeParser) renderArgs(args []string) ([]string, error) {
+	ctx, err := p.RenderContext()
+	if err != nil {
+		return nil, err
+	}
+	return renderFlags(args, ctx)
+}
+
+// RenderContext returns the data to render the args of the recommended configs.
+func (p *BackendRuntimeParser) RenderContext() (*RenderContext, error) {
+	skipModelLoader := helper.SkipModelLoader(p.playground)
 	mainModel := p.models[0]
 
-	source := modelSource.NewModelSourceProvider(mainModel)
-	modelInfo := map[string]string{
-		"ModelPath": source.ModelPath(helper.SkipModelLoader(p.playground)),
-		"ModelName": source.ModelName(),
+	source, err := modelSource.NewModelSourceProvider(mainModel)
+	if err != nil {
+		return nil, err
+	}
+	ctx := &RenderContext{
+		ModelPath: source.ModelPath(skipModelLoader),
+		ModelName: source.ModelName(),
+		Models: map[string][]ModelInfo{
+			string(coreapi.MainRole): {{Name: source.ModelName(), Path: source.ModelPath(skipModelLoader)}},
+		},
+		Size:          p.Size(),
+		LeaderAddress: lwsLeaderAddressEnv,
+		WorkerIndex:   lwsWorkerIndexEnv,
+		Namespace:     p.playground.Namespace,
+	}
+	if p.playground.Spec.Replicas != nil {
+		ctx.Replicas = *p.playground.Spec.Replicas
+	}
+
+	roles := helper.ModelRoles(helper.ModelRefsByPlayground(p.playground))
+	for _, model := range p.models[1:] {
+		source, err := modelSource.NewModelSourceProvider(model)
+		if err != nil {
+			return nil, err
+		}
+		role := roles[coreapi.ModelName(model.Name)]
+		info := ModelInfo{Name: source.ModelName(), Path: source.ModelPath(skipModelLoader)}
+		ctx.Models[string(role)] = append(ctx.Models[string(role)], info)
+
+		switch role {
+		case coreapi.DraftRole:
+			ctx.DraftModelPath = info.Path
+		case coreapi.LoraRole:
+			// Rendered as name=path pairs, e.g. the format of vLLM --lora-modules.
+			ctx.LoraModules = append(ctx.LoraModules, info.Name+"="+info.Path)
+		}
 	}
 
-	// TODO: This is not that reliable because two models doesn't always means speculative-decoding.
-	// Revisit this later.
-	if len(p.models) > 1 {
-		modelInfo["DraftModelPath"] = modelSource.NewModelSourceProvider(p.models[1]).ModelPath(helper.SkipModelLoader(p.playground))
+	ctx.Flavor = p.flavor()
+	ctx.AcceleratorCount = helper.AcceleratorCount(ctx.Flavor)
+
+	return ctx, nil
+}
+
+// flavor returns the flavor of the main model applied to the workloads.
+func (p *BackendRuntimeParser) flavor() *coreapi.Flavor {
+	var claimed []coreapi.FlavorName
+	if p.playground.Spec.ModelClaim != nil {
+		claimed = p.playground.Spec.ModelClaim.InferenceFlavors
+	} else if p.playground.Spec.ModelClaims != nil {
+		claimed = p.playground.Spec.ModelClaims.InferenceFlavors
 	}
+	// The flavor may fall back to a less preferred one, which is reported in the status.
+	return helper.ResolveFlavor(p.models[0], claimed, p.playground.Status.Flavor)
+}
 
-	for _, recommend := range p.backendRuntime.Spec.RecommendedConfigs {
-		if recommend.Name == p.recommendConfigName {
-			return renderFlags(recommend.Args, modelInfo)
+// recommendedConfig returns the most specific recommended config matching the applied
+// flavor and the family of the main model, nil if not found.
+func (p *BackendRuntimeParser) recommendedConfig() *inferenceapi.RecommendedConfig {
+	var flavorName coreapi.FlavorName
+	if flavor := p.flavor(); flavor != nil {
+		flavorName = flavor.Name
+	}
+	return MatchRecommendedConfig(p.backendRuntime.Spec.RecommendedConfigs, p.recommendConfigName, flavorName, p.models[0].Spec.FamilyName)
+}
+
+// MatchRecommendedConfig returns the config with the given name which matches the flavor and
+// the model family best, a config matching the flavor is more specific than the one matching
+// the model family, configs without flavors and modelFamilies are the fallback. The best one
+// is unique since the overlapped configs are rejected, see RecommendedConfigsOverlap.
+func MatchRecommendedConfig(configs []inferenceapi.RecommendedConfig, name string, flavor coreapi.FlavorName, family coreapi.ModelName) *inferenceapi.RecommendedConfig {
+	var res *inferenceapi.RecommendedConfig
+	highest := -1
+
+	for i, config := range configs {
+		if config.Name != name {
+			continue
+		}
+
+		score := 0
+		if len(config.Flavors) > 0 {
+			if !slices.Contains(config.Flavors, flavor) {
+				continue
+			}
+			score += 2
+		}
+		if len(config.ModelFamilies) > 0 {
+			if !slices.Contains(config.ModelFamilies, family) {
+				continue
+			}
+			score += 1
+		}
+
+		if score > highest {
+			res = &configs[i]
+			highest = score
 		}
 	}
+	return res
+}
 
-	// We should not reach here.
-	return nil, fmt.Errorf("failed to parse backendRuntime %s", p.backendRuntime.Name)
+// RecommendedConfigsOverlap returns true if both configs could match the same flavor and model
+// family with the same score, which makes MatchRecommendedConfig ambiguous, e.g. configs with
+// the same name and flavors [a100] and [a100, l4]. These configs are rejected by the webhook.
+func RecommendedConfigsOverlap(a, b inferenceapi.RecommendedConfig) bool {
+	if a.Name != b.Name {
+		return false
+	}
+	return listsOverlap(a.Flavors, b.Flavors) && listsOverlap(a.ModelFamilies, b.ModelFamilies)
+}
+
+// listsOverlap returns true if both lists are empty, or they share at least one item.
+func listsOverlap[T comparable](a, b []T) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return len(a) == len(b)
+	}
+	for _, item := range a {
+		if slices.Contains(b, item) {
+			return true
+		}
+	}
+	return false
+}
+
+// Size returns the number of pods in a workload, greater than 1 means multi-node inference.
+func (p *BackendRuntimeParser) Size() int32 {
+	config := p.recommendedConfig()
+	if config == nil || config.MultiNode == nil {
+		return 1
+	}
+
+	size := ptr.Deref(config.MultiNode.Size, 1)
+	if flavor := p.flavor(); flavor != nil {
+		if pp, err := strconv.ParseInt(flavor.Params[helper.FlavorParamPP], 10, 32); err == nil && pp > 0 {
+			size = int32(pp)
+		}
+	}
+	return size
+}
+
+// LeaderCommand returns the command of the leader pod in multi-node inference.
+func (p *BackendRuntimeParser) LeaderCommand() []string {
+	if config := p.recommendedConfig(); config != nil && config.MultiNode != nil && config.MultiNode.Leader != nil && config.MultiNode.Leader.Command != nil {
+		return config.MultiNode.Leader.Command
+	}
+	return p.Command()
+}
+
+// LeaderArgs returns the args of the leader pod in multi-node inference.
+func (p *BackendRuntimeParser) LeaderArgs() ([]string, error) {
+	if config := p.recommendedConfig(); config != nil && config.MultiNode != nil && config.MultiNode.Leader != nil && config.MultiNode.Leader.Args != nil {
+		return p.renderArgs(config.MultiNode.Leader.Args)
+	}
+	return p.Args()
+}
+
+// WorkerCommand returns the command of the worker pods in multi-node inference.
+func (p *BackendRuntimeParser) WorkerCommand() []string {
+	if config := p.recommendedConfig(); config != nil && config.MultiNode != nil && config.MultiNode.Worker != nil && config.MultiNode.Worker.Command != nil {
+		return config.MultiNode.Worker.Command
+	}
+	return p.Command()
+}
+
+// WorkerArgs returns the args of the worker pods in multi-node inference.
+func (p *BackendRuntimeParser) WorkerArgs() ([]string, error) {
+	if config := p.recommendedConfig(); config != nil && config.MultiNode != nil && config.MultiNode.Worker != nil && config.MultiNode.Worker.Args != nil {
+		return p.renderArgs(config.MultiNode.Worker.Args)
+	}
+	return p.Args()
 }
 
 func (p *BackendRuntimeParser) Image(version string) string {
@@ -93,48 +285,37 @@ func (p *BackendRuntimeParser) Version() string {
 	return p.backendRuntime.Spec.Version
 }
 
-func (p *BackendRuntimeParser) Resources() *inferenceapi.ResourceRequirements {
-	for _, recommend := range p.backendRuntime.Spec.RecommendedConfigs {
-		if recommend.Name == p.recommendConfigName {
-			return recommend.Resources
-		}
+// ScaleTrigger returns the scaleTrigger of the recommended config, the same one providing the
+// args and resources, nil if not configured.
+func (p *BackendRuntimeParser) ScaleTrigger() *inferenceapi.ScaleTrigger {
+	if config := p.recommendedConfig(); config != nil {
+		return config.ScaleTrigger
 	}
-	// We should not reach here.
 	return nil
 }
 
-func (p *BackendRuntimeParser) SharedMemorySize() *resource.Quantity {
-	for _, recommend := range p.backendRuntime.Spec.RecommendedConfigs {
-		if recommend.Name == p.recommendConfigName {
-			return recommend.SharedMemorySize
-		}
+func (p *BackendRuntimeParser) Resources() *inferenceapi.ResourceRequirements {
+	if config := p.recommendedConfig(); config != nil {
+		return config.Resources
 	}
+	// We should not reach here.
 	return nil
 }
 
-func renderFlags(flags []string, modelInfo map[string]string) ([]string, error) {
-	// Capture the word.
-	re := regexp.MustCompile(`\{\{\s*\.(\w+)\s*\}\}`)
-
-	res := []string{}
-
-	for _, flag := range flags {
-		value := flag
-		matches := re.FindAllStringSubmatch(flag, -1)
-		for _, match := range matches {
-			if len(match) <= 1 {
-				continue
-			}
-			key := match[1]
-			replacement, exists := modelInfo[key]
-			if !exists || replacement == "" {
-				return nil, fmt.Errorf("missing flag or the flag has format error: %s", flag)
-			}
-			value = strings.ReplaceAll(value, match[0], replacement)
-		}
-
-		res = append(res, value)
+// SharedMemorySize returns the /dev/shm size of the recommended config. If not configured,
+// multi-accelerator inference exchanging tensors via shared memory defaults to a quarter
+// of the model weight size, bounded to [1Gi, 16Gi], nil if the weight size is unknown.
+func (p *BackendRuntimeParser) SharedMemorySize() *resource.Quantity {
+	if config := p.recommendedConfig(); config != nil && config.SharedMemorySize != nil {
+		return config.SharedMemorySize
 	}
-
-	return res, nil
+	if helper.AcceleratorCount(p.flavor()) <= 1 {
+		return nil
+	}
+	size := p.modelSize()
+	if size == nil {
+		return nil
+	}
+	shm := min(max(size.Value()/4, minSharedMemorySize), maxSharedMemorySize)
+	return resource.NewQuantity(shm, resource.BinarySI)
 }
@@ -17,6 +17,7 @@ limitations under the License.
 package helper
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -25,6 +26,8 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
 
 	inferenceapi "github.com/inftyai/llmaz/api/inference/v1alpha1"
 )
@@ -33,14 +36,14 @@ func TestRenderFlags(t *testing.T) {
 	testCases := []struct {
 		name      string
 		flags     []string
-		modelInfo map[string]string
+		data      any
 		wantFlags []string
 		wantError bool
 	}{
 		{
 			name:  "normal parse long args",
 			flags: []string{"run {{ .ModelPath }};sleep 5", "--host", "0.0.0.0"},
-			modelInfo: map[string]string{
+			data: map[string]any{
 				"ModelPath": "path/to/model",
 				"ModelName": "foo",
 			},
@@ -49,7 +52,7 @@ func TestRenderFlags(t *testing.T) {
 		{
 			name:  "normal parse",
 			flags: []string{"-m", "{{ .ModelPath }}", "--served-model-name", "{{ .ModelName }}", "--host", "0.0.0.0"},
-			modelInfo: map[string]string{
+			data: map[string]any{
 				"ModelPath": "path/to/model",
 				"ModelName": "foo",
 			},
@@ -58,7 +61,7 @@ func TestRenderFlags(t *testing.T) {
 		{
 			name:  "miss some info",
 			flags: []string{"-m", "{{ .ModelPath }}", "--served-model-name", "{{ .ModelName }}", "--host", "0.0.0.0"},
-			modelInfo: map[string]string{
+			data: map[string]any{
 				"ModelPath": "path/to/model",
 			},
 			wantError: true,
@@ -66,29 +69,88 @@ func TestRenderFlags(t *testing.T) {
 		{
 			name:  "missing . with flag",
 			flags: []string{"-m", "{{ ModelPath }}", "--served-model-name", "{{ .ModelName }}", "--host", "0.0.0.0"},
-			modelInfo: map[string]string{
+			data: map[string]any{
 				"ModelPath": "path/to/model",
 				"ModelName": "foo",
 			},
-			wantFlags: []string{"-m", "{{ ModelPath }}", "--served-model-name", "foo", "--host", "0.0.0.0"},
+			wantError: true,
 		},
 		{
 			name:  "no empty space between {{}}",
 			flags: []string{"-m", "{{.ModelPath}}", "--served-model-name", "{{.ModelName}}", "--host", "0.0.0.0"},
-			modelInfo: map[string]string{
+			data: map[string]any{
 				"ModelPath": "path/to/model",
 				"ModelName": "foo",
 			},
 			wantFlags: []string{"-m", "path/to/model", "--served-model-name", "foo", "--host", "0.0.0.0"},
 		},
+		{
+			name:  "expand list info",
+			flags: []string{"--model", "{{ .ModelPath }}", "--lora-modules", "{{ .LoraModules }}", "--host", "0.0.0.0"},
+			data: &RenderContext{
+				ModelPath:   "path/to/model",
+				LoraModules: ArgList{"sql=path/to/sql", "chat=path/to/chat"},
+			},
+			wantFlags: []string{"--model", "path/to/model", "--lora-modules", "sql=path/to/sql", "chat=path/to/chat", "--host", "0.0.0.0"},
+		},
+		{
+			name:  "list info missing",
+			flags: []string{"--model", "{{ .ModelPath }}", "--lora-modules", "{{ .LoraModules }}"},
+			data: map[string]any{
+				"ModelPath": "path/to/model",
+			},
+			wantError: true,
+		},
+		{
+			name:  "list info rendered inline",
+			flags: []string{"--lora-modules={{ .LoraModules }}"},
+			data: &RenderContext{
+				LoraModules: ArgList{"sql=path/to/sql", "chat=path/to/chat"},
+			},
+			wantFlags: []string{"--lora-modules=sql=path/to/sql chat=path/to/chat"},
+		},
+		{
+			name:  "helper functions",
+			flags: []string{"--tensor-parallel-size", "{{ .AcceleratorCount | default 1 }}", "--pipeline-parallel-size", "{{ div .AcceleratorCount 2 }}", "--nnodes", "{{ add .Size 1 }}"},
+			data: &RenderContext{
+				AcceleratorCount: 8,
+				Size:             1,
+			},
+			wantFlags: []string{"--tensor-parallel-size", "8", "--pipeline-parallel-size", "4", "--nnodes", "2"},
+		},
+		{
+			name:      "default value",
+			flags:     []string{"--tensor-parallel-size", "{{ .AcceleratorCount | default 1 }}"},
+			data:      &RenderContext{},
+			wantFlags: []string{"--tensor-parallel-size", "1"},
+		},
+		{
+			name:  "flavor params and models by role",
+			flags: []string{"-tp", "{{ index .Flavor.Params \"TP\" }}", "--speculative_model", "{{ (index .Models \"draft\" 0).Path }}", "--dist-init-addr", "{{ .LeaderAddress }}:20000"},
+			data: &RenderContext{
+				Flavor:        &coreapi.Flavor{Name: "a100", Params: map[string]string{"TP": "4"}},
+				Models:        map[string][]ModelInfo{"draft": {{Name: "draft", Path: "path/to/draft"}}},
+				LeaderAddress: "$(LWS_LEADER_ADDRESS)",
+			},
+			wantFlags: []string{"-tp", "4", "--speculative_model", "path/to/draft", "--dist-init-addr", "$(LWS_LEADER_ADDRESS):20000"},
+		},
+		{
+			name:      "nil flavor",
+			flags:     []string{"-tp", "{{ index .Flavor.Params \"TP\" }}"},
+			data:      &RenderContext{},
+			wantError: true,
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			gotFlags, err := renderFlags(tc.flags, tc.modelInfo)
+			gotFlags, err := renderFlags(tc.flags, tc.data)
 			if tc.wantError && err == nil {
 				t.Fatal("test should fail")
 			}
+			if !tc.wantError && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
 
 			if !tc.wantError && cmp.Diff(tc.wantFlags, gotFlags) != "" {
 				t.Fatalf("want flags %v, got flags %v", tc.wantFlags, gotFlags)
@@ -246,3 +308,562 @@ func TestBackendRuntimeParser_BasicFields(t *testing.T) {
 		})
 	}
 }
+
+func TestBackendRuntimeParser_ArgsWithRoles(t *testing.T) {
+	backend := &inferenceapi.BackendRuntime{
+		ObjectMeta: metav1.ObjectMeta{Name: "vllm"},
+		Spec: inferenceapi.BackendRuntimeSpec{
+			RecommendedConfigs: []inferenceapi.RecommendedConfig{
+				{
+					Name: "speculative-decoding",
+					Args: []string{"--model", "{{ .ModelPath }}", "--speculative_model", "{{ .DraftModelPath }}"},
+				},
+				{
+					Name: "lora",
+					Args: []string{"--model", "{{ .ModelPath }}", "--enable-lora", "--lora-modules", "{{ .LoraModules }}"},
+				},
+			},
+		},
+	}
+
+	llama := wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithModelHub("Huggingface").ModelSourceWithModelID("meta-llama/Meta-Llama-3-8B", "", "", nil, nil).Obj()
+	llamaSmall := wrapper.MakeModel("llama3-1b").FamilyName("llama3").ModelSourceWithModelHub("Huggingface").ModelSourceWithModelID("meta-llama/Llama-3.2-1B", "", "", nil, nil).Obj()
+	sqlAdapter := wrapper.MakeModel("llama3-sql").FamilyName("llama3").ModelSourceWithModelHub("Huggingface").ModelSourceWithModelID("org/llama3-sql-lora", "", "", nil, nil).Obj()
+	chatAdapter := wrapper.MakeModel("llama3-chat").FamilyName("llama3").ModelSourceWithModelHub("Huggingface").ModelSourceWithModelID("org/llama3-chat-lora", "", "", nil, nil).Obj()
+
+	testCases := []struct {
+		name       string
+		models     []*coreapi.OpenModel
+		playground *inferenceapi.Playground
+		wantArgs   []string
+	}{
+		{
+			name:       "speculative decoding",
+			models:     []*coreapi.OpenModel{llama, llamaSmall},
+			playground: wrapper.MakePlayground("llama3", corev1.NamespaceDefault).ModelClaims([]string{"llama3-8b", "llama3-1b"}, []string{"main", "draft"}).Obj(),
+			wantArgs:   []string{"--model", "/workspace/models/models--meta-llama--Meta-Llama-3-8B", "--speculative_model", "/workspace/models/models--meta-llama--Llama-3.2-1B"},
+		},
+		{
+			name:       "lora adapters",
+			models:     []*coreapi.OpenModel{llama, sqlAdapter, chatAdapter},
+			playground: wrapper.MakePlayground("llama3", corev1.NamespaceDefault).ModelClaims([]string{"llama3-sql", "llama3-8b", "llama3-chat"}, []string{"lora", "main", "lora"}).Obj(),
+			wantArgs: []string{"--model", "/workspace/models/models--meta-llama--Meta-Llama-3-8B", "--enable-lora", "--lora-modules",
+				"llama3-sql=/workspace/models/models--org--llama3-sql-lora", "llama3-chat=/workspace/models/models--org--llama3-chat-lora"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			args, err := NewBackendRuntimeParser(backend, tc.models, tc.playground).Args()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.wantArgs, args); diff != "" {
+				t.Fatalf("Args() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBackendRuntimeParser_RenderContext(t *testing.T) {
+	backend := &inferenceapi.BackendRuntime{
+		ObjectMeta: metav1.ObjectMeta{Name: "vllm"},
+		Spec: inferenceapi.BackendRuntimeSpec{
+			RecommendedConfigs: []inferenceapi.RecommendedConfig{
+				{
+					Name: "default",
+					Args: []string{"--model", "{{ .ModelPath }}", "--tensor-parallel-size", "{{ .AcceleratorCount | default 1 }}", "--max-model-len", "{{ index .Flavor.Params \"MAX-MODEL-LEN\" }}"},
+				},
+			},
+		},
+	}
+
+	model := wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithModelHub("Huggingface").ModelSourceWithModelID("meta-llama/Meta-Llama-3-8B", "", "", nil, nil).
+		InferenceFlavors(
+			*wrapper.MakeFlavor("a100").SetRequest("nvidia.com/gpu", "4").SetParams("MAX-MODEL-LEN", "8192").Obj(),
+			*wrapper.MakeFlavor("l4").SetRequest("nvidia.com/gpu", "2").SetParams("MAX-MODEL-LEN", "4096").Obj(),
+		).Obj()
+
+	testCases := []struct {
+		name       string
+		playground *inferenceapi.Playground
+		wantArgs   []string
+	}{
+		{
+			name:       "the most preferred flavor",
+			playground: wrapper.MakePlayground("llama3", corev1.NamespaceDefault).ModelClaim("llama3-8b").Replicas(2).Obj(),
+			wantArgs:   []string{"--model", "/workspace/models/models--meta-llama--Meta-Llama-3-8B", "--tensor-parallel-size", "4", "--max-model-len", "8192"},
+		},
+		{
+			name: "the fallback flavor",
+			playground: func() *inferenceapi.Playground {
+				playground := wrapper.MakePlayground("llama3", corev1.NamespaceDefault).ModelClaim("llama3-8b").Replicas(2).Obj()
+				playground.Status.Flavor = "l4"
+				return playground
+			}(),
+			wantArgs: []string{"--model", "/workspace/models/models--meta-llama--Meta-Llama-3-8B", "--tensor-parallel-size", "2", "--max-model-len", "4096"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			parser := NewBackendRuntimeParser(backend, []*coreapi.OpenModel{model}, tc.playground)
+			args, err := parser.Args()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.wantArgs, args); diff != "" {
+				t.Fatalf("Args() mismatch (-want +got):\n%s", diff)
+			}
+
+			ctx, err := parser.RenderContext()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ctx.Replicas != 2 || ctx.Size != 1 || ctx.Namespace != corev1.NamespaceDefault {
+				t.Fatalf("unexpected render context: %+v", ctx)
+			}
+		})
+	}
+}
+
+func TestBackendRuntimeParser_MultiNode(t *testing.T) {
+	testCases := []struct {
+		name              string
+		multiNode         *inferenceapi.MultiNodeConfig
+		flavors           []coreapi.Flavor
+		configName        string
+		wantSize          int32
+		wantLeaderCommand []string
+		wantLeaderArgs    []string
+		wantWorkerCommand []string
+		wantWorkerArgs    []string
+	}{
+		{
+			name:              "single node",
+			configName:        "default",
+			wantSize:          1,
+			wantLeaderCommand: []string{"python3", "-m", "sglang.launch_server"},
+			wantLeaderArgs:    []string{"--model-path", "/workspace/models/models--meta-llama--Meta-Llama-3-8B"},
+			wantWorkerCommand: []string{"python3", "-m", "sglang.launch_server"},
+			wantWorkerArgs:    []string{"--model-path", "/workspace/models/models--meta-llama--Meta-Llama-3-8B"},
+		},
+		{
+			name:              "leader and workers share the args",
+			configName:        "multi-nodes",
+			multiNode:         &inferenceapi.MultiNodeConfig{Size: ptr.To[int32](2)},
+			wantSize:          2,
+			wantLeaderCommand: []string{"python3", "-m", "sglang.launch_server"},
+			wantLeaderArgs:    []string{"--model-path", "/workspace/models/models--meta-llama--Meta-Llama-3-8B", "--dist-init-addr", "$(LWS_LEADER_ADDRESS):20000", "--nnodes", "2", "--node-rank", "$(LWS_WORKER_INDEX)"},
+			wantWorkerCommand: []string{"python3", "-m", "sglang.launch_server"},
+			wantWorkerArgs:    []string{"--model-path", "/workspace/models/models--meta-llama--Meta-Llama-3-8B", "--dist-init-addr", "$(LWS_LEADER_ADDRESS):20000", "--nnodes", "2", "--node-rank", "$(LWS_WORKER_INDEX)"},
+		},
+		{
+			name:       "size overwritten by the flavor",
+			configName: "multi-nodes",
+			multiNode: &inferenceapi.MultiNodeConfig{
+				Size:   ptr.To[int32](2),
+				Leader: &inferenceapi.NodeConfig{Command: []string{"sh", "-c"}, Args: []string{"ray start --head && vllm serve {{ .ModelPath }} --pipeline-parallel-size {{ .Size }}"}},
+				Worker: &inferenceapi.NodeConfig{Command: []string{"sh", "-c"}, Args: []string{"ray start --address={{ .LeaderAddress }}:6379 --block"}},
+			},
+			flavors:           []coreapi.Flavor{*wrapper.MakeFlavor("a100").SetParams("PP", "4").Obj()},
+			wantSize:          4,
+			wantLeaderCommand: []string{"sh", "-c"},
+			wantLeaderArgs:    []string{"ray start --head && vllm serve /workspace/models/models--meta-llama--Meta-Llama-3-8B --pipeline-parallel-size 4"},
+			wantWorkerCommand: []string{"sh", "-c"},
+			wantWorkerArgs:    []string{"ray start --address=$(LWS_LEADER_ADDRESS):6379 --block"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			backendWrapper := wrapper.MakeBackendRuntime("sglang").
+				Command([]string{"python3", "-m", "sglang.launch_server"}).
+				Arg("default", []string{"--model-path", "{{ .ModelPath }}"}).
+				Arg("multi-nodes", []string{"--model-path", "{{ .ModelPath }}", "--dist-init-addr", "{{ .LeaderAddress }}:20000", "--nnodes", "{{ .Size }}", "--node-rank", "{{ .WorkerIndex }}"})
+			if tc.multiNode != nil {
+				backendWrapper.MultiNode("multi-nodes", *tc.multiNode)
+			}
+			backend := backendWrapper.Obj()
+			model := wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithModelHub("Huggingface").ModelSourceWithModelID("meta-llama/Meta-Llama-3-8B", "", "", nil, nil).
+				InferenceFlavors(tc.flavors...).Obj()
+			playground := wrapper.MakePlayground("llama3", corev1.NamespaceDefault).ModelClaim("llama3-8b").Obj()
+			playground.Spec.BackendRuntimeConfig = &inferenceapi.BackendRuntimeConfig{ConfigName: ptr.To(tc.configName)}
+
+			parser := NewBackendRuntimeParser(backend, []*coreapi.OpenModel{model}, playground)
+			if size := parser.Size(); size != tc.wantSize {
+				t.Fatalf("unexpected size, want %d, got %d", tc.wantSize, size)
+			}
+			if diff := cmp.Diff(tc.wantLeaderCommand, parser.LeaderCommand()); diff != "" {
+				t.Fatalf("LeaderCommand() mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantWorkerCommand, parser.WorkerCommand()); diff != "" {
+				t.Fatalf("WorkerCommand() mismatch (-want +got):\n%s", diff)
+			}
+			leaderArgs, err := parser.LeaderArgs()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.wantLeaderArgs, leaderArgs); diff != "" {
+				t.Fatalf("LeaderArgs() mismatch (-want +got):\n%s", diff)
+			}
+			workerArgs, err := parser.WorkerArgs()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.wantWorkerArgs, workerArgs); diff != "" {
+				t.Fatalf("WorkerArgs() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMatchRecommendedConfig(t *testing.T) {
+	configs := []inferenceapi.RecommendedConfig{
+		{Name: "default", Args: []string{"generic"}},
+		{Name: "default", ModelFamilies: []coreapi.ModelName{"llama3"}, Args: []string{"llama3"}},
+		{Name: "default", Flavors: []coreapi.FlavorName{"a100", "h100"}, Args: []string{"a100"}},
+		{Name: "default", Flavors: []coreapi.FlavorName{"a100"}, ModelFamilies: []coreapi.ModelName{"llama3"}, Args: []string{"a100-llama3"}},
+		{Name: "speculative-decoding", Flavors: []coreapi.FlavorName{"l4"}, Args: []string{"l4"}},
+	}
+
+	testCases := []struct {
+		name     string
+		config   string
+		flavor   coreapi.FlavorName
+		family   coreapi.ModelName
+		wantArgs []string
+	}{
+		{
+			name:     "match both flavor and model family",
+			config:   "default",
+			flavor:   "a100",
+			family:   "llama3",
+			wantArgs: []string{"a100-llama3"},
+		},
+		{
+			name:     "flavor takes precedence over model family",
+			config:   "default",
+			flavor:   "h100",
+			family:   "llama3",
+			wantArgs: []string{"a100"},
+		},
+		{
+			name:     "match model family only",
+			config:   "default",
+			flavor:   "l4",
+			family:   "llama3",
+			wantArgs: []string{"llama3"},
+		},
+		{
+			name:     "fallback to the generic one",
+			config:   "default",
+			family:   "qwen2",
+			wantArgs: []string{"generic"},
+		},
+		{
+			name:   "no matched config",
+			config: "speculative-decoding",
+			flavor: "a100",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := MatchRecommendedConfig(configs, tc.config, tc.flavor, tc.family)
+			if tc.wantArgs == nil {
+				if config != nil {
+					t.Fatalf("unexpected config: %v", config)
+				}
+				return
+			}
+			if config == nil {
+				t.Fatal("expected a config, got nil")
+			}
+			if diff := cmp.Diff(tc.wantArgs, config.Args); diff != "" {
+				t.Fatalf("unexpected config (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBackendRuntimeParser_Probes(t *testing.T) {
+	livenessProbe := &corev1.Probe{ProbeHandler: corev1.ProbeHandler{TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt32(8080)}}}
+
+	testCases := []struct {
+		name               string
+		backend            *inferenceapi.BackendRuntime
+		modelSize          string
+		wantStartupProbe   *corev1.Probe
+		wantLivenessProbe  *corev1.Probe
+		wantReadinessProbe *corev1.Probe
+	}{
+		{
+			name:    "no endpoints",
+			backend: wrapper.MakeBackendRuntime("vllm").Arg("default", []string{}).Obj(),
+		},
+		{
+			name: "probes specified by the backendRuntime take precedence",
+			backend: func() *inferenceapi.BackendRuntime {
+				backend := wrapper.MakeBackendRuntime("vllm").Arg("default", []string{}).Probe("liveness", livenessProbe).Obj()
+				backend.Spec.Endpoints = &inferenceapi.BackendEndpoints{Health: ptr.To("/health"), Ready: ptr.To("/ready")}
+				return backend
+			}(),
+			wantStartupProbe:   &corev1.Probe{ProbeHandler: corev1.ProbeHandler{HTTPGet: &corev1.HTTPGetAction{Path: "/health", Port: intstr.FromInt32(8080)}}, PeriodSeconds: 10, FailureThreshold: 30},
+			wantLivenessProbe:  livenessProbe,
+			wantReadinessProbe: &corev1.Probe{ProbeHandler: corev1.ProbeHandler{HTTPGet: &corev1.HTTPGetAction{Path: "/ready", Port: intstr.FromInt32(8080)}}, PeriodSeconds: 5, FailureThreshold: 3},
+		},
+		{
+			name: "startup probe scaled with the model size",
+			backend: func() *inferenceapi.BackendRuntime {
+				backend := wrapper.MakeBackendRuntime("vllm").Arg("default", []string{}).Obj()
+				backend.Spec.Endpoints = &inferenceapi.BackendEndpoints{Health: ptr.To("/health")}
+				return backend
+			}(),
+			modelSize:          "15Gi",
+			wantStartupProbe:   &corev1.Probe{ProbeHandler: corev1.ProbeHandler{HTTPGet: &corev1.HTTPGetAction{Path: "/health", Port: intstr.FromInt32(8080)}}, PeriodSeconds: 10, FailureThreshold: 34},
+			wantLivenessProbe:  &corev1.Probe{ProbeHandler: corev1.ProbeHandler{HTTPGet: &corev1.HTTPGetAction{Path: "/health", Port: intstr.FromInt32(8080)}}, PeriodSeconds: 10, FailureThreshold: 3},
+			wantReadinessProbe: &corev1.Probe{ProbeHandler: corev1.ProbeHandler{HTTPGet: &corev1.HTTPGetAction{Path: "/health", Port: intstr.FromInt32(8080)}}, PeriodSeconds: 5, FailureThreshold: 3},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			model := wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithModelHub("Huggingface").ModelSourceWithModelID("meta-llama/Meta-Llama-3-8B", "", "", nil, nil).Obj()
+			if tc.modelSize != "" {
+				model.Annotations = map[string]string{coreapi.ModelSizeAnnoKey: tc.modelSize}
+			}
+			playground := wrapper.MakePlayground("llama3", corev1.NamespaceDefault).ModelClaim("llama3-8b").Obj()
+
+			parser := NewBackendRuntimeParser(tc.backend, []*coreapi.OpenModel{model}, playground)
+			if diff := cmp.Diff(tc.wantStartupProbe, parser.StartupProbe()); diff != "" {
+				t.Fatalf("StartupProbe() mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantLivenessProbe, parser.LivenessProbe()); diff != "" {
+				t.Fatalf("LivenessProbe() mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantReadinessProbe, parser.ReadinessProbe()); diff != "" {
+				t.Fatalf("ReadinessProbe() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBackendRuntimeParser_GracefulShutdown(t *testing.T) {
+	preStop := &corev1.LifecycleHandler{Exec: &corev1.ExecAction{Command: []string{"/bin/sh", "-c", "sleep 30"}}}
+	postStart := &corev1.LifecycleHandler{Exec: &corev1.ExecAction{Command: []string{"/bin/sh", "-c", "echo started"}}}
+
+	testCases := []struct {
+		name                  string
+		lifecycle             *corev1.Lifecycle
+		endpoints             *inferenceapi.BackendEndpoints
+		gracefulShutdown      *inferenceapi.GracefulShutdown
+		wantGracePeriod       int64
+		wantPreStop           *corev1.LifecycleHandler
+		wantPreStopScriptPart string
+		wantPostStart         *corev1.LifecycleHandler
+	}{
+		{
+			name:            "no graceful shutdown",
+			wantGracePeriod: 130,
+		},
+		{
+			name:             "preStop specified by the backendRuntime takes precedence",
+			lifecycle:        &corev1.Lifecycle{PreStop: preStop},
+			gracefulShutdown: &inferenceapi.GracefulShutdown{DrainTimeoutSeconds: ptr.To[int64](60)},
+			wantGracePeriod:  70,
+			wantPreStop:      preStop,
+		},
+		{
+			name:             "sleep without the metrics",
+			lifecycle:        &corev1.Lifecycle{PostStart: postStart},
+			gracefulShutdown: &inferenceapi.GracefulShutdown{DrainTimeoutSeconds: ptr.To[int64](30)},
+			wantGracePeriod:  40,
+			wantPreStop:      preStop,
+			wantPostStart:    postStart,
+		},
+		{
+			name:                  "wait for the inflight requests",
+			endpoints:             &inferenceapi.BackendEndpoints{Metrics: ptr.To("/metrics")},
+			gracefulShutdown:      &inferenceapi.GracefulShutdown{InflightRequestMetrics: []string{"vllm:num_requests_running", "vllm:num_requests_waiting"}},
+			wantGracePeriod:       130,
+			wantPreStopScriptPart: "if METRICS=$(curl -sf http://localhost:8080/metrics); then\n    INFLIGHT=$(echo \"$METRICS\" | grep -E '^(vllm:num_requests_running|vllm:num_requests_waiting)[{ ]'",
+		},
+		{
+			name:                  "sleep without curl in the image",
+			endpoints:             &inferenceapi.BackendEndpoints{Metrics: ptr.To("/metrics")},
+			gracefulShutdown:      &inferenceapi.GracefulShutdown{DrainTimeoutSeconds: ptr.To[int64](60), InflightRequestMetrics: []string{"vllm:num_requests_running"}},
+			wantGracePeriod:       70,
+			wantPreStopScriptPart: "if ! command -v curl >/dev/null 2>&1 || ! command -v grep >/dev/null 2>&1 || ! command -v awk >/dev/null 2>&1; then\n  sleep 60\n  exit 0\nfi",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			backend := wrapper.MakeBackendRuntime("vllm").Arg("default", []string{}).Lifecycle(tc.lifecycle).Obj()
+			backend.Spec.Endpoints = tc.endpoints
+			backend.Spec.GracefulShutdown = tc.gracefulShutdown
+			model := wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithModelHub("Huggingface").ModelSourceWithModelID("meta-llama/Meta-Llama-3-8B", "", "", nil, nil).Obj()
+			playground := wrapper.MakePlayground("llama3", corev1.NamespaceDefault).ModelClaim("llama3-8b").Obj()
+
+			parser := NewBackendRuntimeParser(backend, []*coreapi.OpenModel{model}, playground)
+			if got := parser.TerminationGracePeriodSeconds(); got != tc.wantGracePeriod {
+				t.Fatalf("unexpected termination grace period, want %d, got %d", tc.wantGracePeriod, got)
+			}
+
+			lifecycle := parser.Lifecycle()
+			if tc.wantPreStopScriptPart != "" {
+				if lifecycle == nil || lifecycle.PreStop == nil || lifecycle.PreStop.Exec == nil {
+					t.Fatalf("expected a synthesized preStop hook, got %v", lifecycle)
+				}
+				if script := lifecycle.PreStop.Exec.Command[2]; !strings.Contains(script, tc.wantPreStopScriptPart) {
+					t.Fatalf("unexpected preStop script: %s", script)
+				}
+				return
+			}
+
+			var gotPreStop, gotPostStart *corev1.LifecycleHandler
+			if lifecycle != nil {
+				gotPreStop, gotPostStart = lifecycle.PreStop, lifecycle.PostStart
+			}
+			if diff := cmp.Diff(tc.wantPreStop, gotPreStop); diff != "" {
+				t.Fatalf("unexpected preStop (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantPostStart, gotPostStart); diff != "" {
+				t.Fatalf("unexpected postStart (-want +got):\n%s", diff)
+			}
+			if tc.lifecycle != nil && tc.lifecycle.PreStop == nil && tc.gracefulShutdown != nil && backend.Spec.Lifecycle.PreStop != nil {
+				t.Fatal("the lifecycle of the backendRuntime should not be mutated")
+			}
+		})
+	}
+}
+
+func TestBackendRuntimeParser_ModelMetadata(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		gpus                 string
+		annotation           string
+		weightSize           string
+		wantFailureThreshold int32
+		wantShm              *resource.Quantity
+	}{
+		{
+			name:                 "unknown weight size",
+			gpus:                 "2",
+			wantFailureThreshold: 30,
+		},
+		{
+			name:                 "discovered weight size with multiple GPUs",
+			gpus:                 "2",
+			weightSize:           "16Gi",
+			wantFailureThreshold: 34,
+			wantShm:              ptr.To(resource.MustParse("4Gi")),
+		},
+		{
+			name:                 "annotation takes precedence",
+			gpus:                 "2",
+			annotation:           "2Gi",
+			weightSize:           "16Gi",
+			wantFailureThreshold: 31,
+			wantShm:              ptr.To(resource.MustParse("1Gi")),
+		},
+		{
+			name:                 "shared memory bounded for large models",
+			gpus:                 "8",
+			weightSize:           "800Gi",
+			wantFailureThreshold: 230,
+			wantShm:              ptr.To(resource.MustParse("16Gi")),
+		},
+		{
+			name:                 "no shared memory with a single GPU",
+			gpus:                 "1",
+			weightSize:           "16Gi",
+			wantFailureThreshold: 34,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			backend := wrapper.MakeBackendRuntime("vllm").Arg("default", []string{}).Obj()
+			backend.Spec.Endpoints = &inferenceapi.BackendEndpoints{Health: ptr.To("/health")}
+			model := wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithModelHub("Huggingface").ModelSourceWithModelID("meta-llama/Meta-Llama-3-8B", "", "", nil, nil).
+				InferenceFlavors(*wrapper.MakeFlavor("a100").SetRequest("nvidia.com/gpu", tc.gpus).Obj()).Obj()
+			if tc.annotation != "" {
+				model.Annotations = map[string]string{coreapi.ModelSizeAnnoKey: tc.annotation}
+			}
+			if tc.weightSize != "" {
+				model.Status.Metadata = &coreapi.ModelMetadata{WeightSize: ptr.To(resource.MustParse(tc.weightSize))}
+			}
+			playground := wrapper.MakePlayground("llama3", corev1.NamespaceDefault).ModelClaim("llama3-8b").Obj()
+
+			parser := NewBackendRuntimeParser(backend, []*coreapi.OpenModel{model}, playground)
+			if got := parser.StartupProbe().FailureThreshold; got != tc.wantFailureThreshold {
+				t.Fatalf("unexpected failure threshold, want %d, got %d", tc.wantFailureThreshold, got)
+			}
+			got := parser.SharedMemorySize()
+			if (got == nil) != (tc.wantShm == nil) || (got != nil && got.Cmp(*tc.wantShm) != 0) {
+				t.Fatalf("unexpected shared memory size, want %v, got %v", tc.wantShm, got)
+			}
+		})
+	}
+}
+
+func TestRecommendedConfigsOverlap(t *testing.T) {
+	testCases := []struct {
+		name string
+		a    inferenceapi.RecommendedConfig
+		b    inferenceapi.RecommendedConfig
+		want bool
+	}{
+		{
+			name: "different names",
+			a:    inferenceapi.RecommendedConfig{Name: "default"},
+			b:    inferenceapi.RecommendedConfig{Name: "speculative-decoding"},
+		},
+		{
+			name: "both generic",
+			a:    inferenceapi.RecommendedConfig{Name: "default"},
+			b:    inferenceapi.RecommendedConfig{Name: "default"},
+			want: true,
+		},
+		{
+			name: "overlapped flavors",
+			a:    inferenceapi.RecommendedConfig{Name: "default", Flavors: []coreapi.FlavorName{"a100"}},
+			b:    inferenceapi.RecommendedConfig{Name: "default", Flavors: []coreapi.FlavorName{"l4", "a100"}},
+			want: true,
+		},
+		{
+			name: "disjoint flavors",
+			a:    inferenceapi.RecommendedConfig{Name: "default", Flavors: []coreapi.FlavorName{"a100"}},
+			b:    inferenceapi.RecommendedConfig{Name: "default", Flavors: []coreapi.FlavorName{"l4"}},
+		},
+		{
+			name: "overlapped flavors with disjoint model families",
+			a:    inferenceapi.RecommendedConfig{Name: "default", Flavors: []coreapi.FlavorName{"a100"}, ModelFamilies: []coreapi.ModelName{"llama3"}},
+			b:    inferenceapi.RecommendedConfig{Name: "default", Flavors: []coreapi.FlavorName{"a100"}, ModelFamilies: []coreapi.ModelName{"qwen2"}},
+		},
+		{
+			name: "more specific one takes precedence",
+			a:    inferenceapi.RecommendedConfig{Name: "default", Flavors: []coreapi.FlavorName{"a100"}, ModelFamilies: []coreapi.ModelName{"llama3"}},
+			b:    inferenceapi.RecommendedConfig{Name: "default", Flavors: []coreapi.FlavorName{"a100"}},
+		},
+		{
+			name: "overlapped model families",
+			a:    inferenceapi.RecommendedConfig{Name: "default", ModelFamilies: []coreapi.ModelName{"llama3", "qwen2"}},
+			b:    inferenceapi.RecommendedConfig{Name: "default", ModelFamilies: []coreapi.ModelName{"qwen2"}},
+			want: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := RecommendedConfigsOverlap(tc.a, tc.b); got != tc.want {
+				t.Fatalf("unexpected overlap, want %v, got %v", tc.want, got)
+			}
+			if got := RecommendedConfigsOverlap(tc.b, tc.a); got != tc.want {
+				t.Fatalf("unexpected overlap in reversed order, want %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2025 The InftyAI Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helper
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	helper "github.com/inftyai/llmaz/pkg/controller_helper"
+	modelSource "github.com/inftyai/llmaz/pkg/controller_helper/modelsource"
+)
+
+const (
+	// defaultDrainTimeoutSeconds is mainly quoted from https://github.com/kubernetes-sigs/gateway-api-inference-extension/blob/v0.3.0/config/manifests/vllm/gpu-deployment.yaml#L170-L226.
+	defaultDrainTimeoutSeconds int64 = 120
+	// terminationGracePeriodBuffer leaves time for the backend to exit once drained.
+	terminationGracePeriodBuffer int64 = 10
+
+	probePeriodSeconds = 10
+	// startupFailureThreshold allows the backend 5 minutes to load the model at least.
+	startupFailureThreshold int32 = 30
+	// startupGiBPerPeriod represents how many GiB of weights are supposed to be loaded
+	// per probe period, used to extend the startup probe for large models.
+	startupGiBPerPeriod = 4
+)
+
+// StartupProbe returns the startup probe of the backendRuntime, or synthesizes one from
+// the health endpoint, tolerating longer startup for larger models.
+func (p *BackendRuntimeParser) StartupProbe() *corev1.Probe {
+	if p.backendRuntime.Spec.StartupProbe != nil {
+		return p.backendRuntime.Spec.StartupProbe
+	}
+	path := p.healthPath()
+	if path == "" {
+		return nil
+	}
+	return &corev1.Probe{
+		ProbeHandler:     httpGetHandler(path),
+		PeriodSeconds:    probePeriodSeconds,
+		FailureThreshold: StartupFailureThreshold(p.modelSize()),
+	}
+}
+
+// LivenessProbe returns the liveness probe of the backendRuntime, or synthesizes one from the health endpoint.
+func (p *BackendRuntimeParser) LivenessProbe() *corev1.Probe {
+	if p.backendRuntime.Spec.LivenessProbe != nil {
+		return p.backendRuntime.Spec.LivenessProbe
+	}
+	path := p.healthPath()
+	if path == "" {
+		return nil
+	}
+	return &corev1.Probe{
+		ProbeHandler:     httpGetHandler(path),
+		PeriodSeconds:    probePeriodSeconds,
+		FailureThreshold: 3,
+	}
+}
+
+// ReadinessProbe returns the readiness probe of the backendRuntime, or synthesizes one from
+// the ready endpoint, fallback to the health endpoint.
+func (p *BackendRuntimeParser) ReadinessProbe() *corev1.Probe {
+	if p.backendRuntime.Spec.ReadinessProbe != nil {
+		return p.backendRuntime.Spec.ReadinessProbe
+	}
+	path := p.healthPath()
+	if endpoints := p.backendRuntime.Spec.Endpoints; endpoints != nil && endpoints.Ready != nil {
+		path = *endpoints.Ready
+	}
+	if path == "" {
+		return nil
+	}
+	return &corev1.Probe{
+		ProbeHandler:     httpGetHandler(path),
+		PeriodSeconds:    5,
+		FailureThreshold: 3,
+	}
+}
+
+// TerminationGracePeriodSeconds returns the seconds to wait for the backend to drain and exit.
+func (p *BackendRuntimeParser) TerminationGracePeriodSeconds() int64 {
+	return p.drainTimeoutSeconds() + terminationGracePeriodBuffer
+}
+
+// preStopDrain synthesizes a preStop hook waiting for the in-flight requests to finish,
+// nil if the graceful shutdown is not configured.
+func (p *BackendRuntimeParser) preStopDrain() *corev1.LifecycleHandler {
+	shutdown := p.backendRuntime.Spec.GracefulShutdown
+	if shutdown == nil {
+		return nil
+	}
+
+	timeout := p.drainTimeoutSeconds()
+	var metricsPath string
+	if endpoints := p.backendRuntime.Spec.Endpoints; endpoints != nil && endpoints.Metrics != nil {
+		metricsPath = *endpoints.Metrics
+	}
+	if metricsPath == "" || len(shutdown.InflightRequestMetrics) == 0 {
+		return &corev1.LifecycleHandler{
+			Exec: &corev1.ExecAction{Command: []string{"/bin/sh", "-c", fmt.Sprintf("sleep %d", timeout)}},
+		}
+	}
+
+	// Not every engine image ships curl, grep and awk, wait for the whole timeout without them,
+	// and keep waiting once the metrics are unavailable for a while.
+	script := fmt.Sprintf(`END=$(($(date +%%s) + %d))
+if ! command -v curl >/dev/null 2>&1 || ! command -v grep >/dev/null 2>&1 || ! command -v awk >/dev/null 2>&1; then
+  sleep %d
+  exit 0
+fi
+while [ "$(date +%%s)" -lt "$END" ]; do
+  if METRICS=$(curl -sf http://localhost:%d%s); then
+    INFLIGHT=$(echo "$METRICS" | grep -E '^(%s)[{ ]' | awk '{sum += $2} END {print sum + 0}')
+    if [ "$INFLIGHT" = "0" ]; then
+      break
+    fi
+  fi
+  sleep 5
+done`, timeout, timeout, modelSource.DEFAULT_BACKEND_PORT, metricsPath, strings.Join(shutdown.InflightRequestMetrics, "|"))
+
+	return &corev1.LifecycleHandler{
+		Exec: &corev1.ExecAction{Command: []string{"/bin/sh", "-c", script}},
+	}
+}
+
+func (p *BackendRuntimeParser) drainTimeoutSeconds() int64 {
+	if shutdown := p.backendRuntime.Spec.GracefulShutdown; shutdown != nil {
+		return ptr.Deref(shutdown.DrainTimeoutSeconds, defaultDrainTimeoutSeconds)
+	}
+	return defaultDrainTimeoutSeconds
+}
+
+func (p *BackendRuntimeParser) healthPath() string {
+	if endpoints := p.backendRuntime.Spec.Endpoints; endpoints != nil && endpoints.Health != nil {
+		return *endpoints.Health
+	}
+	return ""
+}
+
+// modelSize sums up the weight size of all the models, nil if any of them is unknown.
+// The size annotation takes precedence over the discovered metadata.
+func (p *BackendRuntimeParser) modelSize() *resource.Quantity {
+	total := resource.Quantity{}
+	for _, model := range p.models {
+		size := helper.ModelWeightSize(model)
+		if size == nil {
+			return nil
+		}
+		total.Add(*size)
+	}
+	return &total
+}
+
+// StartupFailureThreshold returns the failure threshold of the startup probe for the model size.
+func StartupFailureThreshold(size *resource.Quantity) int32 {
+	if size == nil {
+		return startupFailureThreshold
+	}
+	gib := (size.Value() + (1 << 30) - 1) >> 30
+	return startupFailureThreshold + int32((gib+startupGiBPerPeriod-1)/startupGiBPerPeriod)
+}
+
+func httpGetHandler(path string) corev1.ProbeHandler {
+	return corev1.ProbeHandler{
+		HTTPGet: &corev1.HTTPGetAction{
+			Path: path,
+			Port: intstr.FromInt32(modelSource.DEFAULT_BACKEND_PORT),
+		},
+	}
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2025 The InftyAI Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helper
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	coreapi "github.com/inftyai/llmaz/api/core/v1alpha1"
+)
+
+// The environments injected into every pod of a LeaderWorkerSet group,
+// container args referring to $(ENV) are expanded by the kubelet.
+const (
+	lwsLeaderAddressEnv = "$(LWS_LEADER_ADDRESS)"
+	lwsWorkerIndexEnv   = "$(LWS_WORKER_INDEX)"
+)
+
+// RenderContext is the data to render the args of the recommended configs with Go text/template,
+// e.g. "{{ .ModelPath }}" or "{{ .AcceleratorCount | default 1 }}".
+type RenderContext struct {
+	// ModelName is the name of the main model.
+	ModelName string
+	// ModelPath is the path of the main model.
+	ModelPath string
+	// DraftModelPath is the path of the draft model in speculative-decoding.
+	DraftModelPath string
+	// LoraModules are the name=path pairs of the lora models, a flag with only
+	// {{ .LoraModules }} will be expanded into one arg per adapter.
+	LoraModules ArgList
+	// Models groups the models by role, e.g. {{ (index .Models "draft" 0).Path }}.
+	Models map[string][]ModelInfo
+	// Flavor is the inference flavor applied to the workloads, nil if not configured,
+	// e.g. {{ index .Flavor.Params "TP" }}.
+	Flavor *coreapi.Flavor
+	// AcceleratorCount is the number of accelerators like GPUs per pod, counted from the flavor limits.
+	AcceleratorCount int64
+	// Replicas is the number of the inference workloads.
+	Replicas int32
+	// Size is the number of pods in a workload, including the leader.
+	Size int32
+	// LeaderAddress is the address of the leader pod of the workload.
+	LeaderAddress string
+	// WorkerIndex is the index of the pod in the workload, 0 refers to the leader.
+	WorkerIndex string
+	// Namespace is the namespace of the workloads.
+	Namespace string
+}
+
+// ModelInfo represents a model in the RenderContext.
+type ModelInfo struct {
+	Name string
+	Path string
+}
+
+// ArgList is rendered as space separated values, or expanded into
+// multiple args once referred by the whole flag.
+type ArgList []string
+
+func (l ArgList) String() string {
+	return strings.Join(l, " ")
+}
+
+var wholeFlagRe = regexp.MustCompile(`^\s*\{\{\s*\.(\w+)\s*\}\}\s*$`)
+
+// templateFuncs are the helper functions available in the args.
+var templateFuncs = template.FuncMap{
+	// default returns the value unless it's empty, e.g. {{ .AcceleratorCount | default 1 }}.
+	"default": func(defaultValue, value any) any {
+		if value == nil || reflect.ValueOf(value).IsZero() {
+			return defaultValue
+		}
+		return value
+	},
+	"add": func(a, b any) (int64, error) { return calculate(a, b, func(x, y int64) int64 { return x + y }) },
+	"sub": func(a, b any) (int64, error) { return calculate(a, b, func(x, y int64) int64 { return x - y }) },
+	"mul": func(a, b any) (int64, error) { return calculate(a, b, func(x, y int64) int64 { return x * y }) },
+	"div": func(a, b any) (int64, error) {
+		if y, err := toInt64(b); err == nil && y == 0 {
+			return 0, fmt.Errorf("division by zero")
+		}
+		return calculate(a, b, func(x, y int64) int64 { return x / y })
+	},
+	"max":   func(a, b any) (int64, error) { return calculate(a, b, func(x, y int64) int64 { return max(x, y) }) },
+	"min":   func(a, b any) (int64, error) { return calculate(a, b, func(x, y int64) int64 { return min(x, y) }) },
+	"join":  func(sep string, values []string) string { return strings.Join(values, sep) },
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+	"trim":  strings.TrimSpace,
+}
+
+func calculate(a, b any, fn func(x, y int64) int64) (int64, error) {
+	x, err := toInt64(a)
+	if err != nil {
+		return 0, err
+	}
+	y, err := toInt64(b)
+	if err != nil {
+		return 0, err
+	}
+	return fn(x, y), nil
+}
+
+func toInt64(value any) (int64, error) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(v.Uint()), nil
+	case reflect.String:
+		return strconv.ParseInt(strings.TrimSpace(v.String()), 10, 64)
+	}
+	return 0, fmt.Errorf("%v is not an integer", value)
+}
+
+// ValidateArgs checks whether the args are valid templates.
+func ValidateArgs(args []string) error {
+	for _, arg := range args {
+		if _, err := newTemplate(arg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newTemplate(flag string) (*template.Template, error) {
+	return template.New("arg").Option("missingkey=error").Funcs(templateFuncs).Parse(flag)
+}
+
+// renderFlags renders every flag as a template with data, a flag referring to an ArgList
+// only will be expanded into one arg per element.
+func renderFlags(flags []string, data any) ([]string, error) {
+	res := []string{}
+
+	for _, flag := range flags {
+		if match := wholeFlagRe.FindStringSubmatch(flag); match != nil {
+			if values, ok := argListField(data, match[1]); ok {
+				res = append(res, values...)
+				continue
+			}
+		}
+
+		tmpl, err := newTemplate(flag)
+		if err != nil {
+			return nil, fmt.Errorf("the flag has format error: %s: %v", flag, err)
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("failed to render flag: %s: %v", flag, err)
+		}
+		res = append(res, buf.String())
+	}
+
+	return res, nil
+}
+
+func argListField(data any, name string) (ArgList, bool) {
+	v := reflect.Indirect(reflect.ValueOf(data))
+	var field reflect.Value
+	switch v.Kind() {
+	case reflect.Struct:
+		field = v.FieldByName(name)
+	case reflect.Map:
+		if v.Type().Key().Kind() == reflect.String {
+			field = v.MapIndex(reflect.ValueOf(name).Convert(v.Type().Key()))
+		}
+	}
+	if !field.IsValid() || !field.CanInterface() {
+		return nil, false
+	}
+	values, ok := field.Interface().(ArgList)
+	return values, ok
+}
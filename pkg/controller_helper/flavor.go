@@ -0,0 +1,297 @@
+/*
+Copyright 2025 The InftyAI Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helper
+
+import (
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	coreapplyv1 "k8s.io/client-go/applyconfigurations/core/v1"
+
+	coreapi "github.com/inftyai/llmaz/api/core/v1alpha1"
+)
+
+// Preset flavor parameters.
+const (
+	FlavorParamTP           = "TP"
+	FlavorParamPP           = "PP"
+	FlavorParamInstanceType = "INSTANCE-TYPE"
+	FlavorParamDoNotDisrupt = "DO-NOT-DISRUPT"
+	// FlavorParamAcceleratorMemory represents the memory of each accelerator, e.g. 80Gi,
+	// used to check whether the flavor fits the model.
+	FlavorParamAcceleratorMemory = "ACCELERATOR-MEMORY"
+)
+
+const (
+	// KarpenterDoNotDisruptAnnoKey prevents Karpenter from voluntarily disrupting the node
+	// the Pod running on, e.g. consolidation, which is expensive for model serving.
+	KarpenterDoNotDisruptAnnoKey = "karpenter.sh/do-not-disrupt"
+)
+
+// ApplyFlavorPlacement steers the Pod onto the nodes matching the flavor, including:
+// - the nodeSelector, tolerations, affinity and topologySpreadConstraints of the flavor.
+// - the required node affinity translated from the flavor params.
+// - the tolerations of the accelerators, which are usually tainted, e.g. nvidia.com/gpu.
+// - the Karpenter annotations translated from the flavor params.
+func ApplyFlavorPlacement(template *coreapplyv1.PodTemplateSpecApplyConfiguration, flavor *coreapi.Flavor) error {
+	if template.Spec == nil {
+		template.WithSpec(coreapplyv1.PodSpec())
+	}
+
+	if len(flavor.NodeSelector) > 0 {
+		template.Spec.WithNodeSelector(flavor.NodeSelector)
+	}
+
+	for _, toleration := range FlavorTolerations(flavor) {
+		tolerationApplyConfiguration := &coreapplyv1.TolerationApplyConfiguration{}
+		if err := toApplyConfiguration(&toleration, tolerationApplyConfiguration); err != nil {
+			return err
+		}
+		addToleration(template.Spec, tolerationApplyConfiguration)
+	}
+
+	if flavor.Affinity != nil {
+		affinity := &coreapplyv1.AffinityApplyConfiguration{}
+		if err := toApplyConfiguration(flavor.Affinity, affinity); err != nil {
+			return err
+		}
+		mergeAffinity(template.Spec, affinity)
+	}
+
+	for _, constraint := range flavor.TopologySpreadConstraints {
+		constraintApplyConfiguration := &coreapplyv1.TopologySpreadConstraintApplyConfiguration{}
+		if err := toApplyConfiguration(&constraint, constraintApplyConfiguration); err != nil {
+			return err
+		}
+		template.Spec.WithTopologySpreadConstraints(constraintApplyConfiguration)
+	}
+
+	if requirements := flavorNodeRequirements(flavor); len(requirements) > 0 {
+		addRequiredNodeAffinity(template.Spec, requirements)
+	}
+
+	if strings.EqualFold(flavor.Params[FlavorParamDoNotDisrupt], "true") {
+		template.WithAnnotations(map[string]string{KarpenterDoNotDisruptAnnoKey: "true"})
+	}
+	return nil
+}
+
+// mergeAffinity merges the flavor affinity to the Pod, the required node selector terms are
+// combined pairwise to AND the constraints, others are appended.
+func mergeAffinity(spec *coreapplyv1.PodSpecApplyConfiguration, affinity *coreapplyv1.AffinityApplyConfiguration) {
+	if spec.Affinity == nil {
+		spec.WithAffinity(coreapplyv1.Affinity())
+	}
+
+	if na := affinity.NodeAffinity; na != nil {
+		if spec.Affinity.NodeAffinity == nil {
+			spec.Affinity.WithNodeAffinity(coreapplyv1.NodeAffinity())
+		}
+		nodeAffinity := spec.Affinity.NodeAffinity
+
+		if na.RequiredDuringSchedulingIgnoredDuringExecution != nil && len(na.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms) > 0 {
+			flavorTerms := na.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+			if nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil || len(nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms) == 0 {
+				nodeAffinity.WithRequiredDuringSchedulingIgnoredDuringExecution(na.RequiredDuringSchedulingIgnoredDuringExecution)
+			} else {
+				podTerms := nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+				terms := make([]coreapplyv1.NodeSelectorTermApplyConfiguration, 0, len(podTerms)*len(flavorTerms))
+				for _, podTerm := range podTerms {
+					for _, flavorTerm := range flavorTerms {
+						term := coreapplyv1.NodeSelectorTermApplyConfiguration{}
+						term.MatchExpressions = append(slices.Clone(podTerm.MatchExpressions), flavorTerm.MatchExpressions...)
+						term.MatchFields = append(slices.Clone(podTerm.MatchFields), flavorTerm.MatchFields...)
+						terms = append(terms, term)
+					}
+				}
+				nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms = terms
+			}
+		}
+		nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution, na.PreferredDuringSchedulingIgnoredDuringExecution...)
+	}
+
+	if pa := affinity.PodAffinity; pa != nil {
+		if spec.Affinity.PodAffinity == nil {
+			spec.Affinity.WithPodAffinity(coreapplyv1.PodAffinity())
+		}
+		podAffinity := spec.Affinity.PodAffinity
+		podAffinity.RequiredDuringSchedulingIgnoredDuringExecution = append(podAffinity.RequiredDuringSchedulingIgnoredDuringExecution, pa.RequiredDuringSchedulingIgnoredDuringExecution...)
+		podAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(podAffinity.PreferredDuringSchedulingIgnoredDuringExecution, pa.PreferredDuringSchedulingIgnoredDuringExecution...)
+	}
+
+	if paa := affinity.PodAntiAffinity; paa != nil {
+		if spec.Affinity.PodAntiAffinity == nil {
+			spec.Affinity.WithPodAntiAffinity(coreapplyv1.PodAntiAffinity())
+		}
+		podAntiAffinity := spec.Affinity.PodAntiAffinity
+		podAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution = append(podAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution, paa.RequiredDuringSchedulingIgnoredDuringExecution...)
+		podAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(podAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution, paa.PreferredDuringSchedulingIgnoredDuringExecution...)
+	}
+}
+
+// toApplyConfiguration converts the API object to the corresponding apply configuration.
+func toApplyConfiguration(obj any, out any) error {
+	unstructured, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return err
+	}
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(unstructured, out)
+}
+
+// flavorNodeRequirements translates the flavor params to the node requirements,
+// sorted by the key to keep the pod template stable.
+func flavorNodeRequirements(flavor *coreapi.Flavor) []*coreapplyv1.NodeSelectorRequirementApplyConfiguration {
+	keys := make([]string, 0, len(flavor.Params))
+	for k := range flavor.Params {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	var requirements []*coreapplyv1.NodeSelectorRequirementApplyConfiguration
+	for _, k := range keys {
+		labelKey := k
+		if k == FlavorParamInstanceType {
+			labelKey = corev1.LabelInstanceTypeStable
+		} else if !strings.Contains(k, "/") {
+			// Not a node label, e.g. TP or PP.
+			continue
+		}
+
+		var values []string
+		for _, v := range strings.Split(flavor.Params[k], ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		}
+		if len(values) == 0 {
+			continue
+		}
+		requirements = append(requirements, coreapplyv1.NodeSelectorRequirement().
+			WithKey(labelKey).
+			WithOperator(corev1.NodeSelectorOpIn).
+			WithValues(values...))
+	}
+	return requirements
+}
+
+// addRequiredNodeAffinity appends the requirements to every node selector term,
+// since terms are ORed while requirements of a term are ANDed.
+func addRequiredNodeAffinity(spec *coreapplyv1.PodSpecApplyConfiguration, requirements []*coreapplyv1.NodeSelectorRequirementApplyConfiguration) {
+	if spec.Affinity == nil {
+		spec.WithAffinity(coreapplyv1.Affinity())
+	}
+	if spec.Affinity.NodeAffinity == nil {
+		spec.Affinity.WithNodeAffinity(coreapplyv1.NodeAffinity())
+	}
+	nodeAffinity := spec.Affinity.NodeAffinity
+	if nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		nodeAffinity.WithRequiredDuringSchedulingIgnoredDuringExecution(coreapplyv1.NodeSelector())
+	}
+	selector := nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if len(selector.NodeSelectorTerms) == 0 {
+		selector.WithNodeSelectorTerms(coreapplyv1.NodeSelectorTerm())
+	}
+	for i := range selector.NodeSelectorTerms {
+		selector.NodeSelectorTerms[i].WithMatchExpressions(requirements...)
+	}
+}
+
+// FlavorTolerations returns the tolerations of the flavor, together with the NoSchedule
+// tolerations of the accelerators requested in the limits.
+func FlavorTolerations(flavor *coreapi.Flavor) []corev1.Toleration {
+	tolerations := slices.Clone(flavor.Tolerations)
+	names := []string{}
+	for name := range flavor.Limits {
+		if isExtendedResource(name) {
+			names = append(names, string(name))
+		}
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		tolerations = append(tolerations, corev1.Toleration{
+			Key:      name,
+			Operator: corev1.TolerationOpExists,
+			Effect:   corev1.TaintEffectNoSchedule,
+		})
+	}
+	return tolerations
+}
+
+func addToleration(spec *coreapplyv1.PodSpecApplyConfiguration, toleration *coreapplyv1.TolerationApplyConfiguration) {
+	for _, t := range spec.Tolerations {
+		if ptrEqual(t.Key, toleration.Key) && ptrEqual(t.Operator, toleration.Operator) &&
+			ptrEqual(t.Value, toleration.Value) && ptrEqual(t.Effect, toleration.Effect) {
+			return
+		}
+	}
+	spec.WithTolerations(toleration)
+}
+
+// NodeMatchesFlavor returns whether the flavor is able to place the Pod onto the node, including
+// the nodeSelector, the node requirements translated from the params and the accelerators.
+func NodeMatchesFlavor(node *corev1.Node, flavor *coreapi.Flavor) bool {
+	for k, v := range flavor.NodeSelector {
+		if node.Labels[k] != v {
+			return false
+		}
+	}
+	for _, requirement := range flavorNodeRequirements(flavor) {
+		value, ok := node.Labels[*requirement.Key]
+		if !ok || !slices.Contains(requirement.Values, value) {
+			return false
+		}
+	}
+	for name := range flavor.Limits {
+		if !isExtendedResource(name) {
+			continue
+		}
+		if quantity, ok := node.Status.Allocatable[name]; !ok || quantity.IsZero() {
+			return false
+		}
+	}
+	return true
+}
+
+// AcceleratorCount returns the number of accelerators, e.g. GPUs, in the limits of the flavor,
+// which are the extended resources like nvidia.com/gpu.
+func AcceleratorCount(flavor *coreapi.Flavor) int64 {
+	if flavor == nil {
+		return 0
+	}
+	var count int64
+	for name, quantity := range flavor.Limits {
+		if isExtendedResource(name) {
+			count += quantity.Value()
+		}
+	}
+	return count
+}
+
+// isExtendedResource returns true for resources like nvidia.com/gpu, which are not
+// native resources like cpu or memory.
+func isExtendedResource(name corev1.ResourceName) bool {
+	return strings.Contains(string(name), "/") && !strings.HasPrefix(string(name), corev1.ResourceDefaultNamespacePrefix)
+}
+
+func ptrEqual[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
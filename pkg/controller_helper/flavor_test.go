@@ -0,0 +1,313 @@
+/*
+Copyright 2025 The InftyAI Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	coreapplyv1 "k8s.io/client-go/applyconfigurations/core/v1"
+	"k8s.io/utils/ptr"
+
+	coreapi "github.com/inftyai/llmaz/api/core/v1alpha1"
+	"github.com/inftyai/llmaz/test/util/wrapper"
+)
+
+func TestApplyFlavorPlacement(t *testing.T) {
+	gpuToleration := corev1.Toleration{Key: "nvidia.com/gpu", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule}
+
+	tests := []struct {
+		name             string
+		template         *coreapplyv1.PodTemplateSpecApplyConfiguration
+		flavor           *coreapi.Flavor
+		wantNodeSelector map[string]string
+		wantTerms        [][]corev1.NodeSelectorRequirement
+		wantTolerations  []corev1.Toleration
+		wantAnnotations  map[string]string
+	}{
+		{
+			name:     "flavor with limits only",
+			template: coreapplyv1.PodTemplateSpec().WithSpec(coreapplyv1.PodSpec()),
+			flavor:   wrapper.MakeFlavor("a100").SetRequest("nvidia.com/gpu", "1").SetRequest("cpu", "1").Obj(),
+			wantTolerations: []corev1.Toleration{
+				gpuToleration,
+			},
+		},
+		{
+			name:     "flavor with nodeSelector and params",
+			template: coreapplyv1.PodTemplateSpec().WithSpec(coreapplyv1.PodSpec().WithNodeSelector(map[string]string{"zone": "a"})),
+			flavor: wrapper.MakeFlavor("a100").
+				SetNodeSelector("gpu-type", "a100").
+				SetParams("INSTANCE-TYPE", "p4d.24xlarge, p4de.24xlarge").
+				SetParams("karpenter.sh/capacity-type", "on-demand").
+				SetParams("TP", "8").
+				SetParams("DO-NOT-DISRUPT", "true").
+				Obj(),
+			wantNodeSelector: map[string]string{"zone": "a", "gpu-type": "a100"},
+			wantTerms: [][]corev1.NodeSelectorRequirement{
+				{
+					{Key: corev1.LabelInstanceTypeStable, Operator: corev1.NodeSelectorOpIn, Values: []string{"p4d.24xlarge", "p4de.24xlarge"}},
+					{Key: "karpenter.sh/capacity-type", Operator: corev1.NodeSelectorOpIn, Values: []string{"on-demand"}},
+				},
+			},
+			wantAnnotations: map[string]string{KarpenterDoNotDisruptAnnoKey: "true"},
+		},
+		{
+			name: "requirements are appended to every existing term",
+			template: coreapplyv1.PodTemplateSpec().WithSpec(coreapplyv1.PodSpec().
+				WithAffinity(coreapplyv1.Affinity().WithNodeAffinity(coreapplyv1.NodeAffinity().
+					WithRequiredDuringSchedulingIgnoredDuringExecution(coreapplyv1.NodeSelector().WithNodeSelectorTerms(
+						coreapplyv1.NodeSelectorTerm().WithMatchExpressions(coreapplyv1.NodeSelectorRequirement().WithKey("zone").WithOperator(corev1.NodeSelectorOpIn).WithValues("a")),
+						coreapplyv1.NodeSelectorTerm().WithMatchExpressions(coreapplyv1.NodeSelectorRequirement().WithKey("zone").WithOperator(corev1.NodeSelectorOpIn).WithValues("b")),
+					)))).
+				WithTolerations(coreapplyv1.Toleration().WithKey("nvidia.com/gpu").WithOperator(corev1.TolerationOpExists).WithEffect(corev1.TaintEffectNoSchedule))),
+			flavor: wrapper.MakeFlavor("a100").SetRequest("nvidia.com/gpu", "1").SetParams("INSTANCE-TYPE", "p4d.24xlarge").Obj(),
+			wantTerms: [][]corev1.NodeSelectorRequirement{
+				{
+					{Key: "zone", Operator: corev1.NodeSelectorOpIn, Values: []string{"a"}},
+					{Key: corev1.LabelInstanceTypeStable, Operator: corev1.NodeSelectorOpIn, Values: []string{"p4d.24xlarge"}},
+				},
+				{
+					{Key: "zone", Operator: corev1.NodeSelectorOpIn, Values: []string{"b"}},
+					{Key: corev1.LabelInstanceTypeStable, Operator: corev1.NodeSelectorOpIn, Values: []string{"p4d.24xlarge"}},
+				},
+			},
+			wantTolerations: []corev1.Toleration{
+				gpuToleration,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ApplyFlavorPlacement(tt.template, tt.flavor); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			spec := tt.template.Spec
+			assert.Equal(t, tt.wantNodeSelector, spec.NodeSelector)
+			var gotAnnotations map[string]string
+			if tt.template.ObjectMetaApplyConfiguration != nil {
+				gotAnnotations = tt.template.Annotations
+			}
+			assert.Equal(t, tt.wantAnnotations, gotAnnotations)
+
+			var gotTerms [][]corev1.NodeSelectorRequirement
+			if spec.Affinity != nil {
+				for _, term := range spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+					var requirements []corev1.NodeSelectorRequirement
+					for _, r := range term.MatchExpressions {
+						requirements = append(requirements, corev1.NodeSelectorRequirement{Key: *r.Key, Operator: *r.Operator, Values: r.Values})
+					}
+					gotTerms = append(gotTerms, requirements)
+				}
+			}
+			assert.Equal(t, tt.wantTerms, gotTerms)
+
+			var gotTolerations []corev1.Toleration
+			for _, toleration := range spec.Tolerations {
+				gotTolerations = append(gotTolerations, corev1.Toleration{
+					Key:      ptr.Deref(toleration.Key, ""),
+					Operator: ptr.Deref(toleration.Operator, ""),
+					Effect:   ptr.Deref(toleration.Effect, ""),
+				})
+			}
+			assert.Equal(t, tt.wantTolerations, gotTolerations)
+		})
+	}
+}
+
+func TestApplyFlavorPlacementWithSchedulingConstraints(t *testing.T) {
+	flavor := wrapper.MakeFlavor("l4").SetParams("INSTANCE-TYPE", "g6.12xlarge").Obj()
+	flavor.Tolerations = []corev1.Toleration{
+		{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "inference", Effect: corev1.TaintEffectNoSchedule},
+	}
+	flavor.Affinity = &corev1.Affinity{
+		NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{
+					{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "pool", Operator: corev1.NodeSelectorOpIn, Values: []string{"l4"}}}},
+					{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "pool", Operator: corev1.NodeSelectorOpIn, Values: []string{"l4-spot"}}}},
+				},
+			},
+			PreferredDuringSchedulingIgnoredDuringExecution: []corev1.PreferredSchedulingTerm{
+				{Weight: 10, Preference: corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "pool", Operator: corev1.NodeSelectorOpIn, Values: []string{"l4"}}}}},
+			},
+		},
+		PodAntiAffinity: &corev1.PodAntiAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+				{Weight: 1, PodAffinityTerm: corev1.PodAffinityTerm{TopologyKey: corev1.LabelHostname}},
+			},
+		},
+	}
+	flavor.TopologySpreadConstraints = []corev1.TopologySpreadConstraint{
+		{MaxSkew: 1, TopologyKey: corev1.LabelTopologyZone, WhenUnsatisfiable: corev1.ScheduleAnyway},
+	}
+
+	template := coreapplyv1.PodTemplateSpec().WithSpec(coreapplyv1.PodSpec().
+		WithAffinity(coreapplyv1.Affinity().WithNodeAffinity(coreapplyv1.NodeAffinity().
+			WithRequiredDuringSchedulingIgnoredDuringExecution(coreapplyv1.NodeSelector().WithNodeSelectorTerms(
+				coreapplyv1.NodeSelectorTerm().WithMatchExpressions(coreapplyv1.NodeSelectorRequirement().WithKey("zone").WithOperator(corev1.NodeSelectorOpIn).WithValues("a")),
+			)))))
+
+	if err := ApplyFlavorPlacement(template, flavor); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	spec := template.Spec
+	assert.Len(t, spec.Tolerations, 1)
+	assert.Equal(t, "dedicated", *spec.Tolerations[0].Key)
+	assert.Equal(t, "inference", *spec.Tolerations[0].Value)
+
+	terms := spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	assert.Len(t, terms, 2)
+	for i, pool := range []string{"l4", "l4-spot"} {
+		var keys, values []string
+		for _, r := range terms[i].MatchExpressions {
+			keys = append(keys, *r.Key)
+			values = append(values, r.Values...)
+		}
+		assert.Equal(t, []string{"zone", "pool", corev1.LabelInstanceTypeStable}, keys)
+		assert.Equal(t, []string{"a", pool, "g6.12xlarge"}, values)
+	}
+
+	assert.Len(t, spec.Affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution, 1)
+	assert.Equal(t, int32(10), *spec.Affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution[0].Weight)
+	assert.Len(t, spec.Affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution, 1)
+	assert.Len(t, spec.TopologySpreadConstraints, 1)
+	assert.Equal(t, corev1.LabelTopologyZone, *spec.TopologySpreadConstraints[0].TopologyKey)
+}
+
+func TestAcceleratorCount(t *testing.T) {
+	tests := []struct {
+		name   string
+		flavor *coreapi.Flavor
+		want   int64
+	}{
+		{
+			name: "nil flavor",
+			want: 0,
+		},
+		{
+			name:   "flavor with gpus",
+			flavor: wrapper.MakeFlavor("a100").SetRequest("nvidia.com/gpu", "8").SetRequest("cpu", "16").SetRequest("memory", "64Gi").Obj(),
+			want:   8,
+		},
+		{
+			name:   "flavor without accelerators",
+			flavor: wrapper.MakeFlavor("cpu").SetRequest("cpu", "16").Obj(),
+			want:   0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, AcceleratorCount(tt.flavor))
+		})
+	}
+}
+
+func TestFlavorTolerations(t *testing.T) {
+	ssdToleration := corev1.Toleration{Key: "disk", Operator: corev1.TolerationOpEqual, Value: "ssd", Effect: corev1.TaintEffectNoSchedule}
+	gpuFlavor := wrapper.MakeFlavor("a100").SetRequest("nvidia.com/gpu", "8").SetRequest("cpu", "16").Obj()
+	gpuFlavor.Tolerations = []corev1.Toleration{ssdToleration}
+
+	tests := []struct {
+		name   string
+		flavor *coreapi.Flavor
+		want   []corev1.Toleration
+	}{
+		{
+			name:   "flavor without tolerations",
+			flavor: wrapper.MakeFlavor("cpu").SetRequest("cpu", "16").Obj(),
+			want:   nil,
+		},
+		{
+			name:   "flavor with accelerators",
+			flavor: gpuFlavor,
+			want: []corev1.Toleration{
+				ssdToleration,
+				{Key: "nvidia.com/gpu", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, FlavorTolerations(tt.flavor))
+		})
+	}
+	assert.Len(t, gpuFlavor.Tolerations, 1, "the tolerations of the flavor should not be modified")
+}
+
+func TestNodeMatchesFlavor(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node",
+			Labels: map[string]string{
+				"gpu-type":                     "a100",
+				corev1.LabelInstanceTypeStable: "p4d.24xlarge",
+			},
+		},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("8")},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		flavor *coreapi.Flavor
+		want   bool
+	}{
+		{
+			name:   "flavor without constraints",
+			flavor: wrapper.MakeFlavor("cpu").Obj(),
+			want:   true,
+		},
+		{
+			name:   "nodeSelector matched",
+			flavor: wrapper.MakeFlavor("a100").SetNodeSelector("gpu-type", "a100").SetRequest("nvidia.com/gpu", "8").Obj(),
+			want:   true,
+		},
+		{
+			name:   "nodeSelector mismatched",
+			flavor: wrapper.MakeFlavor("h100").SetNodeSelector("gpu-type", "h100").Obj(),
+		},
+		{
+			name:   "instance type matched",
+			flavor: wrapper.MakeFlavor("a100").SetParams(FlavorParamInstanceType, "p4d.24xlarge,p4de.24xlarge").Obj(),
+			want:   true,
+		},
+		{
+			name:   "instance type mismatched",
+			flavor: wrapper.MakeFlavor("h100").SetParams(FlavorParamInstanceType, "p5.48xlarge").Obj(),
+		},
+		{
+			name:   "accelerator not allocatable",
+			flavor: wrapper.MakeFlavor("ascend").SetRequest("huawei.com/npu", "8").Obj(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NodeMatchesFlavor(node, tt.flavor))
+		})
+	}
+}
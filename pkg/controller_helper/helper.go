@@ -18,18 +18,26 @@ package helper
 
 import (
 	"context"
+	"slices"
+	"time"
 
 	coreapi "github.com/inftyai/llmaz/api/core/v1alpha1"
 	inferenceapi "github.com/inftyai/llmaz/api/inference/v1alpha1"
+	"github.com/inftyai/llmaz/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-// These two modes are preset.
+// These modes are preset.
 const (
 	DefaultArg             string = "default"
 	SpeculativeDecodingArg string = "speculative-decoding"
+	LoraArg                string = "lora"
+	DynamicLoraArg         string = "dynamic-lora"
 )
 
 func RecommendedConfigName(playground *inferenceapi.Playground) string {
@@ -51,10 +59,23 @@ func DetectArgFrom(playground *inferenceapi.Playground) string {
 	}
 
 	if playground.Spec.ModelClaims != nil {
+		var lora bool
 		for _, mr := range playground.Spec.ModelClaims.Models {
+			if mr.Role == nil {
+				continue
+			}
 			if *mr.Role == coreapi.DraftRole {
 				return SpeculativeDecodingArg
 			}
+			if *mr.Role == coreapi.LoraRole {
+				lora = true
+			}
+		}
+		if DynamicLoRAEnabled(playground) {
+			return DynamicLoraArg
+		}
+		if lora {
+			return LoraArg
 		}
 	}
 
@@ -62,33 +83,57 @@ func DetectArgFrom(playground *inferenceapi.Playground) string {
 	return DefaultArg
 }
 
+// DynamicLoRAEnabled returns whether the lora models of the playground are loaded
+// at runtime rather than rendered into the pod template.
+func DynamicLoRAEnabled(playground *inferenceapi.Playground) bool {
+	return playground.Spec.LoRAConfig != nil && playground.Spec.LoRAConfig.DynamicLoading != nil && *playground.Spec.LoRAConfig.DynamicLoading
+}
+
 func FetchModelsByService(ctx context.Context, k8sClient client.Client, service *inferenceapi.Service) (models []*coreapi.OpenModel, err error) {
-	return fetchModels(ctx, k8sClient, service.Spec.ModelClaims.Models)
+	return fetchModels(ctx, k8sClient, service.Namespace, service.Spec.ModelClaims.Models)
 }
 
 func FetchModelsByPlayground(ctx context.Context, k8sClient client.Client, playground *inferenceapi.Playground) (models []*coreapi.OpenModel, err error) {
-	mainRole := coreapi.MainRole
-	mrs := []coreapi.ModelRef{}
+	return fetchModels(ctx, k8sClient, playground.Namespace, ModelRefsByPlayground(playground))
+}
 
+// ModelRefsByPlayground returns the model references of the playground,
+// a single modelClaim is regarded as the main model.
+func ModelRefsByPlayground(playground *inferenceapi.Playground) []coreapi.ModelRef {
 	if playground.Spec.ModelClaim != nil {
-		mrs = append(mrs, coreapi.ModelRef{Name: playground.Spec.ModelClaim.ModelName, Role: &mainRole})
-	} else {
-		mrs = playground.Spec.ModelClaims.Models
+		mainRole := coreapi.MainRole
+		return []coreapi.ModelRef{{Name: playground.Spec.ModelClaim.ModelName, Role: &mainRole}}
 	}
+	if playground.Spec.ModelClaims != nil {
+		return playground.Spec.ModelClaims.Models
+	}
+	return nil
+}
 
-	return fetchModels(ctx, k8sClient, mrs)
+// ModelRoles returns the roles of the referenced models indexed by model name,
+// a model without role is regarded as the main model.
+func ModelRoles(mrs []coreapi.ModelRef) map[coreapi.ModelName]coreapi.ModelRole {
+	roles := make(map[coreapi.ModelName]coreapi.ModelRole, len(mrs))
+	for _, mr := range mrs {
+		if mr.Role == nil {
+			roles[mr.Name] = coreapi.MainRole
+		} else {
+			roles[mr.Name] = *mr.Role
+		}
+	}
+	return roles
 }
 
-func fetchModels(ctx context.Context, k8sClient client.Client, mrs []coreapi.ModelRef) (models []*coreapi.OpenModel, err error) {
+func fetchModels(ctx context.Context, k8sClient client.Client, namespace string, mrs []coreapi.ModelRef) (models []*coreapi.OpenModel, err error) {
 	for _, mr := range mrs {
-		model := &coreapi.OpenModel{}
-		if err := k8sClient.Get(ctx, types.NamespacedName{Name: string(mr.Name)}, model); err != nil {
+		model, err := FetchModel(ctx, k8sClient, namespace, mr.Name)
+		if err != nil {
 			return nil, err
 		}
 		// Make sure the main model is always the 0-index model.
 		// We only have one main model right now, if this changes,
 		// the logic may also change here.
-		if *mr.Role == coreapi.MainRole {
+		if mr.Role == nil || *mr.Role == coreapi.MainRole {
 			models = append([]*coreapi.OpenModel{model}, models...)
 		} else {
 			models = append(models, model)
@@ -98,6 +143,36 @@ func fetchModels(ctx context.Context, k8sClient client.Client, mrs []coreapi.Mod
 	return models, nil
 }
 
+// FetchModel resolves the model referred in the namespace, the namespaced Model is
+// preferred, then the cluster scoped OpenModel with the same name.
+func FetchModel(ctx context.Context, k8sClient client.Client, namespace string, name coreapi.ModelName) (*coreapi.OpenModel, error) {
+	model := &coreapi.Model{}
+	err := k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: string(name)}, model)
+	if err == nil {
+		return OpenModelFrom(model), nil
+	}
+	// The Model CRD may not be installed yet once upgraded.
+	if !apierrors.IsNotFound(err) && !apimeta.IsNoMatchError(err) {
+		return nil, err
+	}
+
+	openModel := &coreapi.OpenModel{}
+	if err := k8sClient.Get(ctx, types.NamespacedName{Name: string(name)}, openModel); err != nil {
+		return nil, err
+	}
+	return openModel, nil
+}
+
+// OpenModelFrom converts the namespaced Model to the OpenModel consumed by the
+// controllers, the namespace is kept to tell them apart.
+func OpenModelFrom(model *coreapi.Model) *coreapi.OpenModel {
+	return &coreapi.OpenModel{
+		ObjectMeta: *model.ObjectMeta.DeepCopy(),
+		Spec:       *model.Spec.DeepCopy(),
+		Status:     *model.Status.DeepCopy(),
+	}
+}
+
 // FirstAssignedFlavor will return the first assigned flavor of the model.
 func FirstAssignedFlavor(model *coreapi.OpenModel, playground *inferenceapi.Playground) []coreapi.Flavor {
 	var flavors []coreapi.FlavorName
@@ -130,3 +205,107 @@ func SkipModelLoader(obj metav1.Object) bool {
 	}
 	return false
 }
+
+// ScaleToZeroEnabled returns whether the workloads are allowed to be scaled to zero
+// and activated by the service activator.
+func ScaleToZeroEnabled(config *inferenceapi.ScaleToZero) bool {
+	return config != nil && (config.Enabled == nil || *config.Enabled)
+}
+
+// WarmupReplicas returns the minimum replicas required by the warm-up schedules active at now,
+// and the duration after which the result may change, zero duration means nothing scheduled.
+// Malformed schedules will be ignored, they're supposed to be rejected by the webhook.
+func WarmupReplicas(playground *inferenceapi.Playground, now time.Time) (replicas int32, requeueAfter time.Duration) {
+	if playground.Spec.ElasticConfig == nil {
+		return 0, 0
+	}
+
+	for _, warmup := range playground.Spec.ElasticConfig.WarmupSchedules {
+		schedule, err := util.ParseCron(warmup.Schedule)
+		if err != nil {
+			continue
+		}
+		loc := time.UTC
+		if warmup.TimeZone != nil {
+			if loc, err = time.LoadLocation(*warmup.TimeZone); err != nil {
+				continue
+			}
+		}
+
+		var changeAt time.Time
+		current := now.In(loc)
+		// The window is active if it started within the last duration.
+		if start := schedule.Next(current.Add(-warmup.Duration.Duration)); !start.IsZero() && !start.After(current) {
+			target := int32(1)
+			if warmup.Replicas != nil {
+				target = *warmup.Replicas
+			}
+			replicas = max(replicas, target)
+			changeAt = start.Add(warmup.Duration.Duration)
+		} else if changeAt = schedule.Next(current); changeAt.IsZero() {
+			continue
+		}
+
+		if after := changeAt.Sub(now); requeueAfter == 0 || after < requeueAfter {
+			requeueAfter = after
+		}
+	}
+	return replicas, requeueAfter
+}
+
+// FlavorCandidates returns the flavor names of the model in the order of preference,
+// the flavors claimed explicitly take precedence over the ones defined in the model.
+func FlavorCandidates(model *coreapi.OpenModel, claimed []coreapi.FlavorName) []coreapi.FlavorName {
+	if len(claimed) > 0 {
+		return claimed
+	}
+	if model.Spec.InferenceConfig == nil {
+		return nil
+	}
+
+	names := make([]coreapi.FlavorName, 0, len(model.Spec.InferenceConfig.Flavors))
+	for _, flavor := range model.Spec.InferenceConfig.Flavors {
+		names = append(names, flavor.Name)
+	}
+	return names
+}
+
+// ResolveFlavor returns the flavor of the model to apply, current is the flavor applied right now
+// which takes precedence once it's still a candidate, otherwise the most preferred one is returned.
+func ResolveFlavor(model *coreapi.OpenModel, claimed []coreapi.FlavorName, current coreapi.FlavorName) *coreapi.Flavor {
+	candidates := FlavorCandidates(model, claimed)
+	if len(candidates) == 0 || model.Spec.InferenceConfig == nil {
+		return nil
+	}
+
+	name := candidates[0]
+	if current != "" && slices.Contains(candidates, current) {
+		name = current
+	}
+	for i := range model.Spec.InferenceConfig.Flavors {
+		if model.Spec.InferenceConfig.Flavors[i].Name == name {
+			return &model.Spec.InferenceConfig.Flavors[i]
+		}
+	}
+	return nil
+}
+
+// UnschedulableSince returns the earliest time since which any of the pods stays unschedulable,
+// pods created before the given time are ignored, nil means no unschedulable pods found.
+func UnschedulableSince(pods []corev1.Pod, createdAfter time.Time) *time.Time {
+	var since *time.Time
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil || pod.CreationTimestamp.Time.Before(createdAfter) {
+			continue
+		}
+		for _, cond := range pod.Status.Conditions {
+			if cond.Type != corev1.PodScheduled || cond.Status != corev1.ConditionFalse || cond.Reason != corev1.PodReasonUnschedulable {
+				continue
+			}
+			if t := cond.LastTransitionTime.Time; since == nil || t.Before(*since) {
+				since = &t
+			}
+		}
+	}
+	return since
+}
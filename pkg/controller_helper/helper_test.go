@@ -0,0 +1,291 @@
+/*
+Copyright 2025 The InftyAI Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	coreapi "github.com/inftyai/llmaz/api/core/v1alpha1"
+	inferenceapi "github.com/inftyai/llmaz/api/inference/v1alpha1"
+	"github.com/inftyai/llmaz/test/util/wrapper"
+)
+
+func TestWarmupReplicas(t *testing.T) {
+	// 2025-01-06 is Monday.
+	now := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name             string
+		playground       *inferenceapi.Playground
+		wantReplicas     int32
+		wantRequeueAfter time.Duration
+	}{
+		{
+			name:             "no schedules",
+			playground:       wrapper.MakePlayground("playground", "default").Obj(),
+			wantReplicas:     0,
+			wantRequeueAfter: 0,
+		},
+		{
+			name:             "inside the window",
+			playground:       wrapper.MakePlayground("playground", "default").WarmupSchedule("0 8 * * 1-5", "", "10h", 2).Obj(),
+			wantReplicas:     2,
+			wantRequeueAfter: 9 * time.Hour,
+		},
+		{
+			name:             "before the window",
+			playground:       wrapper.MakePlayground("playground", "default").WarmupSchedule("30 9 * * 1-5", "", "1h", 2).Obj(),
+			wantReplicas:     0,
+			wantRequeueAfter: 30 * time.Minute,
+		},
+		{
+			name:             "after the window",
+			playground:       wrapper.MakePlayground("playground", "default").WarmupSchedule("0 7 * * *", "", "1h", 2).Obj(),
+			wantReplicas:     0,
+			wantRequeueAfter: 22 * time.Hour,
+		},
+		{
+			name: "overlapped windows",
+			playground: wrapper.MakePlayground("playground", "default").
+				WarmupSchedule("0 8 * * *", "", "4h", 2).
+				WarmupSchedule("0 6 * * *", "", "4h", 3).
+				Obj(),
+			wantReplicas:     3,
+			wantRequeueAfter: time.Hour,
+		},
+		{
+			name:             "with time zone",
+			playground:       wrapper.MakePlayground("playground", "default").WarmupSchedule("0 16 * * *", "Asia/Shanghai", "2h", 1).Obj(),
+			wantReplicas:     1,
+			wantRequeueAfter: time.Hour,
+		},
+		{
+			name:             "malformed schedule is ignored",
+			playground:       wrapper.MakePlayground("playground", "default").WarmupSchedule("0 8 * *", "", "10h", 2).Obj(),
+			wantReplicas:     0,
+			wantRequeueAfter: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			replicas, requeueAfter := WarmupReplicas(tt.playground, now)
+			assert.Equal(t, tt.wantReplicas, replicas)
+			assert.Equal(t, tt.wantRequeueAfter, requeueAfter)
+		})
+	}
+}
+
+func TestDetectArgFrom(t *testing.T) {
+	tests := []struct {
+		name       string
+		playground *inferenceapi.Playground
+		want       string
+	}{
+		{
+			name:       "single model claim",
+			playground: wrapper.MakePlayground("llama3", corev1.NamespaceDefault).ModelClaim("llama3-8b").Obj(),
+			want:       DefaultArg,
+		},
+		{
+			name:       "draft model",
+			playground: wrapper.MakePlayground("llama3", corev1.NamespaceDefault).ModelClaims([]string{"llama3-8b", "llama3-1b"}, []string{"main", "draft"}).Obj(),
+			want:       SpeculativeDecodingArg,
+		},
+		{
+			name:       "lora models",
+			playground: wrapper.MakePlayground("llama3", corev1.NamespaceDefault).ModelClaims([]string{"llama3-8b", "llama3-sql", "llama3-chat"}, []string{"main", "lora", "lora"}).Obj(),
+			want:       LoraArg,
+		},
+		{
+			name:       "lora models loaded dynamically",
+			playground: wrapper.MakePlayground("llama3", corev1.NamespaceDefault).ModelClaims([]string{"llama3-8b", "llama3-sql"}, []string{"main", "lora"}).DynamicLoRA(true).Obj(),
+			want:       DynamicLoraArg,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, DetectArgFrom(tt.playground))
+		})
+	}
+}
+
+func TestFlavorCandidates(t *testing.T) {
+	model := wrapper.MakeModel("llama3-8b").InferenceFlavors(
+		*wrapper.MakeFlavor("a100").SetRequest("nvidia.com/gpu", "1").Obj(),
+		*wrapper.MakeFlavor("a10").SetRequest("nvidia.com/gpu", "2").Obj(),
+	).Obj()
+
+	tests := []struct {
+		name    string
+		model   *coreapi.OpenModel
+		claimed []coreapi.FlavorName
+		want    []coreapi.FlavorName
+	}{
+		{
+			name:  "flavors of the model",
+			model: model,
+			want:  []coreapi.FlavorName{"a100", "a10"},
+		},
+		{
+			name:    "claimed flavors take precedence",
+			model:   model,
+			claimed: []coreapi.FlavorName{"a10", "a100"},
+			want:    []coreapi.FlavorName{"a10", "a100"},
+		},
+		{
+			name:  "model without flavors",
+			model: wrapper.MakeModel("llama3-8b").Obj(),
+			want:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, FlavorCandidates(tt.model, tt.claimed))
+		})
+	}
+}
+
+func TestUnschedulableSince(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	makePod := func(created time.Time, conditions ...corev1.PodCondition) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created)},
+			Status:     corev1.PodStatus{Conditions: conditions},
+		}
+	}
+	unschedulable := func(since time.Time) corev1.PodCondition {
+		return corev1.PodCondition{
+			Type:               corev1.PodScheduled,
+			Status:             corev1.ConditionFalse,
+			Reason:             corev1.PodReasonUnschedulable,
+			LastTransitionTime: metav1.NewTime(since),
+		}
+	}
+	scheduled := corev1.PodCondition{Type: corev1.PodScheduled, Status: corev1.ConditionTrue}
+
+	tests := []struct {
+		name         string
+		pods         []corev1.Pod
+		createdAfter time.Time
+		want         *time.Time
+	}{
+		{
+			name: "all pods scheduled",
+			pods: []corev1.Pod{makePod(now, scheduled)},
+		},
+		{
+			name: "the earliest unschedulable time",
+			pods: []corev1.Pod{
+				makePod(now.Add(-time.Hour), unschedulable(now.Add(-10*time.Minute))),
+				makePod(now.Add(-time.Hour), unschedulable(now.Add(-20*time.Minute))),
+				makePod(now.Add(-time.Hour), scheduled),
+			},
+			want: ptr.To(now.Add(-20 * time.Minute)),
+		},
+		{
+			name: "pods created before are ignored",
+			pods: []corev1.Pod{
+				makePod(now.Add(-time.Hour), unschedulable(now.Add(-20*time.Minute))),
+				makePod(now.Add(-time.Minute), unschedulable(now.Add(-time.Minute))),
+			},
+			createdAfter: now.Add(-30 * time.Minute),
+			want:         ptr.To(now.Add(-time.Minute)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := UnschedulableSince(tt.pods, tt.createdAfter)
+			if tt.want == nil {
+				assert.Nil(t, got)
+				return
+			}
+			assert.NotNil(t, got)
+			assert.True(t, tt.want.Equal(*got), "want %v, got %v", tt.want, got)
+		})
+	}
+}
+
+func TestResolveFlavor(t *testing.T) {
+	model := wrapper.MakeModel("llama3-8b").InferenceFlavors(
+		*wrapper.MakeFlavor("a100").SetRequest("nvidia.com/gpu", "1").Obj(),
+		*wrapper.MakeFlavor("a10").SetRequest("nvidia.com/gpu", "2").Obj(),
+	).Obj()
+
+	tests := []struct {
+		name    string
+		model   *coreapi.OpenModel
+		claimed []coreapi.FlavorName
+		current coreapi.FlavorName
+		want    coreapi.FlavorName
+	}{
+		{
+			name:  "the most preferred flavor",
+			model: model,
+			want:  "a100",
+		},
+		{
+			name:    "the current flavor",
+			model:   model,
+			current: "a10",
+			want:    "a10",
+		},
+		{
+			name:    "the current flavor is not a candidate",
+			model:   model,
+			claimed: []coreapi.FlavorName{"a100"},
+			current: "a10",
+			want:    "a100",
+		},
+		{
+			name:  "model without flavors",
+			model: wrapper.MakeModel("llama3-8b").Obj(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flavor := ResolveFlavor(tt.model, tt.claimed, tt.current)
+			if tt.want == "" {
+				assert.Nil(t, flavor)
+				return
+			}
+			assert.Equal(t, tt.want, flavor.Name)
+		})
+	}
+}
+
+func TestOpenModelFrom(t *testing.T) {
+	model := wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithURI("s3://bucket/llama3-8b").NamespacedObj("team-a")
+
+	openModel := OpenModelFrom(model)
+	assert.Equal(t, "team-a", openModel.Namespace)
+	assert.Equal(t, model.Spec, openModel.Spec)
+
+	// The converted model should not share the fields with the origin one.
+	openModel.Spec.FamilyName = "qwen2"
+	assert.Equal(t, coreapi.ModelName("llama3"), model.Spec.FamilyName)
+}
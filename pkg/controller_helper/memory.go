@@ -0,0 +1,144 @@
+/*
+Copyright 2025 The InftyAI Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helper
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	coreapi "github.com/inftyai/llmaz/api/core/v1alpha1"
+)
+
+const (
+	// kvCacheBytesFactor estimates the KV cache bytes per token as kvCacheBytesFactor * sqrt(parameters),
+	// which is fitted to the popular models with grouped-query attention in 16-bit, e.g. 128KiB for llama3-8b.
+	kvCacheBytesFactor = 1.5
+	// memoryOverheadPercent reserves the accelerator memory for activations and the runtime.
+	memoryOverheadPercent = 10
+	// bytesPerParameter estimates the parameters from the weight size when unknown.
+	bytesPerParameter = 2
+)
+
+// ModelWeightSize returns the weight size of the model from the size annotation,
+// or the metadata discovered from the model source, nil if unknown.
+func ModelWeightSize(model *coreapi.OpenModel) *resource.Quantity {
+	if value, ok := model.Annotations[coreapi.ModelSizeAnnoKey]; ok {
+		size, err := resource.ParseQuantity(value)
+		if err != nil {
+			return nil
+		}
+		return &size
+	}
+	if model.Status.Metadata != nil {
+		return model.Status.Metadata.WeightSize
+	}
+	return nil
+}
+
+// EstimateAcceleratorMemory estimates the accelerator memory required to serve the model,
+// including the weights and the KV cache of one sequence with the full context length,
+// which is the least the inference engines require to start. Nil if the weight size is unknown.
+func EstimateAcceleratorMemory(model *coreapi.OpenModel) *resource.Quantity {
+	weightSize := ModelWeightSize(model)
+	if weightSize == nil {
+		return nil
+	}
+	required := weightSize.Value()
+
+	if metadata := model.Status.Metadata; metadata != nil && metadata.ContextLength != nil {
+		parameters := required / bytesPerParameter
+		if metadata.ParameterCount != nil {
+			parameters = *metadata.ParameterCount
+		}
+		bytesPerToken := int64(kvCacheBytesFactor * math.Sqrt(float64(parameters)))
+		required += bytesPerToken * *metadata.ContextLength
+	}
+
+	required += required * memoryOverheadPercent / 100
+	return resource.NewQuantity(required, resource.BinarySI)
+}
+
+// FlavorAcceleratorMemory returns the total accelerator memory of the flavor, counting
+// all the pods of multi-node inference. Nil if the ACCELERATOR-MEMORY param is not set.
+func FlavorAcceleratorMemory(flavor *coreapi.Flavor) *resource.Quantity {
+	value, ok := flavor.Params[FlavorParamAcceleratorMemory]
+	if !ok {
+		return nil
+	}
+	perAccelerator, err := resource.ParseQuantity(value)
+	if err != nil {
+		return nil
+	}
+
+	count := AcceleratorCount(flavor)
+	if pp, err := strconv.ParseInt(flavor.Params[FlavorParamPP], 10, 64); err == nil && pp > 1 {
+		count *= pp
+	}
+	return resource.NewQuantity(perAccelerator.Value()*count, resource.BinarySI)
+}
+
+// FlavorFits returns whether the flavor provides enough accelerator memory for the model,
+// true if either the required or the provided memory is unknown.
+func FlavorFits(model *coreapi.OpenModel, flavor *coreapi.Flavor) bool {
+	required, provided := EstimateAcceleratorMemory(model), FlavorAcceleratorMemory(flavor)
+	if required == nil || provided == nil {
+		return true
+	}
+	return provided.Cmp(*required) >= 0
+}
+
+// RecommendFlavors returns the flavors of the model which are known to fit the model,
+// sorted by the accelerator memory from the smallest to the largest, followed by the flavors
+// with the accelerator memory unknown which may fit as well. Nil if the required memory of
+// the model is unknown or none of the flavors may fit.
+func RecommendFlavors(model *coreapi.OpenModel) []coreapi.FlavorName {
+	required := EstimateAcceleratorMemory(model)
+	if required == nil || model.Spec.InferenceConfig == nil {
+		return nil
+	}
+
+	type candidate struct {
+		name   coreapi.FlavorName
+		memory int64
+	}
+	var candidates []candidate
+	var unknown []coreapi.FlavorName
+	for i := range model.Spec.InferenceConfig.Flavors {
+		flavor := &model.Spec.InferenceConfig.Flavors[i]
+		provided := FlavorAcceleratorMemory(flavor)
+		if provided == nil {
+			unknown = append(unknown, flavor.Name)
+			continue
+		}
+		if provided.Cmp(*required) < 0 {
+			continue
+		}
+		candidates = append(candidates, candidate{name: flavor.Name, memory: provided.Value()})
+	}
+	// Stable to respect the order of the flavors with the same memory.
+	slices.SortStableFunc(candidates, func(a, b candidate) int { return cmp.Compare(a.memory, b.memory) })
+
+	var names []coreapi.FlavorName
+	for _, c := range candidates {
+		names = append(names, c.name)
+	}
+	return append(names, unknown...)
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2025 The InftyAI Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+
+	coreapi "github.com/inftyai/llmaz/api/core/v1alpha1"
+	"github.com/inftyai/llmaz/test/util/wrapper"
+)
+
+func TestEstimateAcceleratorMemory(t *testing.T) {
+	tests := []struct {
+		name       string
+		annotation string
+		metadata   *coreapi.ModelMetadata
+		want       *resource.Quantity
+	}{
+		{
+			name: "unknown weight size",
+		},
+		{
+			name:       "weights only",
+			annotation: "10Gi",
+			want:       ptr.To(resource.MustParse("11Gi")),
+		},
+		{
+			name: "weights and kv cache",
+			metadata: &coreapi.ModelMetadata{
+				WeightSize:     ptr.To(resource.MustParse("15Gi")),
+				ParameterCount: ptr.To[int64](8_000_000_000),
+				ContextLength:  ptr.To[int64](8192),
+			},
+			// (15Gi + int64(1.5 * sqrt(8e9)) * 8192) * 1.1
+			want: resource.NewQuantity((15<<30+134164*8192)*11/10, resource.BinarySI),
+		},
+		{
+			name: "parameters estimated from the weight size",
+			metadata: &coreapi.ModelMetadata{
+				WeightSize:    resource.NewQuantity(16_000_000_000, resource.BinarySI),
+				ContextLength: ptr.To[int64](8192),
+			},
+			want: resource.NewQuantity((16_000_000_000+134164*8192)*11/10, resource.BinarySI),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := wrapper.MakeModel("llama3-8b").FamilyName("llama3").Obj()
+			if tt.annotation != "" {
+				model.Annotations = map[string]string{coreapi.ModelSizeAnnoKey: tt.annotation}
+			}
+			model.Status.Metadata = tt.metadata
+
+			got := EstimateAcceleratorMemory(model)
+			if tt.want == nil {
+				assert.Nil(t, got)
+				return
+			}
+			assert.Equal(t, tt.want.Value(), got.Value())
+		})
+	}
+}
+
+func TestFlavorAcceleratorMemory(t *testing.T) {
+	tests := []struct {
+		name   string
+		flavor *coreapi.Flavor
+		want   *resource.Quantity
+	}{
+		{
+			name:   "memory unknown",
+			flavor: wrapper.MakeFlavor("a100").SetRequest("nvidia.com/gpu", "8").Obj(),
+		},
+		{
+			name:   "multiple accelerators",
+			flavor: wrapper.MakeFlavor("a100").SetRequest("nvidia.com/gpu", "8").SetParams(FlavorParamAcceleratorMemory, "80Gi").Obj(),
+			want:   ptr.To(resource.MustParse("640Gi")),
+		},
+		{
+			name:   "multiple nodes",
+			flavor: wrapper.MakeFlavor("a100").SetRequest("nvidia.com/gpu", "8").SetParams(FlavorParamAcceleratorMemory, "80Gi").SetParams(FlavorParamPP, "2").Obj(),
+			want:   ptr.To(resource.MustParse("1280Gi")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FlavorAcceleratorMemory(tt.flavor)
+			if tt.want == nil {
+				assert.Nil(t, got)
+				return
+			}
+			assert.Equal(t, tt.want.Value(), got.Value())
+		})
+	}
+}
+
+func TestRecommendFlavors(t *testing.T) {
+	flavors := []coreapi.Flavor{
+		*wrapper.MakeFlavor("a10").SetRequest("nvidia.com/gpu", "1").SetParams(FlavorParamAcceleratorMemory, "24Gi").Obj(),
+		*wrapper.MakeFlavor("h100").SetRequest("nvidia.com/gpu", "1").SetParams(FlavorParamAcceleratorMemory, "80Gi").Obj(),
+		*wrapper.MakeFlavor("unknown").SetRequest("nvidia.com/gpu", "1").Obj(),
+		*wrapper.MakeFlavor("a100").SetRequest("nvidia.com/gpu", "1").SetParams(FlavorParamAcceleratorMemory, "40Gi").Obj(),
+	}
+
+	tests := []struct {
+		name      string
+		modelSize string
+		want      []coreapi.FlavorName
+		wantFits  map[coreapi.FlavorName]bool
+	}{
+		{
+			name:     "model size unknown",
+			wantFits: map[coreapi.FlavorName]bool{"a10": true, "h100": true, "unknown": true, "a100": true},
+		},
+		{
+			name:      "small model fits all the flavors",
+			modelSize: "1Gi",
+			want:      []coreapi.FlavorName{"a10", "a100", "h100", "unknown"},
+			wantFits:  map[coreapi.FlavorName]bool{"a10": true, "h100": true, "unknown": true, "a100": true},
+		},
+		{
+			name:      "large model",
+			modelSize: "30Gi",
+			want:      []coreapi.FlavorName{"a100", "h100", "unknown"},
+			wantFits:  map[coreapi.FlavorName]bool{"a10": false, "h100": true, "unknown": true, "a100": true},
+		},
+		{
+			name:      "no flavor fits",
+			modelSize: "100Gi",
+			want:      []coreapi.FlavorName{"unknown"},
+			wantFits:  map[coreapi.FlavorName]bool{"a10": false, "h100": false, "unknown": true, "a100": false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := wrapper.MakeModel("llama3").FamilyName("llama3").InferenceFlavors(flavors...).Obj()
+			if tt.modelSize != "" {
+				model.Annotations = map[string]string{coreapi.ModelSizeAnnoKey: tt.modelSize}
+			}
+
+			assert.Equal(t, tt.want, RecommendFlavors(model))
+			for i := range flavors {
+				assert.Equal(t, tt.wantFits[flavors[i].Name], FlavorFits(model, &flavors[i]), "flavor %s", flavors[i].Name)
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2025 The InftyAI Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modelSource
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	coreapplyv1 "k8s.io/client-go/applyconfigurations/core/v1"
+	"k8s.io/utils/ptr"
+
+	coreapi "github.com/inftyai/llmaz/api/core/v1alpha1"
+	"github.com/inftyai/llmaz/pkg/util"
+)
+
+const (
+	MODEL_CACHE_VOLUME_NAME = "model-cache"
+	MODEL_CACHE_NAME_PREFIX = "model-cache-"
+
+	PREHEATED_NODE_AFFINITY_WEIGHT = 100
+)
+
+// ModelCacheName returns the name of the PersistentVolumeClaim and the Job caching the model.
+func ModelCacheName(modelName string) string {
+	return MODEL_CACHE_NAME_PREFIX + modelName
+}
+
+// ModelCacheHostPath returns the host path caching the model on each node.
+func ModelCacheHostPath(modelName string) string {
+	return HOST_CLUSTER_MODEL_PATH + modelName
+}
+
+// ModelCacheEnabled returns whether the model cache is enabled.
+func ModelCacheEnabled(model *coreapi.OpenModel) bool {
+	return model.Spec.Cache != nil
+}
+
+// ModelCacheType returns the type of the model cache, default to PersistentVolume.
+func ModelCacheType(model *coreapi.OpenModel) coreapi.ModelCacheType {
+	if model.Spec.Cache == nil || model.Spec.Cache.Type == "" {
+		return coreapi.PersistentVolumeModelCache
+	}
+	return model.Spec.Cache.Type
+}
+
+// ModelPreheatEnabled returns whether the model is supposed to be preheated on the nodes.
+func ModelPreheatEnabled(model *coreapi.OpenModel) bool {
+	return model.Annotations[coreapi.ModelPreheatAnnoKey] == "true"
+}
+
+// ModelPreheatedLabelKey returns the label key of the nodes the model is preheated on,
+// the model name is shortened to fit in the name part of the label key.
+func ModelPreheatedLabelKey(modelName string) string {
+	return coreapi.ModelPreheatedLabelKeyPrefix + util.ShortName(modelName, validation.DNS1123LabelMaxLength)
+}
+
+// ModelCachedOnHost returns whether the model is cached in the host path of the nodes,
+// either with the HostPath cache or preheated, and not cached in the PersistentVolume.
+func ModelCachedOnHost(model *coreapi.OpenModel) bool {
+	if ModelCacheEnabled(model) {
+		return ModelCacheType(model) == coreapi.HostPathModelCache
+	}
+	return ModelPreheatEnabled(model)
+}
+
+// SourceHash returns the hash of the model source, which changes once the model
+// should be downloaded again.
+func SourceHash(model *coreapi.OpenModel) string {
+	data, _ := json.Marshal(model.Spec.Source)
+	hasher := fnv.New32a()
+	_, _ = hasher.Write(data)
+	return fmt.Sprint(hasher.Sum32())
+}
+
+// ModelCacheSupported returns whether the model could be cached, the host paths and
+// the shared storages are mounted in place and the ollama models are loaded by the runtime itself.
+func ModelCacheSupported(model *coreapi.OpenModel) bool {
+	if model.Spec.Source.URI == nil {
+		return true
+	}
+	source, err := NewModelSourceProvider(model)
+	if err != nil {
+		return false
+	}
+	provider, ok := source.(*URIProvider)
+	return !ok || (provider.protocol != HostPath && provider.protocol != Ollama && !provider.sharedStorage())
+}
+
+// ModelCachedInVolume returns whether the model is downloaded into the PersistentVolumeClaim,
+// so the workloads can mount it without loading the model again.
+func ModelCachedInVolume(model *coreapi.OpenModel) bool {
+	return ModelCacheEnabled(model) && ModelCacheType(model) == coreapi.PersistentVolumeModelCache &&
+		model.Status.Cache != nil && model.Status.Cache.Phase == coreapi.ModelCacheReady
+}
+
+// InjectModelCache mounts the model cache to the model-runner container read-only at the model path.
+// For the HostPath cache or the preheated models, the model loader at the index downloads the model into the host path
+// instead of the model-volume, the files downloaded already on the node will be skipped.
+// For the PersistentVolume cache, the claim named by ModelCacheName is supposed to exist in the
+// namespace of the template.
+func InjectModelCache(template *coreapplyv1.PodTemplateSpecApplyConfiguration, model *coreapi.OpenModel, index int) error {
+	source, err := NewModelSourceProvider(model)
+	if err != nil {
+		return err
+	}
+
+	volumeName := MODEL_CACHE_VOLUME_NAME
+	loaderName := MODEL_LOADER_CONTAINER_NAME
+	if index != 0 {
+		volumeName += "-" + strconv.Itoa(index)
+		loaderName += "-" + strconv.Itoa(index)
+	}
+
+	volume := coreapplyv1.Volume().WithName(volumeName)
+	if ModelCachedOnHost(model) {
+		volume.WithHostPath(coreapplyv1.HostPathVolumeSource().
+			WithPath(ModelCacheHostPath(model.Name)).
+			WithType(corev1.HostPathDirectoryOrCreate))
+	} else {
+		volume.WithPersistentVolumeClaim(coreapplyv1.PersistentVolumeClaimVolumeSource().
+			WithClaimName(ModelCacheName(model.Name)).
+			WithReadOnly(true))
+	}
+	template.Spec.WithVolumes(volume)
+
+	for i := range template.Spec.InitContainers {
+		container := &template.Spec.InitContainers[i]
+		if ptr.Deref(container.Name, "") != loaderName {
+			continue
+		}
+		for j := range container.VolumeMounts {
+			if ptr.Deref(container.VolumeMounts[j].Name, "") == MODEL_VOLUME_NAME {
+				container.VolumeMounts[j].Name = ptr.To(volumeName)
+			}
+		}
+	}
+
+	// The model loader downloads the model to the model path relative to the container model path.
+	modelPath := source.ModelPath(false)
+	for i := range template.Spec.Containers {
+		if ptr.Deref(template.Spec.Containers[i].Name, "") == MODEL_RUNNER_CONTAINER_NAME {
+			template.Spec.Containers[i].WithVolumeMounts(coreapplyv1.VolumeMount().
+				WithName(volumeName).
+				WithMountPath(modelPath).
+				WithSubPath(strings.TrimPrefix(modelPath, CONTAINER_MODEL_PATH)).
+				WithReadOnly(true))
+		}
+	}
+	return nil
+}
+
+// ModelCacheLoader returns the Pod spec with the model loader as the only container, downloading
+// the model into the model-volume, which is supposed to be added by the caller, e.g. backed by the
+// PersistentVolumeClaim of the cache. Other volumes required by the loader are included.
+func ModelCacheLoader(model *coreapi.OpenModel, initContainerImage string) (*coreapplyv1.PodSpecApplyConfiguration, error) {
+	source, err := NewModelSourceProvider(model)
+	if err != nil {
+		return nil, err
+	}
+	template := coreapplyv1.PodTemplateSpec().WithSpec(coreapplyv1.PodSpec().
+		WithContainers(coreapplyv1.Container().WithName(MODEL_RUNNER_CONTAINER_NAME)))
+	source.InjectModelLoader(template, 0, initContainerImage)
+	if len(template.Spec.InitContainers) == 0 {
+		return nil, nil
+	}
+	template.Spec.Containers = template.Spec.InitContainers
+	template.Spec.InitContainers = nil
+	return template.Spec, nil
+}
+
+// InjectPreheatAffinity prefers the nodes the model is preheated on, the affinity
+// doesn't change with the progress of preheating to avoid rolling the workloads.
+func InjectPreheatAffinity(template *coreapplyv1.PodTemplateSpecApplyConfiguration, model *coreapi.OpenModel) {
+	if template.Spec == nil {
+		template.WithSpec(coreapplyv1.PodSpec())
+	}
+	if template.Spec.Affinity == nil {
+		template.Spec.WithAffinity(coreapplyv1.Affinity())
+	}
+	if template.Spec.Affinity.NodeAffinity == nil {
+		template.Spec.Affinity.WithNodeAffinity(coreapplyv1.NodeAffinity())
+	}
+	template.Spec.Affinity.NodeAffinity.WithPreferredDuringSchedulingIgnoredDuringExecution(
+		coreapplyv1.PreferredSchedulingTerm().
+			WithWeight(PREHEATED_NODE_AFFINITY_WEIGHT).
+			WithPreference(coreapplyv1.NodeSelectorTerm().WithMatchExpressions(
+				coreapplyv1.NodeSelectorRequirement().
+					WithKey(ModelPreheatedLabelKey(model.Name)).
+					WithOperator(corev1.NodeSelectorOpIn).
+					WithValues(SourceHash(model)))))
+}
@@ -0,0 +1,215 @@
+/*
+Copyright 2025 The InftyAI Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modelSource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	coreapplyv1 "k8s.io/client-go/applyconfigurations/core/v1"
+	"k8s.io/utils/ptr"
+
+	coreapi "github.com/inftyai/llmaz/api/core/v1alpha1"
+	"github.com/inftyai/llmaz/test/util/wrapper"
+)
+
+func TestModelCacheSupported(t *testing.T) {
+	tests := []struct {
+		name  string
+		model *coreapi.OpenModel
+		want  bool
+	}{
+		{
+			name:  "modelhub",
+			model: wrapper.MakeModel("llama3-8b").ModelSourceWithModelHub("Huggingface").ModelSourceWithModelID("meta-llama/Meta-Llama-3-8B", "", "", nil, nil).Obj(),
+			want:  true,
+		},
+		{
+			name:  "object store",
+			model: wrapper.MakeModel("llama3-8b").ModelSourceWithURI("s3://bucket/llama3-8b").Obj(),
+			want:  true,
+		},
+		{
+			name:  "host path",
+			model: wrapper.MakeModel("llama3-8b").ModelSourceWithURI("host:///models/llama3-8b").Obj(),
+		},
+		{
+			name:  "ollama",
+			model: wrapper.MakeModel("llama3-8b").ModelSourceWithURI("ollama://llama3").Obj(),
+		},
+		{
+			name:  "pvc",
+			model: wrapper.MakeModel("llama3-8b").ModelSourceWithURI("pvc://models/llama3-8b").Obj(),
+		},
+		{
+			name:  "nfs",
+			model: wrapper.MakeModel("llama3-8b").ModelSourceWithURI("nfs://10.0.0.1/exports/llama3-8b").Obj(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ModelCacheSupported(tt.model))
+		})
+	}
+}
+
+func TestModelCachedInVolume(t *testing.T) {
+	tests := []struct {
+		name  string
+		model *coreapi.OpenModel
+		want  bool
+	}{
+		{
+			name:  "cache disabled",
+			model: wrapper.MakeModel("llama3-8b").CacheStatus(coreapi.ModelCacheReady).Obj(),
+		},
+		{
+			name:  "host path cache",
+			model: wrapper.MakeModel("llama3-8b").Cache(coreapi.HostPathModelCache).CacheStatus(coreapi.ModelCacheReady).Obj(),
+		},
+		{
+			name:  "persistent volume cache downloading",
+			model: wrapper.MakeModel("llama3-8b").Cache("").CacheStatus(coreapi.ModelCacheDownloading).Obj(),
+		},
+		{
+			name:  "persistent volume cache ready",
+			model: wrapper.MakeModel("llama3-8b").Cache("").CacheStatus(coreapi.ModelCacheReady).Obj(),
+			want:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ModelCachedInVolume(tt.model))
+		})
+	}
+}
+
+func TestInjectModelCache(t *testing.T) {
+	tests := []struct {
+		name       string
+		cacheType  coreapi.ModelCacheType
+		index      int
+		wantVolume *coreapplyv1.VolumeApplyConfiguration
+		wantMount  *coreapplyv1.VolumeMountApplyConfiguration
+	}{
+		{
+			name:      "persistent volume cache",
+			cacheType: coreapi.PersistentVolumeModelCache,
+			wantVolume: coreapplyv1.Volume().WithName("model-cache").WithPersistentVolumeClaim(
+				coreapplyv1.PersistentVolumeClaimVolumeSource().WithClaimName("model-cache-llama3-8b").WithReadOnly(true)),
+			wantMount: coreapplyv1.VolumeMount().WithName("model-cache").
+				WithMountPath("/workspace/models/models--meta-llama--Meta-Llama-3-8B").
+				WithSubPath("models--meta-llama--Meta-Llama-3-8B").WithReadOnly(true),
+		},
+		{
+			name:      "host path cache of the second model",
+			cacheType: coreapi.HostPathModelCache,
+			index:     1,
+			wantVolume: coreapplyv1.Volume().WithName("model-cache-1").WithHostPath(
+				coreapplyv1.HostPathVolumeSource().WithPath("/mnt/models/cluster/llama3-8b").WithType(corev1.HostPathDirectoryOrCreate)),
+			wantMount: coreapplyv1.VolumeMount().WithName("model-cache-1").
+				WithMountPath("/workspace/models/models--meta-llama--Meta-Llama-3-8B").
+				WithSubPath("models--meta-llama--Meta-Llama-3-8B").WithReadOnly(true),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := wrapper.MakeModel("llama3-8b").ModelSourceWithModelHub("Huggingface").
+				ModelSourceWithModelID("meta-llama/Meta-Llama-3-8B", "", "", nil, nil).Cache(tt.cacheType).Obj()
+			template := coreapplyv1.PodTemplateSpec().WithSpec(coreapplyv1.PodSpec().
+				WithContainers(coreapplyv1.Container().WithName(MODEL_RUNNER_CONTAINER_NAME)))
+			provider, err := NewModelSourceProvider(model)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.cacheType == coreapi.HostPathModelCache {
+				provider.InjectModelLoader(template, tt.index, "model-loader:latest")
+			}
+
+			if err := InjectModelCache(template, model, tt.index); err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Contains(t, template.Spec.Volumes, *tt.wantVolume)
+			assert.Contains(t, template.Spec.Containers[0].VolumeMounts, *tt.wantMount)
+			for _, container := range template.Spec.InitContainers {
+				for _, mount := range container.VolumeMounts {
+					assert.Equal(t, ptr.Deref(tt.wantVolume.Name, ""), ptr.Deref(mount.Name, ""))
+				}
+			}
+		})
+	}
+}
+
+func TestModelCachedOnHost(t *testing.T) {
+	tests := []struct {
+		name  string
+		model *coreapi.OpenModel
+		want  bool
+	}{
+		{
+			name:  "no cache",
+			model: wrapper.MakeModel("llama3-8b").Obj(),
+		},
+		{
+			name:  "host path cache",
+			model: wrapper.MakeModel("llama3-8b").Cache(coreapi.HostPathModelCache).Obj(),
+			want:  true,
+		},
+		{
+			name:  "preheated",
+			model: wrapper.MakeModel("llama3-8b").Annotation(coreapi.ModelPreheatAnnoKey, "true").Obj(),
+			want:  true,
+		},
+		{
+			name:  "preheated with persistent volume cache",
+			model: wrapper.MakeModel("llama3-8b").Annotation(coreapi.ModelPreheatAnnoKey, "true").Cache(coreapi.PersistentVolumeModelCache).Obj(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ModelCachedOnHost(tt.model))
+		})
+	}
+}
+
+func TestInjectPreheatAffinity(t *testing.T) {
+	model := wrapper.MakeModel("llama3-8b").ModelSourceWithURI("s3://bucket/llama3-8b").Annotation(coreapi.ModelPreheatAnnoKey, "true").Obj()
+	template := coreapplyv1.PodTemplateSpec().WithSpec(coreapplyv1.PodSpec().WithAffinity(coreapplyv1.Affinity().WithNodeAffinity(
+		coreapplyv1.NodeAffinity().WithPreferredDuringSchedulingIgnoredDuringExecution(coreapplyv1.PreferredSchedulingTerm().WithWeight(1)))))
+
+	InjectPreheatAffinity(template, model)
+
+	terms := template.Spec.Affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution
+	assert.Len(t, terms, 2)
+	assert.Equal(t, int32(PREHEATED_NODE_AFFINITY_WEIGHT), *terms[1].Weight)
+	assert.Equal(t, []coreapplyv1.NodeSelectorRequirementApplyConfiguration{
+		*coreapplyv1.NodeSelectorRequirement().WithKey("preheat.llmaz.io/llama3-8b").WithOperator(corev1.NodeSelectorOpIn).WithValues(SourceHash(model)),
+	}, terms[1].Preference.MatchExpressions)
+
+	// The hash changes with the source only.
+	other := model.DeepCopy()
+	other.Annotations[coreapi.ModelSizeAnnoKey] = "16Gi"
+	assert.Equal(t, SourceHash(model), SourceHash(other))
+	other.Spec.Source.URI = ptr.To[coreapi.URIProtocol]("s3://bucket/llama3-70b")
+	assert.NotEqual(t, SourceHash(model), SourceHash(other))
+}
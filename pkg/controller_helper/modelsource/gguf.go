@@ -0,0 +1,293 @@
+/*
+Copyright 2025 The InftyAI Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modelSource
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// The value types of the GGUF metadata, see https://github.com/ggml-org/ggml/blob/master/docs/gguf.md.
+const (
+	ggufTypeUint8 uint32 = iota
+	ggufTypeInt8
+	ggufTypeUint16
+	ggufTypeInt16
+	ggufTypeUint32
+	ggufTypeInt32
+	ggufTypeFloat32
+	ggufTypeBool
+	ggufTypeString
+	ggufTypeArray
+	ggufTypeUint64
+	ggufTypeInt64
+	ggufTypeFloat64
+)
+
+const (
+	ggufMagic = "GGUF"
+	// maxGGUFStringLength limits the strings read into memory, others are skipped.
+	maxGGUFStringLength = 1 << 10
+	maxGGUFDimensions   = 8
+)
+
+// ggufFileTypes maps the general.file_type to the names used by llama.cpp.
+var ggufFileTypes = map[uint64]string{
+	0:  "F32",
+	1:  "F16",
+	2:  "Q4_0",
+	3:  "Q4_1",
+	7:  "Q8_0",
+	8:  "Q5_0",
+	9:  "Q5_1",
+	10: "Q2_K",
+	11: "Q3_K_S",
+	12: "Q3_K_M",
+	13: "Q3_K_L",
+	14: "Q4_K_S",
+	15: "Q4_K_M",
+	16: "Q5_K_S",
+	17: "Q5_K_M",
+	18: "Q6_K",
+	32: "BF16",
+}
+
+// ggufHeader is the part of the GGUF header used by the model metadata.
+type ggufHeader struct {
+	architecture   string
+	contextLength  *int64
+	fileType       string
+	parameterCount int64
+	tokenizer      bool
+}
+
+type ggufReader struct {
+	r *bufio.Reader
+}
+
+// parseGGUF parses the header of the GGUF file, the tensor data is not read.
+// The parameter count is summed up from the shapes of the tensors.
+func parseGGUF(r io.Reader) (*ggufHeader, error) {
+	reader := &ggufReader{r: bufio.NewReader(r)}
+
+	magic := make([]byte, len(ggufMagic))
+	if _, err := io.ReadFull(reader.r, magic); err != nil {
+		return nil, err
+	}
+	if string(magic) != ggufMagic {
+		return nil, errors.New("not a GGUF file")
+	}
+	version, err := reader.uint32()
+	if err != nil {
+		return nil, err
+	}
+	// Version 1 uses 32-bit counts and lengths, which is deprecated long ago.
+	if version < 2 {
+		return nil, fmt.Errorf("GGUF version %d not supported", version)
+	}
+
+	tensorCount, err := reader.uint64()
+	if err != nil {
+		return nil, err
+	}
+	kvCount, err := reader.uint64()
+	if err != nil {
+		return nil, err
+	}
+
+	header := &ggufHeader{}
+	contextLengths := map[string]int64{}
+	for i := uint64(0); i < kvCount; i++ {
+		key, err := reader.string()
+		if err != nil {
+			return nil, err
+		}
+		valueType, err := reader.uint32()
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case key == "general.architecture" && valueType == ggufTypeString:
+			if header.architecture, err = reader.string(); err != nil {
+				return nil, err
+			}
+		case key == "general.file_type" || strings.HasSuffix(key, ".context_length"):
+			value, ok, err := reader.integer(valueType)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+			if key == "general.file_type" {
+				header.fileType = ggufFileTypes[uint64(value)]
+			} else {
+				contextLengths[strings.TrimSuffix(key, ".context_length")] = value
+			}
+		default:
+			if key == "tokenizer.ggml.model" {
+				header.tokenizer = true
+			}
+			if err := reader.skip(valueType); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if length, ok := contextLengths[header.architecture]; ok {
+		header.contextLength = &length
+	}
+
+	for i := uint64(0); i < tensorCount; i++ {
+		if err := reader.skipString(); err != nil {
+			return nil, err
+		}
+		dimensions, err := reader.uint32()
+		if err != nil {
+			return nil, err
+		}
+		if dimensions > maxGGUFDimensions {
+			return nil, fmt.Errorf("unexpected %d dimensions of tensor %d", dimensions, i)
+		}
+		elements := int64(1)
+		for j := uint32(0); j < dimensions; j++ {
+			dimension, err := reader.uint64()
+			if err != nil {
+				return nil, err
+			}
+			elements *= int64(dimension)
+		}
+		header.parameterCount += elements
+		// Skip the tensor type and the data offset.
+		if _, err := reader.r.Discard(4 + 8); err != nil {
+			return nil, err
+		}
+	}
+	return header, nil
+}
+
+func (g *ggufReader) uint32() (uint32, error) {
+	var value uint32
+	err := binary.Read(g.r, binary.LittleEndian, &value)
+	return value, err
+}
+
+func (g *ggufReader) uint64() (uint64, error) {
+	var value uint64
+	err := binary.Read(g.r, binary.LittleEndian, &value)
+	return value, err
+}
+
+func (g *ggufReader) string() (string, error) {
+	length, err := g.uint64()
+	if err != nil {
+		return "", err
+	}
+	if length > maxGGUFStringLength {
+		return "", fmt.Errorf("string length %d exceeds the limit", length)
+	}
+	value := make([]byte, length)
+	if _, err := io.ReadFull(g.r, value); err != nil {
+		return "", err
+	}
+	return string(value), nil
+}
+
+func (g *ggufReader) skipString() error {
+	length, err := g.uint64()
+	if err != nil {
+		return err
+	}
+	_, err = io.CopyN(io.Discard, g.r, int64(length))
+	return err
+}
+
+// integer reads the value of the integer types, other types are skipped with false returned.
+func (g *ggufReader) integer(valueType uint32) (int64, bool, error) {
+	var err error
+	switch valueType {
+	case ggufTypeUint8, ggufTypeInt8:
+		var value uint8
+		err = binary.Read(g.r, binary.LittleEndian, &value)
+		return int64(value), err == nil, err
+	case ggufTypeUint16, ggufTypeInt16:
+		var value uint16
+		err = binary.Read(g.r, binary.LittleEndian, &value)
+		return int64(value), err == nil, err
+	case ggufTypeUint32, ggufTypeInt32:
+		var value uint32
+		err = binary.Read(g.r, binary.LittleEndian, &value)
+		return int64(value), err == nil, err
+	case ggufTypeUint64, ggufTypeInt64:
+		var value uint64
+		err = binary.Read(g.r, binary.LittleEndian, &value)
+		return int64(value), err == nil, err
+	}
+	return 0, false, g.skip(valueType)
+}
+
+func (g *ggufReader) skip(valueType uint32) error {
+	switch valueType {
+	case ggufTypeString:
+		return g.skipString()
+	case ggufTypeArray:
+		elementType, err := g.uint32()
+		if err != nil {
+			return err
+		}
+		count, err := g.uint64()
+		if err != nil {
+			return err
+		}
+		if size := ggufTypeSize(elementType); size > 0 {
+			_, err = io.CopyN(io.Discard, g.r, int64(count)*size)
+			return err
+		}
+		for i := uint64(0); i < count; i++ {
+			if err := g.skip(elementType); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	size := ggufTypeSize(valueType)
+	if size == 0 {
+		return fmt.Errorf("unknown GGUF value type %d", valueType)
+	}
+	_, err := io.CopyN(io.Discard, g.r, size)
+	return err
+}
+
+// ggufTypeSize returns the size of the fixed-size types, 0 for the others.
+func ggufTypeSize(valueType uint32) int64 {
+	switch valueType {
+	case ggufTypeUint8, ggufTypeInt8, ggufTypeBool:
+		return 1
+	case ggufTypeUint16, ggufTypeInt16:
+		return 2
+	case ggufTypeUint32, ggufTypeInt32, ggufTypeFloat32:
+		return 4
+	case ggufTypeUint64, ggufTypeInt64, ggufTypeFloat64:
+		return 8
+	}
+	return 0
+}
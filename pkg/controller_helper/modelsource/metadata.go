@@ -0,0 +1,507 @@
+/*
+Copyright 2025 The InftyAI Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modelSource
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+
+	coreapi "github.com/inftyai/llmaz/api/core/v1alpha1"
+	"github.com/inftyai/llmaz/pkg/util"
+)
+
+const (
+	// maxMetadataFiles is the maximum number of files recorded in the metadata.
+	maxMetadataFiles = 100
+	// maxListKeys is the maximum number of objects listed from the buckets.
+	maxListKeys = 1000
+	// maxGGUFHeaderSize limits the bytes read from the GGUF files, the header
+	// includes the vocabulary of the tokenizer which is several MiBs in general.
+	maxGGUFHeaderSize = 64 << 20
+	// maxSafetensorsHeaderSize limits the header size of the safetensors files.
+	maxSafetensorsHeaderSize = 16 << 20
+	// maxConfigSize limits the size of the json files like config.json.
+	maxConfigSize = 4 << 20
+
+	configFile            = "config.json"
+	safetensorsIndexFile  = "model.safetensors.index.json"
+	safetensorsSingleFile = "model.safetensors"
+)
+
+var (
+	weightFileSuffixes = []string{".safetensors", ".bin", ".gguf", ".pt", ".pth"}
+	tokenizerFiles     = []string{"tokenizer.json", "tokenizer.model", "tokenizer_config.json"}
+	// dtypeSizes maps the torch dtypes to the bytes per parameter.
+	dtypeSizes = map[string]int64{
+		"float32":  4,
+		"float16":  2,
+		"bfloat16": 2,
+		"int8":     1,
+	}
+	// safetensorsDTypes maps the safetensors dtypes to the torch dtypes.
+	safetensorsDTypes = map[string]string{
+		"F32":     "float32",
+		"F16":     "float16",
+		"BF16":    "bfloat16",
+		"I8":      "int8",
+		"F8_E4M3": "float8_e4m3fn",
+		"F8_E5M2": "float8_e5m2",
+	}
+)
+
+// modelFile is a file of the model with the size, 0 means unknown.
+type modelFile struct {
+	name string
+	size int64
+}
+
+// openFunc opens the file of the model, reading at most limit bytes.
+type openFunc func(ctx context.Context, name string, limit int64) (io.ReadCloser, error)
+
+// Discover fetches the metadata of the model from the source, credentials are the same as Validate.
+// Nil is returned if the source doesn't support discovering, e.g. the ollama models and host paths.
+func (v *SourceValidator) Discover(ctx context.Context, model *coreapi.OpenModel, credentials map[string][]byte) (*coreapi.ModelMetadata, error) {
+	if hub := model.Spec.Source.ModelHub; hub != nil {
+		if ptr.Deref(hub.Name, coreapi.HUGGING_FACE) == coreapi.HUGGING_FACE {
+			return v.discoverHuggingface(ctx, hub, credentials)
+		}
+		return nil, nil
+	}
+
+	if model.Spec.Source.URI == nil {
+		return nil, nil
+	}
+	protocol, address, err := util.ParseURI(string(*model.Spec.Source.URI))
+	if err != nil {
+		return nil, err
+	}
+
+	switch protocol {
+	case OSS:
+		endpoint, bucket, modelPath, err := util.ParseOSS(address)
+		if err != nil {
+			return nil, err
+		}
+		accessKeyID, secretKey := string(credentials[OSS_ACCESS_KEY_ID]), string(credentials[OSS_ACCESS_KEY_SECRET])
+		sign := func(resource string) func(*http.Request) {
+			if accessKeyID == "" || secretKey == "" {
+				return nil
+			}
+			return func(req *http.Request) { signOSS(req, accessKeyID, secretKey, resource, time.Now().UTC()) }
+		}
+		baseURL := fmt.Sprintf("https://%s.%s", bucket, endpoint)
+		return v.discoverBucket(ctx, modelPath, func(query url.Values) (string, func(*http.Request)) {
+			return baseURL + "/?" + query.Encode(), sign("/" + bucket + "/")
+		}, func(key string) (string, func(*http.Request)) {
+			return baseURL + "/" + escapeKey(key), sign("/" + bucket + "/" + key)
+		})
+	case S3, GCS:
+		bucket, modelPath, err := util.ParseS3(address)
+		if err != nil {
+			return nil, err
+		}
+		endpoint := v.s3Endpoint(credentials)
+		var sign func(*http.Request)
+		if protocol == GCS {
+			if len(credentials[GCS_SERVICE_ACCOUNT_KEY]) > 0 {
+				return nil, nil
+			}
+			endpoint = v.GCSEndpoint
+		} else if accessKeyID, secretKey := string(credentials[AWS_ACCESS_KEY_ID]), string(credentials[AWS_ACCESS_KEY_SECRET]); accessKeyID != "" && secretKey != "" {
+			region := defaultAWSRegion
+			if value := string(credentials[AWS_REGION]); value != "" {
+				region = value
+			}
+			sign = func(req *http.Request) { signV4(req, accessKeyID, secretKey, region, time.Now().UTC()) }
+		}
+		return v.discoverBucket(ctx, modelPath, func(query url.Values) (string, func(*http.Request)) {
+			query.Set("list-type", "2")
+			return fmt.Sprintf("%s/%s?%s", endpoint, bucket, query.Encode()), sign
+		}, func(key string) (string, func(*http.Request)) {
+			return fmt.Sprintf("%s/%s/%s", endpoint, bucket, escapeKey(key)), sign
+		})
+	}
+	return nil, nil
+}
+
+// HuggingfaceModelDetail is part of the response of the Huggingface model API with blobs.
+type HuggingfaceModelDetail struct {
+	Siblings []struct {
+		RFilename string `json:"rfilename"`
+		Size      int64  `json:"size"`
+	} `json:"siblings"`
+	Safetensors *struct {
+		Parameters map[string]int64 `json:"parameters"`
+		Total      int64            `json:"total"`
+	} `json:"safetensors"`
+}
+
+func (v *SourceValidator) discoverHuggingface(ctx context.Context, hub *coreapi.ModelHub, credentials map[string][]byte) (*coreapi.ModelMetadata, error) {
+	revision := ptr.Deref(hub.Revision, "main")
+	var authorize func(*http.Request)
+	if token := string(credentials[HUGGING_FACE_TOKEN_KEY]); token != "" {
+		authorize = func(req *http.Request) { req.Header.Set("Authorization", "Bearer "+token) }
+	}
+
+	target := fmt.Sprintf("%s/api/models/%s/revision/%s?blobs=true", v.HuggingfaceEndpoint, hub.ModelID, url.PathEscape(revision))
+	resp, err := v.get(ctx, target, authorize, true)
+	if err != nil {
+		return nil, err
+	}
+	if resp.statusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d from %s", resp.statusCode, target)
+	}
+	var detail HuggingfaceModelDetail
+	if err := json.Unmarshal(resp.body, &detail); err != nil {
+		return nil, fmt.Errorf("failed to decode the model info: %v", err)
+	}
+
+	var files []modelFile
+	for _, sibling := range detail.Siblings {
+		if hub.Filename != nil && sibling.RFilename != *hub.Filename {
+			continue
+		}
+		if !matchPatterns(sibling.RFilename, hub.AllowPatterns, hub.IgnorePatterns) {
+			continue
+		}
+		files = append(files, modelFile{name: sibling.RFilename, size: sibling.Size})
+	}
+
+	open := func(ctx context.Context, name string, limit int64) (io.ReadCloser, error) {
+		target := fmt.Sprintf("%s/%s/resolve/%s/%s", v.HuggingfaceEndpoint, hub.ModelID, url.PathEscape(revision), name)
+		return v.open(ctx, target, authorize, limit, true)
+	}
+	metadata, err := discoverFiles(ctx, files, open)
+	if err != nil {
+		return nil, err
+	}
+
+	// The model API counts the parameters of all the safetensors files already.
+	if detail.Safetensors != nil && detail.Safetensors.Total > 0 && hub.Filename == nil {
+		metadata.ParameterCount = ptr.To(detail.Safetensors.Total)
+	}
+	return metadata, nil
+}
+
+// discoverBucket lists the objects under the model path, the listURL and the objectURL
+// return the url of the request and the signing function.
+func (v *SourceValidator) discoverBucket(ctx context.Context, modelPath string,
+	listURL func(query url.Values) (string, func(*http.Request)),
+	objectURL func(key string) (string, func(*http.Request))) (*coreapi.ModelMetadata, error) {
+	query := url.Values{}
+	query.Set("max-keys", fmt.Sprint(maxListKeys))
+	query.Set("prefix", modelPath)
+	target, sign := listURL(query)
+
+	resp, err := v.get(ctx, target, sign, false)
+	if err != nil {
+		return nil, err
+	}
+	if resp.statusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d from %s", resp.statusCode, target)
+	}
+	var result struct {
+		Contents []struct {
+			Key  string `xml:"Key"`
+			Size int64  `xml:"Size"`
+		} `xml:"Contents"`
+	}
+	if err := xml.Unmarshal(resp.body, &result); err != nil {
+		return nil, fmt.Errorf("failed to decode the objects: %v", err)
+	}
+
+	// The model path could be a file like a GGUF file, or a directory.
+	prefix := strings.TrimSuffix(modelPath, "/") + "/"
+	var files []modelFile
+	for _, content := range result.Contents {
+		name := path.Base(content.Key)
+		if content.Key != modelPath {
+			if !strings.HasPrefix(content.Key, prefix) || strings.HasSuffix(content.Key, "/") {
+				continue
+			}
+			name = strings.TrimPrefix(content.Key, prefix)
+		}
+		files = append(files, modelFile{name: name, size: content.Size})
+	}
+
+	open := func(ctx context.Context, name string, limit int64) (io.ReadCloser, error) {
+		key := prefix + name
+		if path.Base(modelPath) == name {
+			key = modelPath
+		}
+		target, sign := objectURL(key)
+		return v.open(ctx, target, sign, limit, false)
+	}
+	return discoverFiles(ctx, files, open)
+}
+
+// open requests the first limit bytes of the file with the range header.
+func (v *SourceValidator) open(ctx context.Context, target string, sign func(*http.Request), limit int64, followRedirects bool) (io.ReadCloser, error) {
+	resp, err := v.do(ctx, target, func(req *http.Request) {
+		req.Header.Set("Range", fmt.Sprintf("bytes=0-%d", limit-1))
+		if sign != nil {
+			sign(req)
+		}
+	}, followRedirects)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent {
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, target)
+	}
+	// Servers may ignore the range header.
+	return struct {
+		io.Reader
+		io.Closer
+	}{io.LimitReader(resp.Body, limit), resp.Body}, nil
+}
+
+// discoverFiles builds the metadata from the files, the header of the GGUF file or the
+// config.json together with the safetensors files are read for the details.
+func discoverFiles(ctx context.Context, files []modelFile, open openFunc) (*coreapi.ModelMetadata, error) {
+	metadata := &coreapi.ModelMetadata{}
+	names := map[string]bool{}
+	var weightSize int64
+	var ggufFile string
+
+	for _, file := range files {
+		names[file.name] = true
+		if slices.ContainsFunc(weightFileSuffixes, func(suffix string) bool { return strings.HasSuffix(file.name, suffix) }) &&
+			path.Base(file.name) != "training_args.bin" {
+			weightSize += file.size
+			if ggufFile == "" && strings.HasSuffix(file.name, ".gguf") {
+				ggufFile = file.name
+			}
+		}
+		if slices.Contains(tokenizerFiles, path.Base(file.name)) {
+			metadata.Tokenizer = ptr.To(true)
+		}
+	}
+
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	slices.Sort(sorted)
+	if len(sorted) > maxMetadataFiles {
+		sorted = sorted[:maxMetadataFiles]
+	}
+	metadata.Files = sorted
+	if weightSize > 0 {
+		metadata.WeightSize = resource.NewQuantity(weightSize, resource.BinarySI)
+	}
+
+	if ggufFile != "" {
+		reader, err := open(ctx, ggufFile, maxGGUFHeaderSize)
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = reader.Close() }()
+
+		header, err := parseGGUF(reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the header of %s: %v", ggufFile, err)
+		}
+		metadata.Architecture = header.architecture
+		metadata.ContextLength = header.contextLength
+		metadata.DType = header.fileType
+		if header.parameterCount > 0 {
+			metadata.ParameterCount = ptr.To(header.parameterCount)
+		}
+		metadata.Tokenizer = ptr.To(header.tokenizer)
+		return metadata, nil
+	}
+
+	if !names[configFile] {
+		return metadata, nil
+	}
+	var config modelConfig
+	if err := readJSON(ctx, open, configFile, &config); err != nil {
+		return nil, err
+	}
+	config.apply(metadata)
+
+	switch {
+	case names[safetensorsIndexFile]:
+		// The index records the total bytes of the weights, the parameters are estimated
+		// with the dtype, which doesn't work for the quantized models.
+		var index struct {
+			Metadata struct {
+				TotalSize int64 `json:"total_size"`
+			} `json:"metadata"`
+		}
+		if err := readJSON(ctx, open, safetensorsIndexFile, &index); err != nil {
+			return nil, err
+		}
+		if metadata.WeightSize == nil && index.Metadata.TotalSize > 0 {
+			metadata.WeightSize = resource.NewQuantity(index.Metadata.TotalSize, resource.BinarySI)
+		}
+		if size, ok := dtypeSizes[metadata.DType]; ok && config.QuantizationConfig == nil {
+			metadata.ParameterCount = ptr.To(index.Metadata.TotalSize / size)
+		}
+	case names[safetensorsSingleFile]:
+		count, dtype, err := readSafetensorsHeader(ctx, open, safetensorsSingleFile)
+		if err != nil {
+			return nil, err
+		}
+		metadata.ParameterCount = ptr.To(count)
+		if metadata.DType == "" {
+			metadata.DType = dtype
+		}
+	}
+	return metadata, nil
+}
+
+// modelConfig is part of the config.json of the transformers models.
+type modelConfig struct {
+	Architectures         []string `json:"architectures"`
+	MaxPositionEmbeddings *int64   `json:"max_position_embeddings"`
+	NPositions            *int64   `json:"n_positions"`
+	TorchDType            string   `json:"torch_dtype"`
+	QuantizationConfig    *struct {
+		QuantMethod string `json:"quant_method"`
+	} `json:"quantization_config"`
+	// TextConfig is set by the multimodal models.
+	TextConfig *modelConfig `json:"text_config"`
+}
+
+func (c *modelConfig) apply(metadata *coreapi.ModelMetadata) {
+	if len(c.Architectures) > 0 {
+		metadata.Architecture = c.Architectures[0]
+	}
+
+	metadata.ContextLength = c.MaxPositionEmbeddings
+	if metadata.ContextLength == nil {
+		metadata.ContextLength = c.NPositions
+	}
+	if metadata.ContextLength == nil && c.TextConfig != nil {
+		metadata.ContextLength = c.TextConfig.MaxPositionEmbeddings
+	}
+
+	metadata.DType = c.TorchDType
+	if metadata.DType == "" && c.TextConfig != nil {
+		metadata.DType = c.TextConfig.TorchDType
+	}
+	if c.QuantizationConfig != nil && c.QuantizationConfig.QuantMethod != "" {
+		metadata.DType = c.QuantizationConfig.QuantMethod
+	}
+}
+
+func readJSON(ctx context.Context, open openFunc, name string, value interface{}) error {
+	reader, err := open(ctx, name, maxConfigSize)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = reader.Close() }()
+
+	if err := json.NewDecoder(reader).Decode(value); err != nil {
+		return fmt.Errorf("failed to decode %s: %v", name, err)
+	}
+	return nil
+}
+
+// readSafetensorsHeader returns the parameter count and the dtype of most parameters
+// of the safetensors file, see https://huggingface.co/docs/safetensors/index#format.
+func readSafetensorsHeader(ctx context.Context, open openFunc, name string) (int64, string, error) {
+	reader, err := open(ctx, name, maxSafetensorsHeaderSize+8)
+	if err != nil {
+		return 0, "", err
+	}
+	defer func() { _ = reader.Close() }()
+
+	var size uint64
+	if err := binary.Read(reader, binary.LittleEndian, &size); err != nil {
+		return 0, "", err
+	}
+	if size > maxSafetensorsHeaderSize {
+		return 0, "", fmt.Errorf("header size %d of %s exceeds the limit", size, name)
+	}
+
+	var header map[string]json.RawMessage
+	if err := json.NewDecoder(io.LimitReader(reader, int64(size))).Decode(&header); err != nil {
+		return 0, "", fmt.Errorf("failed to decode the header of %s: %v", name, err)
+	}
+
+	var total int64
+	counts := map[string]int64{}
+	for key, raw := range header {
+		if key == "__metadata__" {
+			continue
+		}
+		var tensor struct {
+			DType string  `json:"dtype"`
+			Shape []int64 `json:"shape"`
+		}
+		if err := json.Unmarshal(raw, &tensor); err != nil {
+			return 0, "", fmt.Errorf("failed to decode tensor %s of %s: %v", key, name, err)
+		}
+		elements := int64(1)
+		for _, dimension := range tensor.Shape {
+			elements *= dimension
+		}
+		total += elements
+		counts[tensor.DType] += elements
+	}
+
+	var dtype string
+	for t, count := range counts {
+		if dtype == "" || count > counts[dtype] || (count == counts[dtype] && t < dtype) {
+			dtype = t
+		}
+	}
+	if torchDType, ok := safetensorsDTypes[dtype]; ok {
+		dtype = torchDType
+	}
+	return total, dtype, nil
+}
+
+// matchPatterns returns whether the file is downloaded with the allow and ignore patterns.
+func matchPatterns(name string, allowPatterns, ignorePatterns []string) bool {
+	match := func(patterns []string) bool {
+		return slices.ContainsFunc(patterns, func(pattern string) bool {
+			matched, _ := path.Match(pattern, name)
+			return matched
+		})
+	}
+	if len(allowPatterns) > 0 && !match(allowPatterns) {
+		return false
+	}
+	return !match(ignorePatterns)
+}
+
+// escapeKey escapes the object key except the slashes.
+func escapeKey(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,285 @@
+/*
+Copyright 2025 The InftyAI Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modelSource
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+
+	coreapi "github.com/inftyai/llmaz/api/core/v1alpha1"
+	"github.com/inftyai/llmaz/test/util/wrapper"
+)
+
+// ggufBuilder writes GGUF headers for testing.
+type ggufBuilder struct {
+	kvs     bytes.Buffer
+	kvCount uint64
+	tensors bytes.Buffer
+	count   uint64
+}
+
+func (b *ggufBuilder) writeString(buf *bytes.Buffer, value string) {
+	_ = binary.Write(buf, binary.LittleEndian, uint64(len(value)))
+	buf.WriteString(value)
+}
+
+func (b *ggufBuilder) kv(key string, valueType uint32, value any) *ggufBuilder {
+	b.writeString(&b.kvs, key)
+	_ = binary.Write(&b.kvs, binary.LittleEndian, valueType)
+	switch v := value.(type) {
+	case string:
+		b.writeString(&b.kvs, v)
+	case []string:
+		_ = binary.Write(&b.kvs, binary.LittleEndian, ggufTypeString)
+		_ = binary.Write(&b.kvs, binary.LittleEndian, uint64(len(v)))
+		for _, s := range v {
+			b.writeString(&b.kvs, s)
+		}
+	default:
+		_ = binary.Write(&b.kvs, binary.LittleEndian, v)
+	}
+	b.kvCount++
+	return b
+}
+
+func (b *ggufBuilder) tensor(name string, dimensions ...uint64) *ggufBuilder {
+	b.writeString(&b.tensors, name)
+	_ = binary.Write(&b.tensors, binary.LittleEndian, uint32(len(dimensions)))
+	for _, d := range dimensions {
+		_ = binary.Write(&b.tensors, binary.LittleEndian, d)
+	}
+	_ = binary.Write(&b.tensors, binary.LittleEndian, uint32(0))
+	_ = binary.Write(&b.tensors, binary.LittleEndian, uint64(0))
+	b.count++
+	return b
+}
+
+func (b *ggufBuilder) bytes() []byte {
+	var buf bytes.Buffer
+	buf.WriteString(ggufMagic)
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(3))
+	_ = binary.Write(&buf, binary.LittleEndian, b.count)
+	_ = binary.Write(&buf, binary.LittleEndian, b.kvCount)
+	buf.Write(b.kvs.Bytes())
+	buf.Write(b.tensors.Bytes())
+	// Tensor data never read.
+	buf.Write(make([]byte, 1024))
+	return buf.Bytes()
+}
+
+func qwen2GGUF() []byte {
+	return (&ggufBuilder{}).
+		kv("general.architecture", ggufTypeString, "qwen2").
+		kv("general.name", ggufTypeString, "qwen2-0.5b-instruct").
+		kv("qwen2.block_count", ggufTypeUint32, uint32(24)).
+		kv("qwen2.context_length", ggufTypeUint32, uint32(32768)).
+		kv("qwen2.rope.freq_base", ggufTypeFloat32, float32(1000000)).
+		kv("general.file_type", ggufTypeUint32, uint32(17)).
+		kv("tokenizer.ggml.model", ggufTypeString, "gpt2").
+		kv("tokenizer.ggml.tokens", ggufTypeArray, []string{"a", "b", "c"}).
+		tensor("token_embd.weight", 896, 151936).
+		tensor("blk.0.attn_norm.weight", 896).
+		bytes()
+}
+
+func safetensorsFile(header string) []byte {
+	var buf bytes.Buffer
+	_ = binary.Write(&buf, binary.LittleEndian, uint64(len(header)))
+	buf.WriteString(header)
+	return buf.Bytes()
+}
+
+func TestParseGGUF(t *testing.T) {
+	header, err := parseGGUF(bytes.NewReader(qwen2GGUF()))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := &ggufHeader{
+		architecture:   "qwen2",
+		contextLength:  ptr.To[int64](32768),
+		fileType:       "Q5_K_M",
+		parameterCount: 896*151936 + 896,
+		tokenizer:      true,
+	}
+	if diff := cmp.Diff(want, header, cmp.AllowUnexported(ggufHeader{})); diff != "" {
+		t.Errorf("unexpected header (-want +got): %s", diff)
+	}
+
+	if _, err := parseGGUF(bytes.NewReader([]byte("GGML"))); err == nil {
+		t.Error("expected an error for non GGUF files")
+	}
+}
+
+func TestSourceValidator_Discover(t *testing.T) {
+	gguf := qwen2GGUF()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		// Huggingface
+		case "/api/models/meta-llama/Meta-Llama-3-8B/revision/main":
+			_, _ = fmt.Fprint(w, `{"siblings": [
+				{"rfilename": "config.json", "size": 654},
+				{"rfilename": "model-00001-of-00002.safetensors", "size": 10000},
+				{"rfilename": "model-00002-of-00002.safetensors", "size": 6000},
+				{"rfilename": "model.safetensors.index.json", "size": 100},
+				{"rfilename": "original/consolidated.00.pth", "size": 16000},
+				{"rfilename": "tokenizer.json", "size": 100}
+			], "safetensors": {"parameters": {"BF16": 8030261248}, "total": 8030261248}}`)
+		case "/meta-llama/Meta-Llama-3-8B/resolve/main/config.json":
+			_, _ = fmt.Fprint(w, `{"architectures": ["LlamaForCausalLM"], "max_position_embeddings": 8192, "torch_dtype": "bfloat16"}`)
+		case "/meta-llama/Meta-Llama-3-8B/resolve/main/model.safetensors.index.json":
+			_, _ = fmt.Fprint(w, `{"metadata": {"total_size": 16060522496}}`)
+		case "/api/models/Qwen/Qwen2-0.5B-Instruct-GGUF/revision/main":
+			_, _ = fmt.Fprintf(w, `{"siblings": [{"rfilename": "qwen2-0_5b-instruct-q5_k_m.gguf", "size": %d}, {"rfilename": "qwen2-0_5b-instruct-q8_0.gguf", "size": 1000}]}`, len(gguf))
+		case "/Qwen/Qwen2-0.5B-Instruct-GGUF/resolve/main/qwen2-0_5b-instruct-q5_k_m.gguf":
+			if r.Header.Get("Range") == "" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.WriteHeader(http.StatusPartialContent)
+			_, _ = w.Write(gguf)
+		// S3
+		case "/bucket":
+			_, _ = fmt.Fprint(w, `<ListBucketResult>
+				<Contents><Key>models/qwen2/config.json</Key><Size>600</Size></Contents>
+				<Contents><Key>models/qwen2/model.safetensors</Key><Size>4000</Size></Contents>
+				<Contents><Key>models/qwen2/model.safetensors.index.json</Key><Size>100</Size></Contents>
+				<Contents><Key>models/qwen2/vocab.json</Key><Size>100</Size></Contents>
+			</ListBucketResult>`)
+		case "/bucket/models/qwen2/config.json":
+			_, _ = fmt.Fprint(w, `{"architectures": ["Qwen2ForCausalLM"], "max_position_embeddings": 32768, "torch_dtype": "bfloat16"}`)
+		case "/bucket/models/qwen2/model.safetensors.index.json":
+			_, _ = fmt.Fprint(w, `{"metadata": {"total_size": 4000}}`)
+		case "/gguf-bucket":
+			_, _ = fmt.Fprintf(w, `<ListBucketResult><Contents><Key>models/qwen2.gguf</Key><Size>%d</Size></Contents></ListBucketResult>`, len(gguf))
+		case "/gguf-bucket/models/qwen2.gguf":
+			_, _ = w.Write(gguf)
+		case "/single-bucket":
+			_, _ = fmt.Fprint(w, `<ListBucketResult>
+				<Contents><Key>models/tiny/config.json</Key><Size>600</Size></Contents>
+				<Contents><Key>models/tiny/model.safetensors</Key><Size>4000</Size></Contents>
+			</ListBucketResult>`)
+		case "/single-bucket/models/tiny/config.json":
+			_, _ = fmt.Fprint(w, `{"architectures": ["LlamaForCausalLM"], "n_positions": 2048}`)
+		case "/single-bucket/models/tiny/model.safetensors":
+			_, _ = w.Write(safetensorsFile(`{"__metadata__": {"format": "pt"}, "a": {"dtype": "F16", "shape": [10, 20], "data_offsets": [0, 400]}, "b": {"dtype": "F32", "shape": [10], "data_offsets": [400, 440]}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	validator := &SourceValidator{
+		HTTPClient:          server.Client(),
+		HuggingfaceEndpoint: server.URL,
+		S3Endpoint:          server.URL,
+	}
+
+	testCases := []struct {
+		name  string
+		model *coreapi.OpenModel
+		want  *coreapi.ModelMetadata
+	}{
+		{
+			name:  "huggingface safetensors model",
+			model: wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithModelHub("Huggingface").ModelSourceWithModelID("meta-llama/Meta-Llama-3-8B", "", "", nil, []string{"original/*"}).Obj(),
+			want: &coreapi.ModelMetadata{
+				ParameterCount: ptr.To[int64](8030261248),
+				Architecture:   "LlamaForCausalLM",
+				ContextLength:  ptr.To[int64](8192),
+				DType:          "bfloat16",
+				WeightSize:     resource.NewQuantity(16000, resource.BinarySI),
+				Files:          []string{"config.json", "model-00001-of-00002.safetensors", "model-00002-of-00002.safetensors", "model.safetensors.index.json", "tokenizer.json"},
+				Tokenizer:      ptr.To(true),
+			},
+		},
+		{
+			name:  "huggingface gguf file",
+			model: wrapper.MakeModel("qwen2-0--5b-gguf").FamilyName("qwen2").ModelSourceWithModelHub("Huggingface").ModelSourceWithModelID("Qwen/Qwen2-0.5B-Instruct-GGUF", "qwen2-0_5b-instruct-q5_k_m.gguf", "", nil, nil).Obj(),
+			want: &coreapi.ModelMetadata{
+				ParameterCount: ptr.To[int64](896*151936 + 896),
+				Architecture:   "qwen2",
+				ContextLength:  ptr.To[int64](32768),
+				DType:          "Q5_K_M",
+				WeightSize:     resource.NewQuantity(int64(len(gguf)), resource.BinarySI),
+				Files:          []string{"qwen2-0_5b-instruct-q5_k_m.gguf"},
+				Tokenizer:      ptr.To(true),
+			},
+		},
+		{
+			name:  "s3 model with safetensors index",
+			model: wrapper.MakeModel("qwen2").FamilyName("qwen2").ModelSourceWithURI("s3://bucket/models/qwen2").Obj(),
+			want: &coreapi.ModelMetadata{
+				ParameterCount: ptr.To[int64](2000),
+				Architecture:   "Qwen2ForCausalLM",
+				ContextLength:  ptr.To[int64](32768),
+				DType:          "bfloat16",
+				WeightSize:     resource.NewQuantity(4000, resource.BinarySI),
+				Files:          []string{"config.json", "model.safetensors", "model.safetensors.index.json", "vocab.json"},
+			},
+		},
+		{
+			name:  "s3 gguf file",
+			model: wrapper.MakeModel("qwen2").FamilyName("qwen2").ModelSourceWithURI("s3://gguf-bucket/models/qwen2.gguf").Obj(),
+			want: &coreapi.ModelMetadata{
+				ParameterCount: ptr.To[int64](896*151936 + 896),
+				Architecture:   "qwen2",
+				ContextLength:  ptr.To[int64](32768),
+				DType:          "Q5_K_M",
+				WeightSize:     resource.NewQuantity(int64(len(gguf)), resource.BinarySI),
+				Files:          []string{"qwen2.gguf"},
+				Tokenizer:      ptr.To(true),
+			},
+		},
+		{
+			name:  "s3 single safetensors file",
+			model: wrapper.MakeModel("tiny").FamilyName("llama").ModelSourceWithURI("s3://single-bucket/models/tiny").Obj(),
+			want: &coreapi.ModelMetadata{
+				ParameterCount: ptr.To[int64](210),
+				Architecture:   "LlamaForCausalLM",
+				ContextLength:  ptr.To[int64](2048),
+				DType:          "float16",
+				WeightSize:     resource.NewQuantity(4000, resource.BinarySI),
+				Files:          []string{"config.json", "model.safetensors"},
+			},
+		},
+		{
+			name:  "ollama model not supported",
+			model: wrapper.MakeModel("llama3").FamilyName("llama3").ModelSourceWithURI("ollama://llama3.3").Obj(),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := validator.Discover(context.Background(), tc.model, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got, cmp.Comparer(func(a, b resource.Quantity) bool { return a.Cmp(b) == 0 })); diff != "" {
+				t.Errorf("unexpected metadata (-want +got): %s", diff)
+			}
+		})
+	}
+}
@@ -21,6 +21,8 @@ import (
 	"strings"
 
 	coreapplyv1 "k8s.io/client-go/applyconfigurations/core/v1"
+
+	coreapi "github.com/inftyai/llmaz/api/core/v1alpha1"
 )
 
 var _ ModelSourceProvider = &ModelHubProvider{}
@@ -33,6 +35,10 @@ type ModelHubProvider struct {
 	modelRevision       *string
 	modelAllowPatterns  []string
 	modelIgnorePatterns []string
+	secretRef           *coreapi.SecretReference
+	checksums           map[string]string
+	// pinnedCommit is the commit the revision is pinned to, empty if not pinned.
+	pinnedCommit string
 }
 
 func (p *ModelHubProvider) ModelName() string {
@@ -83,7 +89,12 @@ func (p *ModelHubProvider) InjectModelLoader(template *coreapplyv1.PodTemplateSp
 	if p.fileName != nil {
 		initContainer.WithEnv(coreapplyv1.EnvVar().WithName("MODEL_FILENAME").WithValue(*p.fileName))
 	}
-	if p.modelRevision != nil {
+	if p.pinnedCommit != "" {
+		// The files of the commit are immutable, so they could be verified against the hub.
+		initContainer.WithEnv(
+			coreapplyv1.EnvVar().WithName("REVISION").WithValue(p.pinnedCommit),
+			coreapplyv1.EnvVar().WithName(VERIFY_HUB_CHECKSUMS).WithValue("true"))
+	} else if p.modelRevision != nil {
 		initContainer.WithEnv(coreapplyv1.EnvVar().WithName("REVISION").WithValue(*p.modelRevision))
 	}
 	if p.modelAllowPatterns != nil {
@@ -98,17 +109,12 @@ func (p *ModelHubProvider) InjectModelLoader(template *coreapplyv1.PodTemplateSp
 		coreapplyv1.EnvVar().
 			WithName(HUGGING_FACE_HUB_TOKEN).
 			WithValueFrom(coreapplyv1.EnvVarSource().
-				WithSecretKeyRef(coreapplyv1.SecretKeySelector().
-					WithName(MODELHUB_SECRET_NAME). // if secret not exists, the env is empty.
-					WithKey(HUGGING_FACE_TOKEN_KEY).
-					WithOptional(true))),
+				WithSecretKeyRef(secretKeySelector(p.secretRef, MODELHUB_SECRET_NAME, HUGGING_FACE_TOKEN_KEY))),
 		coreapplyv1.EnvVar().
 			WithName(HUGGING_FACE_TOKEN_KEY).
 			WithValueFrom(coreapplyv1.EnvVarSource().
-				WithSecretKeyRef(coreapplyv1.SecretKeySelector().
-					WithName(MODELHUB_SECRET_NAME).
-					WithKey(HUGGING_FACE_TOKEN_KEY).
-					WithOptional(true))))
+				WithSecretKeyRef(secretKeySelector(p.secretRef, MODELHUB_SECRET_NAME, HUGGING_FACE_TOKEN_KEY))))
+	injectChecksums(initContainer, p.checksums, p.ModelPath(false))
 
 	template.Spec.WithInitContainers(initContainer)
 }
@@ -140,10 +146,7 @@ func (p *ModelHubProvider) InjectModelEnvVars(template *coreapplyv1.PodTemplateS
 					coreapplyv1.EnvVar().
 						WithName(HUGGING_FACE_HUB_TOKEN).
 						WithValueFrom(coreapplyv1.EnvVarSource().
-							WithSecretKeyRef(coreapplyv1.SecretKeySelector().
-								WithName(MODELHUB_SECRET_NAME). // if secret not exists, the env is empty.
-								WithKey(HUGGING_FACE_TOKEN_KEY).
-								WithOptional(true))))
+							WithSecretKeyRef(secretKeySelector(p.secretRef, MODELHUB_SECRET_NAME, HUGGING_FACE_TOKEN_KEY))))
 			}
 
 			// Add HF_TOKEN if it doesn't exist
@@ -152,10 +155,7 @@ func (p *ModelHubProvider) InjectModelEnvVars(template *coreapplyv1.PodTemplateS
 					coreapplyv1.EnvVar().
 						WithName(HUGGING_FACE_TOKEN_KEY).
 						WithValueFrom(coreapplyv1.EnvVarSource().
-							WithSecretKeyRef(coreapplyv1.SecretKeySelector().
-								WithName(MODELHUB_SECRET_NAME).
-								WithKey(HUGGING_FACE_TOKEN_KEY).
-								WithOptional(true))))
+							WithSecretKeyRef(secretKeySelector(p.secretRef, MODELHUB_SECRET_NAME, HUGGING_FACE_TOKEN_KEY))))
 			}
 		}
 	}
@@ -17,6 +17,9 @@ limitations under the License.
 package modelSource
 
 import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/sets"
 	coreapplyv1 "k8s.io/client-go/applyconfigurations/core/v1"
 
 	coreapi "github.com/inftyai/llmaz/api/core/v1alpha1"
@@ -33,12 +36,15 @@ const (
 	// container & volume configs
 	DEFAULT_BACKEND_PORT        = 8080
 	MODEL_VOLUME_NAME           = "model-volume"
+	MODEL_SOURCE_VOLUME_NAME    = "model-source"
 	MODEL_RUNNER_CONTAINER_NAME = "model-runner"
 	MODEL_LOADER_CONTAINER_NAME = "model-loader"
 
 	// model source type
 	MODEL_SOURCE_MODELHUB        = "modelhub"
 	MODEL_SOURCE_MODEL_OBJ_STORE = "objstore"
+	MODEL_SOURCE_HTTP            = "http"
+	MODEL_SOURCE_OCI             = "oci"
 
 	// secrets
 	MODELHUB_SECRET_NAME   = "modelhub-secret"
@@ -52,6 +58,37 @@ const (
 	AWS_ACCESS_SECRET_NAME = "aws-access-secret"
 	AWS_ACCESS_KEY_ID      = "AWS_ACCESS_KEY_ID"
 	AWS_ACCESS_KEY_SECRET  = "AWS_SECRET_ACCESS_KEY"
+	AWS_SESSION_TOKEN      = "AWS_SESSION_TOKEN"
+	AWS_REGION             = "AWS_REGION"
+	// AWS_ENDPOINT_URL overwrites the endpoint of S3, e.g. for MinIO.
+	AWS_ENDPOINT_URL = "AWS_ENDPOINT_URL"
+	// AWS_S3_ADDRESSING_STYLE is one of auto, virtual and path, path is usually required by MinIO.
+	AWS_S3_ADDRESSING_STYLE = "AWS_S3_ADDRESSING_STYLE"
+
+	// The service account key of GCS is mounted as a file, the model loader falls back to
+	// the application default credentials like the workload identity if not found.
+	GCS_ACCESS_SECRET_NAME      = "gcs-access-secret"
+	GCS_SERVICE_ACCOUNT_KEY     = "service-account.json"
+	GCS_CREDENTIALS_FILE        = "GCS_CREDENTIALS_FILE"
+	GCS_CREDENTIALS_VOLUME_NAME = "gcs-credentials"
+	GCS_CREDENTIALS_MOUNT_PATH  = "/var/run/secrets/llmaz/gcs/"
+
+	// Only one of the account key, the SAS token or the service principal is required, the model loader
+	// falls back to the workload identity or the anonymous access if none found.
+	AZURE_ACCESS_SECRET_NAME  = "azure-access-secret"
+	AZURE_STORAGE_ACCOUNT_KEY = "AZURE_STORAGE_ACCOUNT_KEY"
+	AZURE_STORAGE_SAS_TOKEN   = "AZURE_STORAGE_SAS_TOKEN"
+	AZURE_CLIENT_ID           = "AZURE_CLIENT_ID"
+	AZURE_TENANT_ID           = "AZURE_TENANT_ID"
+	AZURE_CLIENT_SECRET       = "AZURE_CLIENT_SECRET"
+
+	// HTTP_AUTH_HEADER is the value of the Authorization header, e.g. Bearer <token>.
+	HTTP_ACCESS_SECRET_NAME = "http-access-secret"
+	HTTP_AUTH_HEADER        = "HTTP_AUTH_HEADER"
+
+	OCI_ACCESS_SECRET_NAME = "oci-access-secret"
+	OCI_USERNAME           = "OCI_USERNAME"
+	OCI_PASSWORD           = "OCI_PASSWORD"
 )
 
 type ModelSourceProvider interface {
@@ -65,7 +102,9 @@ type ModelSourceProvider interface {
 	InjectModelEnvVars(spec *coreapplyv1.PodTemplateSpecApplyConfiguration)
 }
 
-func NewModelSourceProvider(model *coreapi.OpenModel) ModelSourceProvider {
+// NewModelSourceProvider returns the provider of the model source, the source is validated at
+// the webhooks, an error is returned rather than crashing once it's not supported anyway.
+func NewModelSourceProvider(model *coreapi.OpenModel) (ModelSourceProvider, error) {
 	if model.Spec.Source.ModelHub != nil {
 		return &ModelHubProvider{
 			modelName:           model.Name,
@@ -75,34 +114,140 @@ func NewModelSourceProvider(model *coreapi.OpenModel) ModelSourceProvider {
 			modelRevision:       model.Spec.Source.ModelHub.Revision,
 			modelAllowPatterns:  model.Spec.Source.ModelHub.AllowPatterns,
 			modelIgnorePatterns: model.Spec.Source.ModelHub.IgnorePatterns,
-		}
+			secretRef:           model.Spec.Source.SecretRef,
+			checksums:           model.Spec.Source.Checksums,
+			pinnedCommit:        PinnedCommit(model),
+		}, nil
 	}
 
 	if model.Spec.Source.URI != nil {
 		// We'll validate the format in the webhook, so generally no error should happen here.
 		protocol, value, _ := util.ParseURI(string(*model.Spec.Source.URI))
-		provider := &URIProvider{modelName: model.Name, protocol: protocol, uri: string(*model.Spec.Source.URI)}
+		provider := &URIProvider{modelName: model.Name, protocol: protocol, uri: string(*model.Spec.Source.URI),
+			secretRef: model.Spec.Source.SecretRef, checksums: model.Spec.Source.Checksums}
 
 		switch protocol {
 		case OSS:
 			provider.endpoint, provider.bucket, provider.modelPath, _ = util.ParseOSS(value)
 		case S3, GCS:
 			provider.bucket, provider.modelPath, _ = util.ParseS3(value)
+		case AZBLOB, ABFSS:
+			var account string
+			account, provider.bucket, provider.modelPath, _ = parseAzure(protocol, value)
+			provider.endpoint = azureBlobEndpoint(account)
+		case HTTPS:
+			provider.modelPath, provider.checksum, _ = util.ParseHTTPS(value)
+		case OCI:
+			provider.endpoint, provider.modelPath, provider.reference, _ = util.ParseOCI(value)
+		case PVC:
+			provider.volume, provider.modelPath, _ = util.ParsePVC(value)
+		case NFS:
+			provider.volume, provider.modelPath, _ = util.ParseNFS(value)
 		case HostPath:
 			provider.modelPath = value
 		case Ollama:
 			provider.modelPath = value
 		default:
-			// This should be validated at webhooks.
-			panic("protocol not supported")
+			return nil, fmt.Errorf("protocol %s of model %s not supported", protocol, model.Name)
 		}
 
-		return provider
+		return provider, nil
+	}
+	return nil, fmt.Errorf("source of model %s not set", model.Name)
+}
+
+// CredentialKeys returns the names of the credentials to access the model source, which are
+// the keys of the default secret as well, empty if no credentials are supported.
+func CredentialKeys(model *coreapi.OpenModel) []string {
+	if model.Spec.Source.ModelHub != nil {
+		return []string{HUGGING_FACE_TOKEN_KEY}
+	}
+	if model.Spec.Source.URI != nil {
+		protocol, _, _ := util.ParseURI(string(*model.Spec.Source.URI))
+		switch protocol {
+		case OSS:
+			return []string{OSS_ACCESS_KEY_ID, OSS_ACCESS_KEY_SECRET}
+		case S3:
+			return awsSecretKeys
+		case GCS:
+			return []string{GCS_SERVICE_ACCOUNT_KEY}
+		case AZBLOB, ABFSS:
+			return azureSecretKeys
+		case HTTPS:
+			return []string{HTTP_AUTH_HEADER}
+		case OCI:
+			return []string{OCI_USERNAME, OCI_PASSWORD}
+		}
 	}
-	// Should not reach here, it will be validated at webhook in prior.
 	return nil
 }
 
+// optionalCredentialKeys are the credentials not required even in the secret referenced explicitly,
+// the Azure ones are alternatives of each other, e.g. the account key or the SAS token.
+var optionalCredentialKeys = sets.New(AWS_SESSION_TOKEN, AWS_REGION, AWS_ENDPOINT_URL, AWS_S3_ADDRESSING_STYLE,
+	AZURE_STORAGE_ACCOUNT_KEY, AZURE_STORAGE_SAS_TOKEN, AZURE_CLIENT_ID, AZURE_TENANT_ID, AZURE_CLIENT_SECRET)
+
+// RequiredCredentialKeys returns the credentials required in the secret referenced by the model source,
+// which are the ones mapped explicitly and the ones not optional, empty if no secret is referenced.
+func RequiredCredentialKeys(model *coreapi.OpenModel) []string {
+	ref := model.Spec.Source.SecretRef
+	if ref == nil {
+		return nil
+	}
+	var keys []string
+	for _, credential := range CredentialKeys(model) {
+		if _, mapped := ref.Keys[credential]; mapped || !optionalCredentialKeys.Has(credential) {
+			keys = append(keys, credential)
+		}
+	}
+	return keys
+}
+
+// SecretKey returns the key in the secret referenced by the model source storing the credential.
+func SecretKey(model *coreapi.OpenModel, credential string) string {
+	if ref := model.Spec.Source.SecretRef; ref != nil {
+		return secretKey(ref, credential)
+	}
+	return credential
+}
+
+// secretKey returns the key in the referenced secret storing the credential.
+func secretKey(ref *coreapi.SecretReference, credential string) string {
+	if key, ok := ref.Keys[credential]; ok {
+		return key
+	}
+	return credential
+}
+
+// secretKeySelector selects the credential from the secret referenced by the model source if set,
+// otherwise from the default secret. The default secret is optional for the public models, while the
+// referenced secret is required with the credentials except the optional ones not mapped explicitly,
+// so the workloads fail fast rather than downloading anonymously once the secret is missing or mistyped.
+func secretKeySelector(ref *coreapi.SecretReference, defaultSecretName, credential string) *coreapplyv1.SecretKeySelectorApplyConfiguration {
+	if ref == nil {
+		return coreapplyv1.SecretKeySelector().WithName(defaultSecretName).WithKey(credential).WithOptional(true)
+	}
+	_, mapped := ref.Keys[credential]
+	return coreapplyv1.SecretKeySelector().WithName(ref.Name).WithKey(secretKey(ref, credential)).
+		WithOptional(!mapped && optionalCredentialKeys.Has(credential))
+}
+
+// SourceClaimName returns the name of the PersistentVolumeClaim storing the model of pvc:// source,
+// which is supposed to exist in the namespace of the workloads, empty for other sources.
+func SourceClaimName(model *coreapi.OpenModel) string {
+	if model.Spec.Source.URI == nil {
+		return ""
+	}
+	provider, err := NewModelSourceProvider(model)
+	if err != nil {
+		return ""
+	}
+	if provider, ok := provider.(*URIProvider); ok && provider.protocol == PVC {
+		return provider.volume
+	}
+	return ""
+}
+
 // InjectModelVolume mounts the model-volume to the pod template
 // The logic for mounting model-volume to model-runner container is identical in both ModelHubProvider and URIProvider,
 // so this function can be reused and only needs to be configured once
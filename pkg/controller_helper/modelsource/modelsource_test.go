@@ -17,10 +17,13 @@ limitations under the License.
 package modelSource
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	coreapplyv1 "k8s.io/client-go/applyconfigurations/core/v1"
+	"k8s.io/utils/ptr"
 
 	coreapi "github.com/inftyai/llmaz/api/core/v1alpha1"
 	"github.com/inftyai/llmaz/test/util"
@@ -77,11 +80,49 @@ func TestModelSourceProvider(t *testing.T) {
 			wantModelPath:   "/workspace/models/weight.gguf",
 			skipModelLoader: false,
 		},
+		{
+			name:            "URI with https file",
+			model:           wrapper.MakeModel("test-7b").FamilyName("test").ModelSourceWithURI("https://example.com/models/weight.safetensors?download=true#sha256=3f786850e387550fdab836ed7e6dc881de23001b3f786850e387550fdab836ed").Obj(),
+			wantModelName:   "test-7b",
+			wantModelPath:   "/workspace/models/weight.safetensors",
+			skipModelLoader: false,
+		},
+		{
+			name:            "URI with https file and skipModelLoader is true",
+			model:           wrapper.MakeModel("test-7b").FamilyName("test").ModelSourceWithURI("https://example.com/models/weight.gguf#sha256=3f786850e387550fdab836ed7e6dc881de23001b3f786850e387550fdab836ed").Obj(),
+			wantModelName:   "test-7b",
+			wantModelPath:   "https://example.com/models/weight.gguf",
+			skipModelLoader: true,
+		},
+		{
+			name:            "URI with pvc and skipModelLoader is true",
+			model:           wrapper.MakeModel("test-7b").FamilyName("test").ModelSourceWithURI("pvc://models/llama3/Meta-Llama-3-8B").Obj(),
+			wantModelName:   "test-7b",
+			wantModelPath:   "/workspace/models/models--Meta-Llama-3-8B",
+			skipModelLoader: true,
+		},
+		{
+			name:            "URI with azure blob",
+			model:           wrapper.MakeModel("test-7b").FamilyName("test").ModelSourceWithURI("azblob://account/container/models/Meta-Llama-3-8B").Obj(),
+			wantModelName:   "test-7b",
+			wantModelPath:   "/workspace/models/models--Meta-Llama-3-8B",
+			skipModelLoader: false,
+		},
+		{
+			name:            "URI with oci artifact",
+			model:           wrapper.MakeModel("test-7b").FamilyName("test").ModelSourceWithURI("oci://ghcr.io/inftyai/qwen2:0.5b").Obj(),
+			wantModelName:   "test-7b",
+			wantModelPath:   "/workspace/models/models--qwen2",
+			skipModelLoader: false,
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			provider := NewModelSourceProvider(tc.model)
+			provider, err := NewModelSourceProvider(tc.model)
+			if err != nil {
+				t.Fatal(err)
+			}
 			if tc.wantModelName != provider.ModelName() {
 				t.Fatalf("unexpected model name, want %s, got %s", tc.wantModelName, provider.ModelName())
 			}
@@ -92,6 +133,30 @@ func TestModelSourceProvider(t *testing.T) {
 	}
 }
 
+func TestModelSourceProviderNotSupported(t *testing.T) {
+	testCases := []struct {
+		name  string
+		model *coreapi.OpenModel
+	}{
+		{
+			name:  "unknown protocol",
+			model: wrapper.MakeModel("test-7b").FamilyName("test").ModelSourceWithURI("ftp://example.com/models/test-7b").Obj(),
+		},
+		{
+			name:  "source not set",
+			model: wrapper.MakeModel("test-7b").FamilyName("test").Obj(),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			provider, err := NewModelSourceProvider(tc.model)
+			assert.Nil(t, provider)
+			assert.Error(t, err)
+		})
+	}
+}
+
 func TestEnvInjectModelLoader(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -226,3 +291,352 @@ func TestInjectModelEnvVars(t *testing.T) {
 		})
 	}
 }
+
+func TestURIProvider_InjectModelLoader(t *testing.T) {
+	tests := []struct {
+		name           string
+		model          *coreapi.OpenModel
+		wantEnvs       map[string]string
+		wantSecretEnvs map[string]string
+		wantVolume     bool
+	}{
+		{
+			name:  "s3",
+			model: wrapper.MakeModel("llama3-8b").ModelSourceWithURI("s3://bucket/models/llama3-8b").Obj(),
+			wantEnvs: map[string]string{
+				"MODEL_SOURCE_TYPE": MODEL_SOURCE_MODEL_OBJ_STORE,
+				"PROVIDER":          S3,
+				"BUCKET":            "bucket",
+				"MODEL_PATH":        "models/llama3-8b",
+			},
+			wantSecretEnvs: map[string]string{
+				AWS_ACCESS_KEY_ID:       AWS_ACCESS_SECRET_NAME,
+				AWS_ACCESS_KEY_SECRET:   AWS_ACCESS_SECRET_NAME,
+				AWS_SESSION_TOKEN:       AWS_ACCESS_SECRET_NAME,
+				AWS_REGION:              AWS_ACCESS_SECRET_NAME,
+				AWS_ENDPOINT_URL:        AWS_ACCESS_SECRET_NAME,
+				AWS_S3_ADDRESSING_STYLE: AWS_ACCESS_SECRET_NAME,
+			},
+		},
+		{
+			name:  "gcs",
+			model: wrapper.MakeModel("llama3-8b").ModelSourceWithURI("gcs://bucket/models/llama3-8b").Obj(),
+			wantEnvs: map[string]string{
+				"MODEL_SOURCE_TYPE":  MODEL_SOURCE_MODEL_OBJ_STORE,
+				"PROVIDER":           GCS,
+				"BUCKET":             "bucket",
+				"MODEL_PATH":         "models/llama3-8b",
+				GCS_CREDENTIALS_FILE: "/var/run/secrets/llmaz/gcs/service-account.json",
+			},
+			wantVolume: true,
+		},
+		{
+			name:  "azblob",
+			model: wrapper.MakeModel("llama3-8b").ModelSourceWithURI("abfss://container@account.dfs.core.windows.net/models/llama3-8b").Obj(),
+			wantEnvs: map[string]string{
+				"MODEL_SOURCE_TYPE": MODEL_SOURCE_MODEL_OBJ_STORE,
+				"PROVIDER":          Azure,
+				"ENDPOINT":          "https://account.blob.core.windows.net",
+				"BUCKET":            "container",
+				"MODEL_PATH":        "models/llama3-8b",
+			},
+			wantSecretEnvs: map[string]string{
+				AZURE_STORAGE_ACCOUNT_KEY: AZURE_ACCESS_SECRET_NAME,
+				AZURE_STORAGE_SAS_TOKEN:   AZURE_ACCESS_SECRET_NAME,
+				AZURE_CLIENT_ID:           AZURE_ACCESS_SECRET_NAME,
+				AZURE_TENANT_ID:           AZURE_ACCESS_SECRET_NAME,
+				AZURE_CLIENT_SECRET:       AZURE_ACCESS_SECRET_NAME,
+			},
+		},
+		{
+			name:  "https",
+			model: wrapper.MakeModel("qwen2").ModelSourceWithURI("https://example.com/models/qwen2.gguf#sha256=3f786850e387550fdab836ed7e6dc881de23001b3f786850e387550fdab836ed").Obj(),
+			wantEnvs: map[string]string{
+				"MODEL_SOURCE_TYPE": MODEL_SOURCE_HTTP,
+				"MODEL_URL":         "https://example.com/models/qwen2.gguf",
+				"MODEL_CHECKSUM":    "3f786850e387550fdab836ed7e6dc881de23001b3f786850e387550fdab836ed",
+			},
+			wantSecretEnvs: map[string]string{
+				HTTP_AUTH_HEADER: HTTP_ACCESS_SECRET_NAME,
+			},
+		},
+		{
+			name:  "oci",
+			model: wrapper.MakeModel("qwen2").ModelSourceWithURI("oci://ghcr.io/inftyai/qwen2@sha256:3f786850e387550fdab836ed7e6dc881de23001b3f786850e387550fdab836ed").Obj(),
+			wantEnvs: map[string]string{
+				"MODEL_SOURCE_TYPE": MODEL_SOURCE_OCI,
+				"ENDPOINT":          "ghcr.io",
+				"MODEL_PATH":        "inftyai/qwen2",
+				"REFERENCE":         "sha256:3f786850e387550fdab836ed7e6dc881de23001b3f786850e387550fdab836ed",
+			},
+			wantSecretEnvs: map[string]string{
+				OCI_USERNAME: OCI_ACCESS_SECRET_NAME,
+				OCI_PASSWORD: OCI_ACCESS_SECRET_NAME,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template := coreapplyv1.PodTemplateSpec().WithSpec(coreapplyv1.PodSpec().WithContainers(
+				coreapplyv1.Container().WithName(MODEL_RUNNER_CONTAINER_NAME).WithEnv(
+					// Set by users already, should not be overwritten.
+					coreapplyv1.EnvVar().WithName(AWS_REGION).WithValue("us-west-2"),
+				),
+			))
+			provider, err := NewModelSourceProvider(tt.model)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// Multiple models share the credentials volume.
+			provider.InjectModelLoader(template, 0, "model-loader:latest")
+			provider.InjectModelLoader(template, 1, "model-loader:latest")
+
+			envs, secretEnvs := map[string]string{}, map[string]string{}
+			for _, env := range template.Spec.InitContainers[0].Env {
+				if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil {
+					secretEnvs[*env.Name] = *env.ValueFrom.SecretKeyRef.Name
+				} else if *env.Name != AWS_REGION {
+					envs[*env.Name] = *env.Value
+				}
+			}
+			assert.Equal(t, tt.wantEnvs, envs)
+			delete(tt.wantSecretEnvs, AWS_REGION)
+			if len(tt.wantSecretEnvs) == 0 {
+				tt.wantSecretEnvs = map[string]string{}
+			}
+			assert.Equal(t, tt.wantSecretEnvs, secretEnvs)
+
+			var volumes int
+			for _, volume := range template.Spec.Volumes {
+				if *volume.Name == GCS_CREDENTIALS_VOLUME_NAME {
+					volumes++
+					assert.Equal(t, GCS_ACCESS_SECRET_NAME, *volume.Secret.SecretName)
+				}
+			}
+			if tt.wantVolume {
+				assert.Equal(t, 1, volumes)
+			} else {
+				assert.Equal(t, 0, volumes)
+			}
+		})
+	}
+}
+
+func TestInjectModelEnvVars_GCS(t *testing.T) {
+	template := coreapplyv1.PodTemplateSpec().WithSpec(coreapplyv1.PodSpec().WithContainers(
+		coreapplyv1.Container().WithName(MODEL_RUNNER_CONTAINER_NAME),
+	))
+	provider := &URIProvider{modelName: "test-model", protocol: GCS}
+	provider.InjectModelEnvVars(template)
+	// AWS credentials don't work for GCS.
+	assert.Empty(t, template.Spec.Containers[0].Env)
+}
+
+func TestURIProvider_InjectSharedStorage(t *testing.T) {
+	tests := []struct {
+		name          string
+		model         *coreapi.OpenModel
+		wantVolume    *coreapplyv1.VolumeApplyConfiguration
+		wantMountPath string
+		wantSubPath   *string
+	}{
+		{
+			name:  "pvc",
+			model: wrapper.MakeModel("llama3-8b").ModelSourceWithURI("pvc://models/llama3/Meta-Llama-3-8B").Obj(),
+			wantVolume: coreapplyv1.Volume().WithPersistentVolumeClaim(coreapplyv1.PersistentVolumeClaimVolumeSource().
+				WithClaimName("models").WithReadOnly(true)),
+			wantMountPath: "/workspace/models/models--Meta-Llama-3-8B",
+			wantSubPath:   ptr.To("llama3/Meta-Llama-3-8B"),
+		},
+		{
+			name:  "nfs directory",
+			model: wrapper.MakeModel("llama3-8b").ModelSourceWithURI("nfs://10.0.0.1/exports/Meta-Llama-3-8B").Obj(),
+			wantVolume: coreapplyv1.Volume().WithNFS(coreapplyv1.NFSVolumeSource().
+				WithServer("10.0.0.1").WithPath("/exports/Meta-Llama-3-8B").WithReadOnly(true)),
+			wantMountPath: "/workspace/models/models--Meta-Llama-3-8B",
+		},
+		{
+			name:  "nfs gguf file",
+			model: wrapper.MakeModel("qwen2").ModelSourceWithURI("nfs://10.0.0.1/exports/qwen2.gguf").Obj(),
+			wantVolume: coreapplyv1.Volume().WithNFS(coreapplyv1.NFSVolumeSource().
+				WithServer("10.0.0.1").WithPath("/exports").WithReadOnly(true)),
+			wantMountPath: "/workspace/models/qwen2.gguf",
+			wantSubPath:   ptr.To("qwen2.gguf"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template := coreapplyv1.PodTemplateSpec().WithSpec(coreapplyv1.PodSpec().WithContainers(
+				coreapplyv1.Container().WithName(MODEL_RUNNER_CONTAINER_NAME),
+			))
+			provider, err := NewModelSourceProvider(tt.model)
+			if err != nil {
+				t.Fatal(err)
+			}
+			provider.InjectModelLoader(template, 0, "model-loader:latest")
+			// Mounted as well once the model loader is skipped, but only once.
+			provider.InjectModelEnvVars(template)
+
+			assert.Empty(t, template.Spec.InitContainers)
+			assert.Len(t, template.Spec.Volumes, 1)
+			volume := template.Spec.Volumes[0]
+			assert.True(t, strings.HasPrefix(*volume.Name, MODEL_SOURCE_VOLUME_NAME+"-"))
+			tt.wantVolume.Name = volume.Name
+			assert.Equal(t, *tt.wantVolume, volume)
+
+			mounts := template.Spec.Containers[0].VolumeMounts
+			assert.Len(t, mounts, 1)
+			assert.Equal(t, *volume.Name, *mounts[0].Name)
+			assert.Equal(t, tt.wantMountPath, *mounts[0].MountPath)
+			assert.Equal(t, tt.wantSubPath, mounts[0].SubPath)
+			assert.True(t, *mounts[0].ReadOnly)
+		})
+	}
+}
+
+func TestInjectSecretRef(t *testing.T) {
+	tests := []struct {
+		name           string
+		model          *coreapi.OpenModel
+		wantSecretEnvs map[string]*corev1.SecretKeySelector
+	}{
+		{
+			name: "modelhub with the default secret",
+			model: wrapper.MakeModel("llama3-8b").ModelSourceWithModelHub("Huggingface").
+				ModelSourceWithModelID("meta-llama/Meta-Llama-3-8B", "", "", nil, nil).Obj(),
+			wantSecretEnvs: map[string]*corev1.SecretKeySelector{
+				HUGGING_FACE_HUB_TOKEN: {LocalObjectReference: corev1.LocalObjectReference{Name: MODELHUB_SECRET_NAME}, Key: HUGGING_FACE_TOKEN_KEY, Optional: ptr.To(true)},
+				HUGGING_FACE_TOKEN_KEY: {LocalObjectReference: corev1.LocalObjectReference{Name: MODELHUB_SECRET_NAME}, Key: HUGGING_FACE_TOKEN_KEY, Optional: ptr.To(true)},
+			},
+		},
+		{
+			name: "modelhub with the mapped key",
+			model: wrapper.MakeModel("llama3-8b").ModelSourceWithModelHub("Huggingface").
+				ModelSourceWithModelID("meta-llama/Meta-Llama-3-8B", "", "", nil, nil).
+				ModelSourceSecretRef("llama3-secret", map[string]string{HUGGING_FACE_TOKEN_KEY: "token"}).Obj(),
+			wantSecretEnvs: map[string]*corev1.SecretKeySelector{
+				HUGGING_FACE_HUB_TOKEN: {LocalObjectReference: corev1.LocalObjectReference{Name: "llama3-secret"}, Key: "token", Optional: ptr.To(false)},
+				HUGGING_FACE_TOKEN_KEY: {LocalObjectReference: corev1.LocalObjectReference{Name: "llama3-secret"}, Key: "token", Optional: ptr.To(false)},
+			},
+		},
+		{
+			name: "oss with the keys not mapped",
+			model: wrapper.MakeModel("llama3-8b").ModelSourceWithURI("oss://bucket.endpoint/models/llama3-8b").
+				ModelSourceSecretRef("oss-secret", map[string]string{OSS_ACCESS_KEY_ID: "id"}).Obj(),
+			wantSecretEnvs: map[string]*corev1.SecretKeySelector{
+				OSS_ACCESS_KEY_ID:     {LocalObjectReference: corev1.LocalObjectReference{Name: "oss-secret"}, Key: "id", Optional: ptr.To(false)},
+				OSS_ACCESS_KEY_SECRET: {LocalObjectReference: corev1.LocalObjectReference{Name: "oss-secret"}, Key: OSS_ACCESS_KEY_SECRET, Optional: ptr.To(false)},
+			},
+		},
+		{
+			name: "s3 with the optional keys",
+			model: wrapper.MakeModel("llama3-8b").ModelSourceWithURI("s3://bucket/models/llama3-8b").
+				ModelSourceSecretRef("s3-secret", map[string]string{AWS_REGION: "region"}).Obj(),
+			wantSecretEnvs: map[string]*corev1.SecretKeySelector{
+				AWS_ACCESS_KEY_ID:       {LocalObjectReference: corev1.LocalObjectReference{Name: "s3-secret"}, Key: AWS_ACCESS_KEY_ID, Optional: ptr.To(false)},
+				AWS_ACCESS_KEY_SECRET:   {LocalObjectReference: corev1.LocalObjectReference{Name: "s3-secret"}, Key: AWS_ACCESS_KEY_SECRET, Optional: ptr.To(false)},
+				AWS_SESSION_TOKEN:       {LocalObjectReference: corev1.LocalObjectReference{Name: "s3-secret"}, Key: AWS_SESSION_TOKEN, Optional: ptr.To(true)},
+				AWS_REGION:              {LocalObjectReference: corev1.LocalObjectReference{Name: "s3-secret"}, Key: "region", Optional: ptr.To(false)},
+				AWS_ENDPOINT_URL:        {LocalObjectReference: corev1.LocalObjectReference{Name: "s3-secret"}, Key: AWS_ENDPOINT_URL, Optional: ptr.To(true)},
+				AWS_S3_ADDRESSING_STYLE: {LocalObjectReference: corev1.LocalObjectReference{Name: "s3-secret"}, Key: AWS_S3_ADDRESSING_STYLE, Optional: ptr.To(true)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template := coreapplyv1.PodTemplateSpec().WithSpec(coreapplyv1.PodSpec().WithContainers(
+				coreapplyv1.Container().WithName(MODEL_RUNNER_CONTAINER_NAME),
+			))
+			provider, err := NewModelSourceProvider(tt.model)
+			if err != nil {
+				t.Fatal(err)
+			}
+			provider.InjectModelLoader(template, 0, "model-loader:latest")
+			provider.InjectModelEnvVars(template)
+
+			for _, container := range []coreapplyv1.ContainerApplyConfiguration{template.Spec.InitContainers[0], template.Spec.Containers[0]} {
+				secretEnvs := map[string]*corev1.SecretKeySelector{}
+				for _, env := range container.Env {
+					if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil {
+						ref := env.ValueFrom.SecretKeyRef
+						secretEnvs[*env.Name] = &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: *ref.Name}, Key: *ref.Key, Optional: ref.Optional}
+					}
+				}
+				assert.Equal(t, tt.wantSecretEnvs, secretEnvs, "container %s", *container.Name)
+			}
+		})
+	}
+}
+
+func TestRequiredCredentialKeys(t *testing.T) {
+	tests := []struct {
+		name  string
+		model *coreapi.OpenModel
+		want  []string
+	}{
+		{
+			name: "default secret",
+			model: wrapper.MakeModel("llama3-8b").ModelSourceWithModelHub("Huggingface").
+				ModelSourceWithModelID("meta-llama/Meta-Llama-3-8B", "", "", nil, nil).Obj(),
+		},
+		{
+			name: "modelhub",
+			model: wrapper.MakeModel("llama3-8b").ModelSourceWithModelHub("Huggingface").
+				ModelSourceWithModelID("meta-llama/Meta-Llama-3-8B", "", "", nil, nil).ModelSourceSecretRef("llama3-secret", nil).Obj(),
+			want: []string{HUGGING_FACE_TOKEN_KEY},
+		},
+		{
+			name:  "s3 with the optional key mapped",
+			model: wrapper.MakeModel("llama3-8b").ModelSourceWithURI("s3://bucket/models/llama3-8b").ModelSourceSecretRef("s3-secret", map[string]string{AWS_REGION: "region"}).Obj(),
+			want:  []string{AWS_ACCESS_KEY_ID, AWS_ACCESS_KEY_SECRET, AWS_REGION},
+		},
+		{
+			name:  "azure credentials are alternatives",
+			model: wrapper.MakeModel("llama3-8b").ModelSourceWithURI("azblob://account/container/models/llama3-8b").ModelSourceSecretRef("azure-secret", nil).Obj(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, RequiredCredentialKeys(tt.model))
+		})
+	}
+}
+
+func TestInjectGCSCredentialsVolume(t *testing.T) {
+	template := coreapplyv1.PodTemplateSpec().WithSpec(coreapplyv1.PodSpec().WithContainers(
+		coreapplyv1.Container().WithName(MODEL_RUNNER_CONTAINER_NAME),
+	))
+	models := []*coreapi.OpenModel{
+		wrapper.MakeModel("llama3-8b").ModelSourceWithURI("gcs://bucket/models/llama3-8b").Obj(),
+		wrapper.MakeModel("qwen2-7b").ModelSourceWithURI("gcs://bucket/models/qwen2-7b").
+			ModelSourceSecretRef("gcs-secret", map[string]string{GCS_SERVICE_ACCOUNT_KEY: "key.json"}).Obj(),
+		wrapper.MakeModel("qwen2-72b").ModelSourceWithURI("gcs://bucket/models/qwen2-72b").
+			ModelSourceSecretRef("gcs-secret", map[string]string{GCS_SERVICE_ACCOUNT_KEY: "key.json"}).Obj(),
+	}
+	for i, model := range models {
+		provider, err := NewModelSourceProvider(model)
+		if err != nil {
+			t.Fatal(err)
+		}
+		provider.InjectModelLoader(template, i, "model-loader:latest")
+	}
+
+	// Models with the same secret share the volume.
+	assert.Len(t, template.Spec.Volumes, 2)
+	assert.Equal(t, GCS_CREDENTIALS_VOLUME_NAME, *template.Spec.Volumes[0].Name)
+	assert.Equal(t, GCS_ACCESS_SECRET_NAME, *template.Spec.Volumes[0].Secret.SecretName)
+	assert.True(t, *template.Spec.Volumes[0].Secret.Optional)
+
+	volume := template.Spec.Volumes[1]
+	assert.Equal(t, "gcs-secret", *volume.Secret.SecretName)
+	assert.False(t, *volume.Secret.Optional)
+	assert.Equal(t, "key.json", *volume.Secret.Items[0].Key)
+	assert.Equal(t, GCS_SERVICE_ACCOUNT_KEY, *volume.Secret.Items[0].Path)
+	for i, volumeName := range []string{GCS_CREDENTIALS_VOLUME_NAME, *volume.Name, *volume.Name} {
+		assert.Equal(t, volumeName, *template.Spec.InitContainers[i].VolumeMounts[1].Name)
+	}
+}
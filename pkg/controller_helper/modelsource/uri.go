@@ -17,10 +17,19 @@ limitations under the License.
 package modelSource
 
 import (
+	"fmt"
+	"hash/fnv"
+	"net/url"
+	"path"
+	"slices"
 	"strconv"
 	"strings"
 
 	coreapplyv1 "k8s.io/client-go/applyconfigurations/core/v1"
+	"k8s.io/utils/ptr"
+
+	coreapi "github.com/inftyai/llmaz/api/core/v1alpha1"
+	"github.com/inftyai/llmaz/pkg/util"
 )
 
 var _ ModelSourceProvider = &URIProvider{}
@@ -31,6 +40,16 @@ const (
 	S3       = "S3"
 	Ollama   = "OLLAMA"
 	HostPath = "HOST"
+	HTTPS    = "HTTPS"
+	OCI      = "OCI"
+	PVC      = "PVC"
+	NFS      = "NFS"
+	// AZBLOB and ABFSS both refer to Azure Blob Storage, ABFSS is the
+	// URI scheme of Azure Data Lake Storage Gen2.
+	AZBLOB = "AZBLOB"
+	ABFSS  = "ABFSS"
+	// Azure is the provider of Azure Blob Storage for the model loader.
+	Azure = "AZURE"
 )
 
 type URIProvider struct {
@@ -40,6 +59,16 @@ type URIProvider struct {
 	endpoint  string
 	modelPath string
 	uri       string
+	// checksum is the sha256 of the file downloaded with https.
+	checksum string
+	// reference is the tag or digest of the OCI artifact.
+	reference string
+	// volume is the claim name of PVC or the server of NFS.
+	volume string
+	// secretRef refers to the secret of the credentials, the default one is used if nil.
+	secretRef *coreapi.SecretReference
+	// checksums are the expected sha256 of the model files.
+	checksums map[string]string
 }
 
 func (p *URIProvider) ModelName() string {
@@ -65,11 +94,20 @@ func (p *URIProvider) ModelPath(skipModelLoader bool) string {
 
 	// Skip the model loader to allow the inference engine to handle loading models directly from remote storage (e.g., S3, OSS).
 	// In this case, the remote model path should be returned (e.g., s3://bucket/modelPath).
-	if skipModelLoader {
+	// Models on the shared storage are mounted in place even if the model loader is skipped.
+	if skipModelLoader && !p.sharedStorage() {
+		if p.protocol == HTTPS {
+			// Without the checksum fragment.
+			return "https://" + p.modelPath
+		}
 		return p.uri
 	}
 
-	// protocol is oss.
+	if p.protocol == HTTPS {
+		return CONTAINER_MODEL_PATH + httpsFileName(p.modelPath)
+	}
+
+	// protocol is one of the object stores or the shared storages.
 	splits := strings.Split(p.modelPath, "/")
 
 	if strings.Contains(p.modelPath, ".gguf") {
@@ -105,6 +143,11 @@ func (p *URIProvider) InjectModelLoader(template *coreapplyv1.PodTemplateSpecApp
 		return
 	}
 
+	if p.sharedStorage() {
+		p.injectSharedStorage(template)
+		return
+	}
+
 	// Other protocols.
 	initContainerName := MODEL_LOADER_CONTAINER_NAME
 	if index != 0 {
@@ -132,67 +175,203 @@ func (p *URIProvider) InjectModelLoader(template *coreapplyv1.PodTemplateSpecApp
 			coreapplyv1.EnvVar().WithName("ENDPOINT").WithValue(p.endpoint),
 			coreapplyv1.EnvVar().WithName("BUCKET").WithValue(p.bucket),
 			coreapplyv1.EnvVar().WithName("MODEL_PATH").WithValue(p.modelPath),
-			coreapplyv1.EnvVar().WithName(OSS_ACCESS_KEY_ID).WithValueFrom(coreapplyv1.EnvVarSource().WithSecretKeyRef(coreapplyv1.SecretKeySelector().WithName(OSS_ACCESS_SECRET_NAME).WithKey(OSS_ACCESS_KEY_ID).WithOptional(true))),
-			coreapplyv1.EnvVar().WithName(OSS_ACCESS_KEY_SECRET).WithValueFrom(coreapplyv1.EnvVarSource().WithSecretKeyRef(coreapplyv1.SecretKeySelector().WithName(OSS_ACCESS_SECRET_NAME).WithKey(OSS_ACCESS_KEY_SECRET).WithOptional(true))),
 		)
+		injectSecretEnvs(initContainer, p.secretRef, OSS_ACCESS_SECRET_NAME, OSS_ACCESS_KEY_ID, OSS_ACCESS_KEY_SECRET)
+	case S3:
+		initContainer.WithEnv(
+			coreapplyv1.EnvVar().WithName("MODEL_SOURCE_TYPE").WithValue(MODEL_SOURCE_MODEL_OBJ_STORE),
+			coreapplyv1.EnvVar().WithName("PROVIDER").WithValue(S3),
+			coreapplyv1.EnvVar().WithName("BUCKET").WithValue(p.bucket),
+			coreapplyv1.EnvVar().WithName("MODEL_PATH").WithValue(p.modelPath),
+		)
+		injectSecretEnvs(initContainer, p.secretRef, AWS_ACCESS_SECRET_NAME, awsSecretKeys...)
+	case GCS:
+		initContainer.WithEnv(
+			coreapplyv1.EnvVar().WithName("MODEL_SOURCE_TYPE").WithValue(MODEL_SOURCE_MODEL_OBJ_STORE),
+			coreapplyv1.EnvVar().WithName("PROVIDER").WithValue(GCS),
+			coreapplyv1.EnvVar().WithName("BUCKET").WithValue(p.bucket),
+			coreapplyv1.EnvVar().WithName("MODEL_PATH").WithValue(p.modelPath),
+			coreapplyv1.EnvVar().WithName(GCS_CREDENTIALS_FILE).WithValue(GCS_CREDENTIALS_MOUNT_PATH+GCS_SERVICE_ACCOUNT_KEY),
+		)
+		initContainer.WithVolumeMounts(coreapplyv1.VolumeMount().
+			WithName(injectGCSCredentialsVolume(template, p.secretRef)).
+			WithMountPath(GCS_CREDENTIALS_MOUNT_PATH).
+			WithReadOnly(true))
+	case AZBLOB, ABFSS:
+		initContainer.WithEnv(
+			coreapplyv1.EnvVar().WithName("MODEL_SOURCE_TYPE").WithValue(MODEL_SOURCE_MODEL_OBJ_STORE),
+			coreapplyv1.EnvVar().WithName("PROVIDER").WithValue(Azure),
+			coreapplyv1.EnvVar().WithName("ENDPOINT").WithValue(p.endpoint),
+			coreapplyv1.EnvVar().WithName("BUCKET").WithValue(p.bucket),
+			coreapplyv1.EnvVar().WithName("MODEL_PATH").WithValue(p.modelPath),
+		)
+		injectSecretEnvs(initContainer, p.secretRef, AZURE_ACCESS_SECRET_NAME, azureSecretKeys...)
+	case HTTPS:
+		initContainer.WithEnv(
+			coreapplyv1.EnvVar().WithName("MODEL_SOURCE_TYPE").WithValue(MODEL_SOURCE_HTTP),
+			coreapplyv1.EnvVar().WithName("MODEL_URL").WithValue("https://"+p.modelPath),
+		)
+		if p.checksum != "" {
+			initContainer.WithEnv(coreapplyv1.EnvVar().WithName("MODEL_CHECKSUM").WithValue(p.checksum))
+		}
+		injectSecretEnvs(initContainer, p.secretRef, HTTP_ACCESS_SECRET_NAME, HTTP_AUTH_HEADER)
+	case OCI:
+		initContainer.WithEnv(
+			coreapplyv1.EnvVar().WithName("MODEL_SOURCE_TYPE").WithValue(MODEL_SOURCE_OCI),
+			coreapplyv1.EnvVar().WithName("ENDPOINT").WithValue(p.endpoint),
+			coreapplyv1.EnvVar().WithName("MODEL_PATH").WithValue(p.modelPath),
+			coreapplyv1.EnvVar().WithName("REFERENCE").WithValue(p.reference),
+		)
+		injectSecretEnvs(initContainer, p.secretRef, OCI_ACCESS_SECRET_NAME, OCI_USERNAME, OCI_PASSWORD)
 	}
+	injectChecksums(initContainer, p.checksums, p.ModelPath(false))
 
 	template.Spec.WithInitContainers(initContainer)
 }
 
 func (p *URIProvider) InjectModelEnvVars(template *coreapplyv1.PodTemplateSpecApplyConfiguration) {
+	if p.sharedStorage() {
+		p.injectSharedStorage(template)
+		return
+	}
+
+	// The credentials of GCS, HTTPS and OCI for the inference engines are left to the workloads,
+	// since the way to authenticate differs from engine to engine.
+	for i := range template.Spec.Containers {
+		if *template.Spec.Containers[i].Name != MODEL_RUNNER_CONTAINER_NAME {
+			continue
+		}
+		switch p.protocol {
+		case S3:
+			injectSecretEnvs(&template.Spec.Containers[i], p.secretRef, AWS_ACCESS_SECRET_NAME, awsSecretKeys...)
+		case OSS:
+			injectSecretEnvs(&template.Spec.Containers[i], p.secretRef, OSS_ACCESS_SECRET_NAME, OSS_ACCESS_KEY_ID, OSS_ACCESS_KEY_SECRET)
+		case AZBLOB, ABFSS:
+			injectSecretEnvs(&template.Spec.Containers[i], p.secretRef, AZURE_ACCESS_SECRET_NAME, azureSecretKeys...)
+		}
+	}
+}
+
+// sharedStorage returns whether the model sits on the shared storage, which is mounted
+// to the model-runner container directly without the model loader.
+func (p *URIProvider) sharedStorage() bool {
+	return p.protocol == PVC || p.protocol == NFS
+}
+
+// injectSharedStorage mounts the model on the PVC or NFS read-only to the model-runner container
+// at the model path, the volume is added once even if injected repeatedly.
+func (p *URIProvider) injectSharedStorage(template *coreapplyv1.PodTemplateSpecApplyConfiguration) {
+	hasher := fnv.New32a()
+	_, _ = hasher.Write([]byte(p.modelName))
+	volumeName := fmt.Sprintf("%s-%d", MODEL_SOURCE_VOLUME_NAME, hasher.Sum32())
+	for _, volume := range template.Spec.Volumes {
+		if ptr.Deref(volume.Name, "") == volumeName {
+			return
+		}
+	}
+
+	volume := coreapplyv1.Volume().WithName(volumeName)
+	subPath := p.modelPath
 	switch p.protocol {
-	case S3, GCS:
-		for i := range template.Spec.Containers {
-			if *template.Spec.Containers[i].Name == MODEL_RUNNER_CONTAINER_NAME {
-				// Check if AWS credentials already exist
-				awsKeyIDExists := false
-				awsKeySecretExists := false
-				for _, env := range template.Spec.Containers[i].Env {
-					if *env.Name == AWS_ACCESS_KEY_ID {
-						awsKeyIDExists = true
-					}
-					if *env.Name == AWS_ACCESS_KEY_SECRET {
-						awsKeySecretExists = true
-					}
-				}
-
-				// Add AWS_ACCESS_KEY_ID if it doesn't exist
-				if !awsKeyIDExists {
-					template.Spec.Containers[i].WithEnv(coreapplyv1.EnvVar().WithName(AWS_ACCESS_KEY_ID).WithValueFrom(coreapplyv1.EnvVarSource().WithSecretKeyRef(coreapplyv1.SecretKeySelector().WithName(AWS_ACCESS_SECRET_NAME).WithKey(AWS_ACCESS_KEY_ID).WithOptional(true))))
-				}
-
-				// Add AWS_ACCESS_KEY_SECRET if it doesn't exist
-				if !awsKeySecretExists {
-					template.Spec.Containers[i].WithEnv(coreapplyv1.EnvVar().WithName(AWS_ACCESS_KEY_SECRET).WithValueFrom(coreapplyv1.EnvVarSource().WithSecretKeyRef(coreapplyv1.SecretKeySelector().WithName(AWS_ACCESS_SECRET_NAME).WithKey(AWS_ACCESS_KEY_SECRET).WithOptional(true))))
-				}
-			}
+	case PVC:
+		volume.WithPersistentVolumeClaim(coreapplyv1.PersistentVolumeClaimVolumeSource().
+			WithClaimName(p.volume).
+			WithReadOnly(true))
+	case NFS:
+		// NFS exports directories only, so mount the parent directory of the model file.
+		exportPath := p.modelPath
+		subPath = ""
+		if strings.Contains(p.modelPath, ".gguf") {
+			exportPath, subPath = path.Dir(p.modelPath), path.Base(p.modelPath)
 		}
-	case OSS:
-		for i := range template.Spec.Containers {
-			if *template.Spec.Containers[i].Name == MODEL_RUNNER_CONTAINER_NAME {
-				// Check if OSS credentials already exist
-				ossKeyIDExists := false
-				ossKeySecretExists := false
-				for _, env := range template.Spec.Containers[i].Env {
-					if *env.Name == OSS_ACCESS_KEY_ID {
-						ossKeyIDExists = true
-					}
-					if *env.Name == OSS_ACCESS_KEY_SECRET {
-						ossKeySecretExists = true
-					}
-				}
-
-				// Add OSS_ACCESS_KEY_ID if it doesn't exist
-				if !ossKeyIDExists {
-					template.Spec.Containers[i].WithEnv(coreapplyv1.EnvVar().WithName(OSS_ACCESS_KEY_ID).WithValueFrom(coreapplyv1.EnvVarSource().WithSecretKeyRef(coreapplyv1.SecretKeySelector().WithName(OSS_ACCESS_SECRET_NAME).WithKey(OSS_ACCESS_KEY_ID).WithOptional(true))))
-				}
-
-				// Add OSS_ACCESS_KEY_SECRET if it doesn't exist
-				if !ossKeySecretExists {
-					template.Spec.Containers[i].WithEnv(coreapplyv1.EnvVar().WithName(OSS_ACCESS_KEY_SECRET).WithValueFrom(coreapplyv1.EnvVarSource().WithSecretKeyRef(coreapplyv1.SecretKeySelector().WithName(OSS_ACCESS_SECRET_NAME).WithKey(OSS_ACCESS_KEY_SECRET).WithOptional(true))))
-				}
-			}
+		volume.WithNFS(coreapplyv1.NFSVolumeSource().
+			WithServer(p.volume).
+			WithPath(path.Join("/", exportPath)).
+			WithReadOnly(true))
+	}
+	template.Spec.WithVolumes(volume)
+
+	mount := coreapplyv1.VolumeMount().
+		WithName(volumeName).
+		WithMountPath(p.ModelPath(false)).
+		WithReadOnly(true)
+	if subPath != "" {
+		mount.WithSubPath(subPath)
+	}
+	for i := range template.Spec.Containers {
+		if ptr.Deref(template.Spec.Containers[i].Name, "") == MODEL_RUNNER_CONTAINER_NAME {
+			template.Spec.Containers[i].WithVolumeMounts(mount)
+		}
+	}
+}
+
+// httpsFileName returns the name of the file to download, e.g. model.gguf of
+// example.com/models/model.gguf?download=true.
+func httpsFileName(modelPath string) string {
+	if u, err := url.Parse("https://" + modelPath); err == nil {
+		return path.Base(u.Path)
+	}
+	return path.Base(modelPath)
+}
+
+// awsSecretKeys are the keys of the AWS secret, the session token, region, endpoint and
+// addressing style are optional, which are useful for temporary credentials or MinIO.
+var awsSecretKeys = []string{AWS_ACCESS_KEY_ID, AWS_ACCESS_KEY_SECRET, AWS_SESSION_TOKEN, AWS_REGION, AWS_ENDPOINT_URL, AWS_S3_ADDRESSING_STYLE}
+
+// azureSecretKeys are the keys of the Azure secret, the names follow the environments of the Azure SDKs.
+var azureSecretKeys = []string{AZURE_STORAGE_ACCOUNT_KEY, AZURE_STORAGE_SAS_TOKEN, AZURE_CLIENT_ID, AZURE_TENANT_ID, AZURE_CLIENT_SECRET}
+
+// parseAzure parses the address of Azure Blob Storage in the format of the protocol.
+func parseAzure(protocol, address string) (account, container, modelPath string, err error) {
+	if protocol == ABFSS {
+		return util.ParseABFSS(address)
+	}
+	return util.ParseAzblob(address)
+}
+
+// azureBlobEndpoint returns the blob endpoint of the storage account, ADLS Gen2 accounts serve
+// the blob APIs as well.
+func azureBlobEndpoint(account string) string {
+	return fmt.Sprintf("https://%s.blob.core.windows.net", account)
+}
+
+// injectSecretEnvs injects the credentials as the env vars of the container with the same names, selected
+// by secretKeySelector from the referenced secret or the default one, env vars set already will not be overwritten.
+func injectSecretEnvs(container *coreapplyv1.ContainerApplyConfiguration, ref *coreapi.SecretReference, secretName string, keys ...string) {
+	for _, key := range keys {
+		if slices.ContainsFunc(container.Env, func(env coreapplyv1.EnvVarApplyConfiguration) bool {
+			return ptr.Deref(env.Name, "") == key
+		}) {
+			continue
+		}
+		container.WithEnv(coreapplyv1.EnvVar().WithName(key).WithValueFrom(coreapplyv1.EnvVarSource().
+			WithSecretKeyRef(secretKeySelector(ref, secretName, key))))
+	}
+}
+
+// injectGCSCredentialsVolume adds the volume of the GCS service account key once, which is shared by
+// the model loaders of the models with the same secret, and returns the name of the volume.
+func injectGCSCredentialsVolume(template *coreapplyv1.PodTemplateSpecApplyConfiguration, ref *coreapi.SecretReference) string {
+	volumeName := GCS_CREDENTIALS_VOLUME_NAME
+	source := coreapplyv1.SecretVolumeSource().WithSecretName(GCS_ACCESS_SECRET_NAME).WithOptional(true)
+	if ref != nil {
+		key := secretKey(ref, GCS_SERVICE_ACCOUNT_KEY)
+		hasher := fnv.New32a()
+		_, _ = hasher.Write([]byte(ref.Name + "/" + key))
+		volumeName = fmt.Sprintf("%s-%d", GCS_CREDENTIALS_VOLUME_NAME, hasher.Sum32())
+
+		// The service account key is required in the referenced secret, see secretKeySelector.
+		source = coreapplyv1.SecretVolumeSource().
+			WithSecretName(ref.Name).
+			WithItems(coreapplyv1.KeyToPath().WithKey(key).WithPath(GCS_SERVICE_ACCOUNT_KEY)).
+			WithOptional(false)
+	}
+
+	for _, volume := range template.Spec.Volumes {
+		if ptr.Deref(volume.Name, "") == volumeName {
+			return volumeName
 		}
 	}
+	template.Spec.WithVolumes(coreapplyv1.Volume().WithName(volumeName).WithSecret(source))
+	return volumeName
 }
@@ -0,0 +1,819 @@
+/*
+Copyright 2025 The InftyAI Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modelSource
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"k8s.io/utils/ptr"
+
+	coreapi "github.com/inftyai/llmaz/api/core/v1alpha1"
+	"github.com/inftyai/llmaz/pkg/util"
+)
+
+// The reasons of the SourceError.
+const (
+	ReasonModelNotFound  = "ModelNotFound"
+	ReasonUnauthorized   = "Unauthorized"
+	ReasonSecretNotFound = "SecretNotFound"
+	ReasonInvalidSource  = "InvalidSource"
+)
+
+const (
+	defaultValidateTimeout = 30 * time.Second
+	defaultAWSRegion       = "us-east-1"
+	azureAPIVersion        = "2021-08-06"
+)
+
+// SourceError represents the model source is unavailable, retrying will not help
+// until the model or the credentials are changed.
+type SourceError struct {
+	// Reason is a brief CamelCase reason, e.g. ModelNotFound.
+	Reason  string
+	Message string
+}
+
+func (e *SourceError) Error() string {
+	return e.Message
+}
+
+// SourceValidator checks whether the model source is available before serving.
+// The endpoints could be overwritten for mirrors or local fake servers.
+type SourceValidator struct {
+	HTTPClient          *http.Client
+	HuggingfaceEndpoint string
+	ModelScopeEndpoint  string
+	OllamaEndpoint      string
+	S3Endpoint          string
+	GCSEndpoint         string
+}
+
+// NewSourceValidator returns a validator with the public endpoints, which could be
+// overwritten by the environments like HF_ENDPOINT.
+func NewSourceValidator() *SourceValidator {
+	return &SourceValidator{
+		HTTPClient:          &http.Client{Timeout: defaultValidateTimeout},
+		HuggingfaceEndpoint: envOrDefault("HF_ENDPOINT", "https://huggingface.co"),
+		ModelScopeEndpoint:  envOrDefault("MODELSCOPE_ENDPOINT", "https://www.modelscope.cn"),
+		OllamaEndpoint:      envOrDefault("OLLAMA_REGISTRY_ENDPOINT", "https://registry.ollama.ai"),
+		S3Endpoint:          envOrDefault("AWS_ENDPOINT_URL", "https://s3.amazonaws.com"),
+		GCSEndpoint:         envOrDefault("GCS_ENDPOINT", "https://storage.googleapis.com"),
+	}
+}
+
+func envOrDefault(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return strings.TrimSuffix(value, "/")
+	}
+	return defaultValue
+}
+
+// CredentialsSecretName returns the name of the secret storing the credentials
+// to access the model source, either referenced by the model or the default one,
+// empty if no credentials are supported.
+func CredentialsSecretName(model *coreapi.OpenModel) string {
+	if model.Spec.Source.SecretRef != nil {
+		return model.Spec.Source.SecretRef.Name
+	}
+	if model.Spec.Source.ModelHub != nil {
+		return MODELHUB_SECRET_NAME
+	}
+	if model.Spec.Source.URI != nil {
+		protocol, _, _ := util.ParseURI(string(*model.Spec.Source.URI))
+		switch protocol {
+		case OSS:
+			return OSS_ACCESS_SECRET_NAME
+		case S3:
+			return AWS_ACCESS_SECRET_NAME
+		case GCS:
+			return GCS_ACCESS_SECRET_NAME
+		case AZBLOB, ABFSS:
+			return AZURE_ACCESS_SECRET_NAME
+		case HTTPS:
+			return HTTP_ACCESS_SECRET_NAME
+		case OCI:
+			return OCI_ACCESS_SECRET_NAME
+		}
+	}
+	return ""
+}
+
+// CredentialsData returns the credentials keyed by the credential names from the data of the
+// secret named by CredentialsSecretName, following the key mapping of the secretRef.
+func CredentialsData(model *coreapi.OpenModel, data map[string][]byte) map[string][]byte {
+	ref := model.Spec.Source.SecretRef
+	if ref == nil || len(ref.Keys) == 0 || data == nil {
+		return data
+	}
+	credentials := map[string][]byte{}
+	for _, credential := range CredentialKeys(model) {
+		if value, ok := data[secretKey(ref, credential)]; ok {
+			credentials[credential] = value
+		}
+	}
+	return credentials
+}
+
+// Validate checks whether the model exists in the source and is accessible. Credentials
+// are the data of the secret named by CredentialsSecretName, nil if not found, see CredentialsData.
+// A *SourceError is returned if the source is unavailable, other errors are transient.
+// Host paths can't be validated out of the nodes, and the claims of PVC live in the namespaces
+// of the workloads, so they're always regarded as available.
+func (v *SourceValidator) Validate(ctx context.Context, model *coreapi.OpenModel, credentials map[string][]byte) error {
+	err := v.validate(ctx, model, credentials)
+	// The credential errors refer to the secret of the model source, which may be referenced by the model.
+	var sourceErr *SourceError
+	if errors.As(err, &sourceErr) {
+		switch sourceErr.Reason {
+		case ReasonSecretNotFound:
+			sourceErr.Message += fmt.Sprintf(", secret %s not found", CredentialsSecretName(model))
+		case ReasonUnauthorized:
+			sourceErr.Message += fmt.Sprintf(" in secret %s", CredentialsSecretName(model))
+		}
+	}
+	return err
+}
+
+func (v *SourceValidator) validate(ctx context.Context, model *coreapi.OpenModel, credentials map[string][]byte) error {
+	if hub := model.Spec.Source.ModelHub; hub != nil {
+		switch ptr.Deref(hub.Name, coreapi.HUGGING_FACE) {
+		case coreapi.HUGGING_FACE:
+			return v.validateHuggingface(ctx, hub, credentials)
+		case coreapi.MODEL_SCOPE:
+			return v.validateModelScope(ctx, hub)
+		}
+		return &SourceError{Reason: ReasonInvalidSource, Message: fmt.Sprintf("unknown model hub %s", *hub.Name)}
+	}
+
+	if model.Spec.Source.URI == nil {
+		return &SourceError{Reason: ReasonInvalidSource, Message: "model source is not set"}
+	}
+	protocol, address, err := util.ParseURI(string(*model.Spec.Source.URI))
+	if err != nil {
+		return &SourceError{Reason: ReasonInvalidSource, Message: err.Error()}
+	}
+
+	switch protocol {
+	case OSS:
+		endpoint, bucket, modelPath, err := util.ParseOSS(address)
+		if err != nil {
+			return &SourceError{Reason: ReasonInvalidSource, Message: err.Error()}
+		}
+		return v.validateOSS(ctx, endpoint, bucket, modelPath, credentials)
+	case S3, GCS:
+		bucket, modelPath, err := util.ParseS3(address)
+		if err != nil {
+			return &SourceError{Reason: ReasonInvalidSource, Message: err.Error()}
+		}
+		if protocol == GCS {
+			// Requests with the service account key require OAuth, leave it to the model loader.
+			if len(credentials[GCS_SERVICE_ACCOUNT_KEY]) > 0 {
+				return nil
+			}
+			return v.validateS3Compatible(ctx, v.GCSEndpoint, "", bucket, modelPath, nil)
+		}
+		return v.validateS3Compatible(ctx, v.s3Endpoint(credentials), "", bucket, modelPath, credentials)
+	case AZBLOB, ABFSS:
+		account, container, modelPath, err := parseAzure(protocol, address)
+		if err != nil {
+			return &SourceError{Reason: ReasonInvalidSource, Message: err.Error()}
+		}
+		return v.validateAzure(ctx, account, container, modelPath, credentials)
+	case HTTPS:
+		modelPath, _, err := util.ParseHTTPS(address)
+		if err != nil {
+			return &SourceError{Reason: ReasonInvalidSource, Message: err.Error()}
+		}
+		return v.validateHTTPS(ctx, "https://"+modelPath, credentials)
+	case OCI:
+		registry, repository, reference, err := util.ParseOCI(address)
+		if err != nil {
+			return &SourceError{Reason: ReasonInvalidSource, Message: err.Error()}
+		}
+		return v.validateOCI(ctx, registry, repository, reference, credentials)
+	case Ollama:
+		return v.validateOllama(ctx, address)
+	case HostPath, PVC, NFS:
+		return nil
+	}
+	return &SourceError{Reason: ReasonInvalidSource, Message: fmt.Sprintf("protocol %s not supported", protocol)}
+}
+
+// s3Endpoint returns the endpoint of S3 from the credentials if set, e.g. for MinIO.
+func (v *SourceValidator) s3Endpoint(credentials map[string][]byte) string {
+	if endpoint := string(credentials[AWS_ENDPOINT_URL]); endpoint != "" {
+		return strings.TrimSuffix(endpoint, "/")
+	}
+	return v.S3Endpoint
+}
+
+// HuggingfaceModelInfo is part of the response of the Huggingface model API.
+type HuggingfaceModelInfo struct {
+	ID       string               `json:"id"`
+	SHA      string               `json:"sha"`
+	Siblings []HuggingfaceSibling `json:"siblings"`
+}
+
+// HuggingfaceSibling represents a file in the Huggingface model repo.
+type HuggingfaceSibling struct {
+	RFilename string `json:"rfilename"`
+}
+
+func (v *SourceValidator) validateHuggingface(ctx context.Context, hub *coreapi.ModelHub, credentials map[string][]byte) error {
+	info, err := v.huggingfaceModelInfo(ctx, hub, credentials)
+	if err != nil {
+		return err
+	}
+	if hub.Filename != nil && !slices.ContainsFunc(info.Siblings, func(sibling HuggingfaceSibling) bool { return sibling.RFilename == *hub.Filename }) {
+		return &SourceError{Reason: ReasonModelNotFound, Message: fmt.Sprintf("file %s not found in model %s", *hub.Filename, hub.ModelID)}
+	}
+	return nil
+}
+
+// huggingfaceModelInfo fetches the info of the model at the revision.
+func (v *SourceValidator) huggingfaceModelInfo(ctx context.Context, hub *coreapi.ModelHub, credentials map[string][]byte) (*HuggingfaceModelInfo, error) {
+	revision := ptr.Deref(hub.Revision, "main")
+	target := fmt.Sprintf("%s/api/models/%s/revision/%s", v.HuggingfaceEndpoint, hub.ModelID, url.PathEscape(revision))
+	token := string(credentials[HUGGING_FACE_TOKEN_KEY])
+	var authorize func(*http.Request)
+	if token != "" {
+		authorize = func(req *http.Request) { req.Header.Set("Authorization", "Bearer "+token) }
+	}
+
+	// Follow the redirections of the renamed repos.
+	resp, err := v.get(ctx, target, authorize, true)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.statusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return nil, unauthorizedError(fmt.Sprintf("model %s", hub.ModelID), authorize != nil)
+	case http.StatusNotFound:
+		message := fmt.Sprintf("model %s with revision %s not found", hub.ModelID, revision)
+		if code := resp.header.Get("X-Error-Code"); code != "" {
+			message = fmt.Sprintf("%s: %s", message, code)
+		}
+		return nil, &SourceError{Reason: ReasonModelNotFound, Message: message}
+	default:
+		return nil, fmt.Errorf("unexpected status code %d from %s", resp.statusCode, target)
+	}
+
+	var info HuggingfaceModelInfo
+	if err := json.Unmarshal(resp.body, &info); err != nil {
+		return nil, fmt.Errorf("failed to decode the model info: %v", err)
+	}
+	return &info, nil
+}
+
+// ResolveRevision returns the commit the revision of the model hub resolves to, only
+// supported with Huggingface. Errors are the same as Validate.
+func (v *SourceValidator) ResolveRevision(ctx context.Context, model *coreapi.OpenModel, credentials map[string][]byte) (string, error) {
+	hub := model.Spec.Source.ModelHub
+	if hub == nil || ptr.Deref(hub.Name, coreapi.HUGGING_FACE) != coreapi.HUGGING_FACE {
+		return "", &SourceError{Reason: ReasonInvalidSource, Message: "only the revisions of Huggingface could be resolved"}
+	}
+	info, err := v.huggingfaceModelInfo(ctx, hub, credentials)
+	if err != nil {
+		return "", err
+	}
+	if info.SHA == "" {
+		return "", fmt.Errorf("no commit found for model %s with revision %s", hub.ModelID, ptr.Deref(hub.Revision, "main"))
+	}
+	return info.SHA, nil
+}
+
+func (v *SourceValidator) validateModelScope(ctx context.Context, hub *coreapi.ModelHub) error {
+	target := fmt.Sprintf("%s/api/v1/models/%s", v.ModelScopeEndpoint, hub.ModelID)
+	resp, err := v.get(ctx, target, nil, true)
+	if err != nil {
+		return err
+	}
+
+	switch resp.statusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return unauthorizedError(fmt.Sprintf("model %s", hub.ModelID), false)
+	case http.StatusNotFound:
+		return &SourceError{Reason: ReasonModelNotFound, Message: fmt.Sprintf("model %s not found", hub.ModelID)}
+	}
+	return fmt.Errorf("unexpected status code %d from %s", resp.statusCode, target)
+}
+
+func (v *SourceValidator) validateOllama(ctx context.Context, name string) error {
+	repository, tag := name, "latest"
+	if i := strings.LastIndex(name, ":"); i != -1 {
+		repository, tag = name[:i], name[i+1:]
+	}
+	if !strings.Contains(repository, "/") {
+		repository = "library/" + repository
+	}
+
+	target := fmt.Sprintf("%s/v2/%s/manifests/%s", v.OllamaEndpoint, repository, tag)
+	resp, err := v.get(ctx, target, nil, true)
+	if err != nil {
+		return err
+	}
+
+	switch resp.statusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusNotFound:
+		return &SourceError{Reason: ReasonModelNotFound, Message: fmt.Sprintf("ollama model %s not found", name)}
+	}
+	return fmt.Errorf("unexpected status code %d from %s", resp.statusCode, target)
+}
+
+// validateHTTPS requests the first byte of the file, the checksum is verified by the model loader.
+func (v *SourceValidator) validateHTTPS(ctx context.Context, target string, credentials map[string][]byte) error {
+	header := string(credentials[HTTP_AUTH_HEADER])
+	sign := func(req *http.Request) {
+		req.Header.Set("Range", "bytes=0-0")
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+	}
+
+	// Follow the redirections, e.g. to the CDNs, the Authorization header will be dropped across domains.
+	resp, err := v.do(ctx, target, sign, true)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusPartialContent:
+		return nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return unauthorizedError(fmt.Sprintf("file %s", target), header != "")
+	case http.StatusNotFound:
+		return &SourceError{Reason: ReasonModelNotFound, Message: fmt.Sprintf("file %s not found", target)}
+	}
+	return fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, target)
+}
+
+// ociManifestMediaTypes are the accepted media types of the manifests, the model files are
+// the layers of the OCI image manifest, the index is resolved by the model loader.
+var ociManifestMediaTypes = []string{
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+// validateOCI fetches the manifest of the artifact following the OCI distribution spec,
+// the anonymous or basic authentications are exchanged for the bearer tokens if challenged.
+func (v *SourceValidator) validateOCI(ctx context.Context, registry, repository, reference string, credentials map[string][]byte) error {
+	username, password := string(credentials[OCI_USERNAME]), string(credentials[OCI_PASSWORD])
+	withCredentials := username != "" || password != ""
+	target := fmt.Sprintf("https://%s/v2/%s/manifests/%s", registry, repository, reference)
+	artifact := fmt.Sprintf("artifact %s/%s:%s", registry, repository, reference)
+
+	accept := func(req *http.Request) { req.Header.Set("Accept", strings.Join(ociManifestMediaTypes, ", ")) }
+	resp, err := v.get(ctx, target, accept, true)
+	if err != nil {
+		return err
+	}
+
+	if resp.statusCode == http.StatusUnauthorized {
+		authorization, err := v.ociAuthorization(ctx, resp.header.Get("WWW-Authenticate"), username, password)
+		if err != nil {
+			return err
+		}
+		if authorization == "" {
+			return unauthorizedError(artifact, withCredentials)
+		}
+		if resp, err = v.get(ctx, target, func(req *http.Request) {
+			accept(req)
+			req.Header.Set("Authorization", authorization)
+		}, true); err != nil {
+			return err
+		}
+	}
+
+	switch resp.statusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return unauthorizedError(artifact, withCredentials)
+	case http.StatusNotFound:
+		return &SourceError{Reason: ReasonModelNotFound, Message: fmt.Sprintf("%s not found", artifact)}
+	}
+	return fmt.Errorf("unexpected status code %d from %s", resp.statusCode, target)
+}
+
+// ociAuthorization returns the Authorization header answering the challenge of the registry,
+// empty if the challenge can't be answered, e.g. basic authentication without credentials.
+func (v *SourceValidator) ociAuthorization(ctx context.Context, challenge, username, password string) (string, error) {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if username == "" && password == "" {
+			return "", nil
+		}
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password)), nil
+	case "bearer":
+	default:
+		return "", nil
+	}
+
+	values := parseChallengeParams(params)
+	realm, err := url.Parse(values["realm"])
+	if err != nil || realm.Host == "" {
+		return "", nil
+	}
+	query := realm.Query()
+	for _, key := range []string{"service", "scope"} {
+		if values[key] != "" {
+			query.Set(key, values[key])
+		}
+	}
+	realm.RawQuery = query.Encode()
+
+	var sign func(*http.Request)
+	if username != "" || password != "" {
+		sign = func(req *http.Request) { req.SetBasicAuth(username, password) }
+	}
+	resp, err := v.get(ctx, realm.String(), sign, true)
+	if err != nil {
+		return "", err
+	}
+	switch resp.statusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return "", nil
+	default:
+		return "", fmt.Errorf("unexpected status code %d from %s", resp.statusCode, realm.Redacted())
+	}
+
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.Unmarshal(resp.body, &token); err != nil {
+		return "", fmt.Errorf("failed to decode the registry token: %v", err)
+	}
+	if token.Token == "" {
+		token.Token = token.AccessToken
+	}
+	if token.Token == "" {
+		return "", nil
+	}
+	return "Bearer " + token.Token, nil
+}
+
+// parseChallengeParams parses the params of the WWW-Authenticate header,
+// e.g. realm="https://ghcr.io/token",service="ghcr.io",scope="repository:a/b:pull".
+func parseChallengeParams(params string) map[string]string {
+	values := map[string]string{}
+	for params != "" {
+		var key, value string
+		key, params, _ = strings.Cut(strings.TrimLeft(params, ", "), "=")
+		if strings.HasPrefix(params, `"`) {
+			value, params, _ = strings.Cut(params[1:], `"`)
+		} else {
+			value, params, _ = strings.Cut(params, ",")
+		}
+		values[strings.ToLower(strings.TrimSpace(key))] = value
+	}
+	return values
+}
+
+type listBucketResult struct {
+	KeyCount *int `xml:"KeyCount"`
+	Contents []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+}
+
+// validateS3Compatible lists the objects under the model path with the S3 ListObjectsV2 API,
+// requests will be signed with AWS Signature Version 4 if credentials provided.
+func (v *SourceValidator) validateS3Compatible(ctx context.Context, endpoint, region, bucket, modelPath string, credentials map[string][]byte) error {
+	if region == "" {
+		region = defaultAWSRegion
+		if value := string(credentials[AWS_REGION]); value != "" {
+			region = value
+		}
+	}
+
+	query := url.Values{}
+	query.Set("list-type", "2")
+	query.Set("max-keys", "1")
+	query.Set("prefix", modelPath)
+	target := fmt.Sprintf("%s/%s?%s", endpoint, bucket, query.Encode())
+
+	accessKeyID, secretKey := string(credentials[AWS_ACCESS_KEY_ID]), string(credentials[AWS_ACCESS_KEY_SECRET])
+	var sign func(*http.Request)
+	if accessKeyID != "" && secretKey != "" {
+		sign = func(req *http.Request) { signV4(req, accessKeyID, secretKey, region, time.Now().UTC()) }
+	}
+
+	// Don't follow the redirections of the object stores, which refer to other regions.
+	resp, err := v.get(ctx, target, sign, false)
+	if err != nil {
+		return err
+	}
+
+	switch resp.statusCode {
+	case http.StatusOK:
+	case http.StatusMovedPermanently:
+		// The bucket lives in another region of AWS.
+		if bucketRegion := resp.header.Get("X-Amz-Bucket-Region"); bucketRegion != "" && bucketRegion != region && endpoint == v.S3Endpoint {
+			return v.validateS3Compatible(ctx, fmt.Sprintf("https://s3.%s.amazonaws.com", bucketRegion), bucketRegion, bucket, modelPath, credentials)
+		}
+		return fmt.Errorf("unexpected redirection from %s", target)
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return unauthorizedError(fmt.Sprintf("bucket %s", bucket), sign != nil)
+	case http.StatusNotFound:
+		return &SourceError{Reason: ReasonModelNotFound, Message: fmt.Sprintf("bucket %s not found", bucket)}
+	default:
+		return fmt.Errorf("unexpected status code %d from %s", resp.statusCode, target)
+	}
+	return v.checkListResult(resp.body, bucket, modelPath)
+}
+
+// validateOSS lists the objects under the model path with the OSS ListObjects API,
+// requests will be signed with the OSS signature if credentials provided.
+func (v *SourceValidator) validateOSS(ctx context.Context, endpoint, bucket, modelPath string, credentials map[string][]byte) error {
+	query := url.Values{}
+	query.Set("max-keys", "1")
+	query.Set("prefix", modelPath)
+	target := fmt.Sprintf("https://%s.%s/?%s", bucket, endpoint, query.Encode())
+
+	accessKeyID, secretKey := string(credentials[OSS_ACCESS_KEY_ID]), string(credentials[OSS_ACCESS_KEY_SECRET])
+	var sign func(*http.Request)
+	if accessKeyID != "" && secretKey != "" {
+		sign = func(req *http.Request) { signOSS(req, accessKeyID, secretKey, "/"+bucket+"/", time.Now().UTC()) }
+	}
+
+	// Don't follow the redirections of the object stores, which refer to other regions.
+	resp, err := v.get(ctx, target, sign, false)
+	if err != nil {
+		return err
+	}
+
+	switch resp.statusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return unauthorizedError(fmt.Sprintf("bucket %s", bucket), sign != nil)
+	case http.StatusNotFound:
+		return &SourceError{Reason: ReasonModelNotFound, Message: fmt.Sprintf("bucket %s not found", bucket)}
+	default:
+		return fmt.Errorf("unexpected status code %d from %s", resp.statusCode, target)
+	}
+	return v.checkListResult(resp.body, bucket, modelPath)
+}
+
+type azureListBlobsResult struct {
+	Blobs struct {
+		Blob []struct {
+			Name string `xml:"Name"`
+		} `xml:"Blob"`
+	} `xml:"Blobs"`
+}
+
+// validateAzure lists the blobs under the model path with the Azure List Blobs API, requests are
+// signed with the account key or authorized by the SAS token if provided. The service principals
+// and workload identities require OAuth, which are left to the model loader.
+func (v *SourceValidator) validateAzure(ctx context.Context, account, container, modelPath string, credentials map[string][]byte) error {
+	accountKey, sasToken := string(credentials[AZURE_STORAGE_ACCOUNT_KEY]), strings.TrimPrefix(string(credentials[AZURE_STORAGE_SAS_TOKEN]), "?")
+	if accountKey == "" && sasToken == "" && len(credentials[AZURE_CLIENT_ID]) > 0 {
+		return nil
+	}
+
+	query := url.Values{}
+	query.Set("restype", "container")
+	query.Set("comp", "list")
+	query.Set("maxresults", "1")
+	query.Set("prefix", modelPath)
+	// The SAS token is excluded from the errors, which are surfaced into the conditions.
+	listURL := fmt.Sprintf("%s/%s?%s", azureBlobEndpoint(account), container, query.Encode())
+	target := listURL
+	if sasToken != "" {
+		target += "&" + sasToken
+	}
+
+	var sign func(*http.Request)
+	if accountKey != "" {
+		sign = func(req *http.Request) { signAzure(req, account, accountKey, time.Now().UTC()) }
+	}
+
+	resp, err := v.get(ctx, target, sign, false)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			urlErr.URL = listURL
+		}
+		return err
+	}
+
+	switch resp.statusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return unauthorizedError(fmt.Sprintf("container %s", container), sign != nil || sasToken != "")
+	case http.StatusNotFound:
+		// Private containers are not found either without credentials.
+		return &SourceError{Reason: ReasonModelNotFound, Message: fmt.Sprintf("container %s not found or not public in account %s", container, account)}
+	default:
+		return fmt.Errorf("unexpected status code %d from %s", resp.statusCode, listURL)
+	}
+
+	var result azureListBlobsResult
+	if err := xml.Unmarshal(resp.body, &result); err != nil {
+		return fmt.Errorf("failed to decode the blobs of container %s: %v", container, err)
+	}
+	if len(result.Blobs.Blob) == 0 {
+		return &SourceError{Reason: ReasonModelNotFound, Message: fmt.Sprintf("no blobs found under %s in container %s", modelPath, container)}
+	}
+	return nil
+}
+
+func (v *SourceValidator) checkListResult(body []byte, bucket, modelPath string) error {
+	var result listBucketResult
+	if err := xml.Unmarshal(body, &result); err != nil {
+		return fmt.Errorf("failed to decode the objects of bucket %s: %v", bucket, err)
+	}
+	if len(result.Contents) == 0 && ptr.Deref(result.KeyCount, 0) == 0 {
+		return &SourceError{Reason: ReasonModelNotFound, Message: fmt.Sprintf("no objects found under %s in bucket %s", modelPath, bucket)}
+	}
+	return nil
+}
+
+type response struct {
+	statusCode int
+	header     http.Header
+	body       []byte
+}
+
+func (v *SourceValidator) get(ctx context.Context, target string, sign func(*http.Request), followRedirects bool) (*response, error) {
+	resp, err := v.do(ctx, target, sign, followRedirects)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	// Read at most 1MiB in case of unexpected responses, the list results are limited by max-keys.
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	return &response{statusCode: resp.StatusCode, header: resp.Header, body: body}, nil
+}
+
+// do sends the GET request, the caller is responsible for closing the response body.
+func (v *SourceValidator) do(ctx context.Context, target string, sign func(*http.Request), followRedirects bool) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	if sign != nil {
+		sign(req)
+	}
+
+	client := *v.HTTPClient
+	if !followRedirects {
+		client.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+	}
+	return client.Do(req)
+}
+
+func unauthorizedError(target string, withCredentials bool) *SourceError {
+	if !withCredentials {
+		return &SourceError{Reason: ReasonSecretNotFound, Message: fmt.Sprintf("%s requires credentials", target)}
+	}
+	return &SourceError{Reason: ReasonUnauthorized, Message: fmt.Sprintf("%s is not accessible with the credentials", target)}
+}
+
+// signV4 signs the request with AWS Signature Version 4 for the s3 service,
+// see https://docs.aws.amazon.com/IAM/latest/UserGuide/reference_sigv-create-signed-request.html.
+func signV4(req *http.Request, accessKeyID, secretKey, region string, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	// The body of the GET requests is always empty.
+	payloadHash := hex.EncodeToString(sha256Sum(nil))
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	canonicalHeaders := fmt.Sprintf("host:%s\nx-amz-content-sha256:%s\nx-amz-date:%s\n", req.URL.Host, payloadHash, amzDate)
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := fmt.Sprintf("%s/%s/s3/aws4_request", date, region)
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hex.EncodeToString(sha256Sum([]byte(canonicalRequest))),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+secretKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s", accessKeyID, scope, signedHeaders, signature))
+}
+
+// canonicalQuery encodes the query sorted by keys with spaces encoded as %20.
+func canonicalQuery(values url.Values) string {
+	return strings.ReplaceAll(values.Encode(), "+", "%20")
+}
+
+// signOSS signs the request with the OSS signature version 1,
+// see https://www.alibabacloud.com/help/en/oss/developer-reference/include-signatures-in-the-authorization-header.
+// The resource is in the format of /bucket/key.
+func signOSS(req *http.Request, accessKeyID, secretKey, resource string, now time.Time) {
+	date := now.Format(http.TimeFormat)
+	req.Header.Set("Date", date)
+
+	stringToSign := fmt.Sprintf("%s\n\n\n%s\n%s", req.Method, date, resource)
+	mac := hmac.New(sha1.New, []byte(secretKey))
+	mac.Write([]byte(stringToSign))
+	req.Header.Set("Authorization", fmt.Sprintf("OSS %s:%s", accessKeyID, base64.StdEncoding.EncodeToString(mac.Sum(nil))))
+}
+
+// signAzure signs the request with the Shared Key of the storage account,
+// see https://learn.microsoft.com/en-us/rest/api/storageservices/authorize-with-shared-key.
+func signAzure(req *http.Request, account, accountKey string, now time.Time) {
+	req.Header.Set("X-Ms-Date", now.Format(http.TimeFormat))
+	req.Header.Set("X-Ms-Version", azureAPIVersion)
+
+	var headers []string
+	for key := range req.Header {
+		if lower := strings.ToLower(key); strings.HasPrefix(lower, "x-ms-") {
+			headers = append(headers, lower+":"+strings.TrimSpace(req.Header.Get(key)))
+		}
+	}
+	slices.Sort(headers)
+
+	resource := "/" + account + req.URL.EscapedPath()
+	query := req.URL.Query()
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		values := query[key]
+		slices.Sort(values)
+		resource += "\n" + strings.ToLower(key) + ":" + strings.Join(values, ",")
+	}
+
+	// The standard headers are all empty for the GET requests.
+	stringToSign := req.Method + strings.Repeat("\n", 12) + strings.Join(headers, "\n") + "\n" + resource
+
+	key, _ := base64.StdEncoding.DecodeString(accountKey)
+	signature := base64.StdEncoding.EncodeToString(hmacSHA256(key, stringToSign))
+	req.Header.Set("Authorization", fmt.Sprintf("SharedKey %s:%s", account, signature))
+}
+
+func sha256Sum(data []byte) []byte {
+	sum := sha256.Sum256(data)
+	return sum[:]
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,516 @@
+/*
+Copyright 2025 The InftyAI Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modelSource
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/utils/ptr"
+
+	coreapi "github.com/inftyai/llmaz/api/core/v1alpha1"
+	"github.com/inftyai/llmaz/test/util/wrapper"
+)
+
+// fakeSourceServer mocks the model hubs and object stores.
+func fakeSourceServer() *httptest.Server {
+	return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		// Huggingface
+		case r.URL.Path == "/api/models/meta-llama/Meta-Llama-3-8B/revision/main":
+			if r.Header.Get("Authorization") != "Bearer hf-token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = fmt.Fprint(w, `{"id": "meta-llama/Meta-Llama-3-8B", "siblings": [{"rfilename": "config.json"}]}`)
+		case r.URL.Path == "/api/models/Qwen/Qwen2-0.5B-Instruct-GGUF/revision/main":
+			_, _ = fmt.Fprint(w, `{"id": "Qwen/Qwen2-0.5B-Instruct-GGUF", "sha": "198f08841147e5196a6a69bd0053690fb1fd3857", "siblings": [{"rfilename": "qwen2-0_5b-instruct-q5_k_m.gguf"}]}`)
+		case strings.HasPrefix(r.URL.Path, "/api/models/"):
+			w.Header().Set("X-Error-Code", "RepoNotFound")
+			w.WriteHeader(http.StatusNotFound)
+		// S3
+		case r.URL.Path == "/private-bucket":
+			if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=access-key/") {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			fallthrough
+		case r.URL.Path == "/public-bucket":
+			keyCount := 0
+			if r.URL.Query().Get("prefix") == "models/llama3" && r.URL.Query().Get("list-type") == "2" {
+				keyCount = 1
+			}
+			_, _ = fmt.Fprintf(w, `<ListBucketResult><KeyCount>%d</KeyCount></ListBucketResult>`, keyCount)
+		// OSS
+		case r.Host == "oss-bucket.oss-cn-hangzhou.aliyuncs.com" && r.URL.Path == "/":
+			if !strings.HasPrefix(r.Header.Get("Authorization"), "OSS access-key:") {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			_, _ = fmt.Fprint(w, `<ListBucketResult><Contents><Key>models/qwen2/config.json</Key></Contents></ListBucketResult>`)
+		// Ollama
+		case r.URL.Path == "/v2/library/llama3.3/manifests/latest":
+			w.WriteHeader(http.StatusOK)
+		// Azure
+		case r.Host == "account.blob.core.windows.net" && r.URL.Query().Get("comp") == "list":
+			if r.URL.Path == "/private" {
+				switch {
+				case strings.HasPrefix(r.Header.Get("Authorization"), "SharedKey account:") && r.Header.Get("X-Ms-Date") != "":
+				case r.URL.Query().Get("sig") == "sas-signature":
+				case r.URL.Query().Get("sig") != "":
+					w.WriteHeader(http.StatusForbidden)
+					return
+				default:
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+			} else if r.URL.Path != "/public" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			if r.URL.Query().Get("prefix") != "models/llama3" {
+				_, _ = fmt.Fprint(w, `<EnumerationResults><Blobs></Blobs></EnumerationResults>`)
+				return
+			}
+			_, _ = fmt.Fprint(w, `<EnumerationResults><Blobs><Blob><Name>models/llama3/config.json</Name></Blob></Blobs></EnumerationResults>`)
+		// HTTPS
+		case r.URL.Path == "/private/qwen2.gguf":
+			if r.Header.Get("Authorization") != "Bearer http-token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			fallthrough
+		case r.URL.Path == "/models/qwen2.gguf":
+			if r.Header.Get("Range") != "bytes=0-0" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.WriteHeader(http.StatusPartialContent)
+		// OCI registry
+		case r.URL.Path == "/token":
+			if strings.Contains(r.URL.Query().Get("scope"), "inftyai/private") {
+				if username, password, ok := r.BasicAuth(); !ok || username != "user" || password != "password" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+			}
+			_, _ = fmt.Fprint(w, `{"token": "registry-token"}`)
+		case strings.HasPrefix(r.URL.Path, "/v2/inftyai/"):
+			if r.Header.Get("Authorization") != "Bearer registry-token" {
+				repository := strings.Split(strings.TrimPrefix(r.URL.Path, "/v2/"), "/manifests/")[0]
+				w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="https://auth.example.com/token",service="registry.example.com",scope="repository:%s:pull"`, repository))
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			if !strings.HasSuffix(r.URL.Path, "/manifests/latest") || !strings.Contains(r.Header.Get("Accept"), "application/vnd.oci.image.manifest.v1+json") {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestSourceValidator_Validate(t *testing.T) {
+	server := fakeSourceServer()
+	defer server.Close()
+
+	client := server.Client()
+	// OSS requests virtual hosted buckets, route all of them to the fake server.
+	client.Transport = &http.Transport{
+		DialContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, network, server.Listener.Addr().String())
+		},
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+	}
+	validator := &SourceValidator{
+		HTTPClient:          client,
+		HuggingfaceEndpoint: server.URL,
+		ModelScopeEndpoint:  server.URL,
+		OllamaEndpoint:      server.URL,
+		S3Endpoint:          server.URL,
+		GCSEndpoint:         server.URL,
+	}
+
+	hfModel := wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithModelHub("Huggingface").ModelSourceWithModelID("meta-llama/Meta-Llama-3-8B", "", "", nil, nil).Obj()
+
+	testCases := []struct {
+		name        string
+		model       *coreapi.OpenModel
+		credentials map[string][]byte
+		wantReason  string
+	}{
+		{
+			name:        "huggingface model with token",
+			model:       hfModel,
+			credentials: map[string][]byte{HUGGING_FACE_TOKEN_KEY: []byte("hf-token")},
+		},
+		{
+			name:       "huggingface model requires token",
+			model:      hfModel,
+			wantReason: ReasonSecretNotFound,
+		},
+		{
+			name:        "huggingface model with a wrong token",
+			model:       hfModel,
+			credentials: map[string][]byte{HUGGING_FACE_TOKEN_KEY: []byte("wrong-token")},
+			wantReason:  ReasonUnauthorized,
+		},
+		{
+			name:       "huggingface model not found",
+			model:      wrapper.MakeModel("not-found").FamilyName("llama3").ModelSourceWithModelHub("Huggingface").ModelSourceWithModelID("meta-llama/not-found", "", "", nil, nil).Obj(),
+			wantReason: ReasonModelNotFound,
+		},
+		{
+			name:  "huggingface model file exists",
+			model: wrapper.MakeModel("qwen2-0--5b-gguf").FamilyName("qwen2").ModelSourceWithModelHub("Huggingface").ModelSourceWithModelID("Qwen/Qwen2-0.5B-Instruct-GGUF", "qwen2-0_5b-instruct-q5_k_m.gguf", "", nil, nil).Obj(),
+		},
+		{
+			name:       "huggingface model file not found",
+			model:      wrapper.MakeModel("qwen2-0--5b-gguf").FamilyName("qwen2").ModelSourceWithModelHub("Huggingface").ModelSourceWithModelID("Qwen/Qwen2-0.5B-Instruct-GGUF", "qwen2-0_5b-instruct-q8_0.gguf", "", nil, nil).Obj(),
+			wantReason: ReasonModelNotFound,
+		},
+		{
+			name:  "public s3 bucket",
+			model: wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithURI("s3://public-bucket/models/llama3").Obj(),
+		},
+		{
+			name:       "s3 prefix not found",
+			model:      wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithURI("s3://public-bucket/models/qwen2").Obj(),
+			wantReason: ReasonModelNotFound,
+		},
+		{
+			name:        "private s3 bucket with credentials",
+			model:       wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithURI("s3://private-bucket/models/llama3").Obj(),
+			credentials: map[string][]byte{AWS_ACCESS_KEY_ID: []byte("access-key"), AWS_ACCESS_KEY_SECRET: []byte("secret-key")},
+		},
+		{
+			name:       "private s3 bucket without credentials",
+			model:      wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithURI("s3://private-bucket/models/llama3").Obj(),
+			wantReason: ReasonSecretNotFound,
+		},
+		{
+			name:  "gcs bucket",
+			model: wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithURI("gcs://public-bucket/models/llama3").Obj(),
+		},
+		{
+			name:        "private gcs bucket with the service account key",
+			model:       wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithURI("gcs://private-bucket/models/llama3").Obj(),
+			credentials: map[string][]byte{GCS_SERVICE_ACCOUNT_KEY: []byte(`{"type": "service_account"}`)},
+		},
+		{
+			name:        "oss bucket with credentials",
+			model:       wrapper.MakeModel("qwen2").FamilyName("qwen2").ModelSourceWithURI("oss://oss-bucket.oss-cn-hangzhou.aliyuncs.com/models/qwen2").Obj(),
+			credentials: map[string][]byte{OSS_ACCESS_KEY_ID: []byte("access-key"), OSS_ACCESS_KEY_SECRET: []byte("secret-key")},
+		},
+		{
+			name:        "oss bucket not found",
+			model:       wrapper.MakeModel("qwen2").FamilyName("qwen2").ModelSourceWithURI("oss://not-found.oss-cn-hangzhou.aliyuncs.com/models/qwen2").Obj(),
+			credentials: map[string][]byte{OSS_ACCESS_KEY_ID: []byte("access-key"), OSS_ACCESS_KEY_SECRET: []byte("secret-key")},
+			wantReason:  ReasonModelNotFound,
+		},
+		{
+			name:  "ollama model",
+			model: wrapper.MakeModel("llama3").FamilyName("llama3").ModelSourceWithURI("ollama://llama3.3").Obj(),
+		},
+		{
+			name:       "ollama model not found",
+			model:      wrapper.MakeModel("llama3").FamilyName("llama3").ModelSourceWithURI("ollama://llama3.3:405b").Obj(),
+			wantReason: ReasonModelNotFound,
+		},
+		{
+			name:  "public azure container",
+			model: wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithURI("azblob://account/public/models/llama3").Obj(),
+		},
+		{
+			name:       "azure prefix not found",
+			model:      wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithURI("azblob://account/public/models/qwen2").Obj(),
+			wantReason: ReasonModelNotFound,
+		},
+		{
+			name:        "private azure container with the account key",
+			model:       wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithURI("abfss://private@account.dfs.core.windows.net/models/llama3").Obj(),
+			credentials: map[string][]byte{AZURE_STORAGE_ACCOUNT_KEY: []byte("YWNjb3VudC1rZXk=")},
+		},
+		{
+			name:        "private azure container with the sas token",
+			model:       wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithURI("azblob://account/private/models/llama3").Obj(),
+			credentials: map[string][]byte{AZURE_STORAGE_SAS_TOKEN: []byte("?sv=2021-08-06&sp=rl&sig=sas-signature")},
+		},
+		{
+			name:        "private azure container with a wrong sas token",
+			model:       wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithURI("azblob://account/private/models/llama3").Obj(),
+			credentials: map[string][]byte{AZURE_STORAGE_SAS_TOKEN: []byte("sv=2021-08-06&sp=rl&sig=wrong-signature")},
+			wantReason:  ReasonUnauthorized,
+		},
+		{
+			name:       "private azure container without credentials",
+			model:      wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithURI("azblob://account/private/models/llama3").Obj(),
+			wantReason: ReasonModelNotFound,
+		},
+		{
+			name:        "private azure container with the service principal",
+			model:       wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithURI("azblob://account/private/models/qwen2").Obj(),
+			credentials: map[string][]byte{AZURE_CLIENT_ID: []byte("client-id"), AZURE_TENANT_ID: []byte("tenant-id"), AZURE_CLIENT_SECRET: []byte("client-secret")},
+		},
+		{
+			name:  "https file",
+			model: wrapper.MakeModel("qwen2").FamilyName("qwen2").ModelSourceWithURI("https://example.com/models/qwen2.gguf").Obj(),
+		},
+		{
+			name:       "https file not found",
+			model:      wrapper.MakeModel("qwen2").FamilyName("qwen2").ModelSourceWithURI("https://example.com/models/llama3.gguf").Obj(),
+			wantReason: ReasonModelNotFound,
+		},
+		{
+			name:        "private https file with the auth header",
+			model:       wrapper.MakeModel("qwen2").FamilyName("qwen2").ModelSourceWithURI("https://example.com/private/qwen2.gguf").Obj(),
+			credentials: map[string][]byte{HTTP_AUTH_HEADER: []byte("Bearer http-token")},
+		},
+		{
+			name:       "private https file without the auth header",
+			model:      wrapper.MakeModel("qwen2").FamilyName("qwen2").ModelSourceWithURI("https://example.com/private/qwen2.gguf").Obj(),
+			wantReason: ReasonSecretNotFound,
+		},
+		{
+			name:  "oci artifact with the anonymous token",
+			model: wrapper.MakeModel("qwen2").FamilyName("qwen2").ModelSourceWithURI("oci://registry.example.com/inftyai/qwen2").Obj(),
+		},
+		{
+			name:       "oci artifact not found",
+			model:      wrapper.MakeModel("qwen2").FamilyName("qwen2").ModelSourceWithURI("oci://registry.example.com/inftyai/qwen2:v2").Obj(),
+			wantReason: ReasonModelNotFound,
+		},
+		{
+			name:        "private oci artifact with credentials",
+			model:       wrapper.MakeModel("qwen2").FamilyName("qwen2").ModelSourceWithURI("oci://registry.example.com/inftyai/private:latest").Obj(),
+			credentials: map[string][]byte{OCI_USERNAME: []byte("user"), OCI_PASSWORD: []byte("password")},
+		},
+		{
+			name:       "private oci artifact without credentials",
+			model:      wrapper.MakeModel("qwen2").FamilyName("qwen2").ModelSourceWithURI("oci://registry.example.com/inftyai/private:latest").Obj(),
+			wantReason: ReasonSecretNotFound,
+		},
+		{
+			name:        "private oci artifact with wrong credentials",
+			model:       wrapper.MakeModel("qwen2").FamilyName("qwen2").ModelSourceWithURI("oci://registry.example.com/inftyai/private:latest").Obj(),
+			credentials: map[string][]byte{OCI_USERNAME: []byte("user"), OCI_PASSWORD: []byte("wrong-password")},
+			wantReason:  ReasonUnauthorized,
+		},
+		{
+			name:  "host path",
+			model: wrapper.MakeModel("llama3").FamilyName("llama3").ModelSourceWithURI("host:///mnt/models/llama3").Obj(),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validator.Validate(context.Background(), tc.model, tc.credentials)
+
+			var sourceErr *SourceError
+			if tc.wantReason == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.As(err, &sourceErr) {
+				t.Fatalf("expected a source error with reason %s, got %v", tc.wantReason, err)
+			}
+			if sourceErr.Reason != tc.wantReason {
+				t.Fatalf("unexpected reason, want %s, got %s: %s", tc.wantReason, sourceErr.Reason, sourceErr.Message)
+			}
+		})
+	}
+}
+
+func TestSourceValidator_S3Endpoint(t *testing.T) {
+	server := fakeSourceServer()
+	defer server.Close()
+
+	// The default endpoint doesn't serve the bucket, e.g. the buckets live in MinIO.
+	validator := &SourceValidator{HTTPClient: server.Client(), S3Endpoint: server.URL + "/aws"}
+	model := wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithURI("s3://public-bucket/models/llama3").Obj()
+
+	if err := validator.Validate(context.Background(), model, map[string][]byte{AWS_ENDPOINT_URL: []byte(server.URL + "/")}); err != nil {
+		t.Fatalf("unexpected error with the endpoint in the credentials: %v", err)
+	}
+	if err := validator.Validate(context.Background(), model, nil); err == nil {
+		t.Fatal("expected an error with the default endpoint")
+	}
+}
+
+func TestSourceValidator_TransientError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	validator := &SourceValidator{HTTPClient: server.Client(), HuggingfaceEndpoint: server.URL}
+	model := wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithModelHub("Huggingface").ModelSourceWithModelID("meta-llama/Meta-Llama-3-8B", "", "", nil, nil).Obj()
+
+	err := validator.Validate(context.Background(), model, nil)
+	var sourceErr *SourceError
+	if err == nil || errors.As(err, &sourceErr) {
+		t.Fatalf("expected a transient error, got %v", err)
+	}
+}
+
+func TestSourceValidator_ResolveRevision(t *testing.T) {
+	server := fakeSourceServer()
+	defer server.Close()
+
+	validator := &SourceValidator{HTTPClient: server.Client(), HuggingfaceEndpoint: server.URL}
+
+	model := wrapper.MakeModel("qwen2").FamilyName("qwen2").ModelSourceWithModelHub("Huggingface").ModelSourceWithModelID("Qwen/Qwen2-0.5B-Instruct-GGUF", "", "", nil, nil).Obj()
+	commit, err := validator.ResolveRevision(context.Background(), model, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "198f08841147e5196a6a69bd0053690fb1fd3857"; commit != want {
+		t.Fatalf("unexpected commit, want %s, got %s", want, commit)
+	}
+
+	var sourceErr *SourceError
+	model = wrapper.MakeModel("qwen2").FamilyName("qwen2").ModelSourceWithModelHub("Huggingface").ModelSourceWithModelID("Qwen/Qwen2-0.5B-Instruct-GGUF", "", "v2", nil, nil).Obj()
+	if _, err := validator.ResolveRevision(context.Background(), model, nil); !errors.As(err, &sourceErr) || sourceErr.Reason != ReasonModelNotFound {
+		t.Fatalf("expected a source error with reason %s, got %v", ReasonModelNotFound, err)
+	}
+	model = wrapper.MakeModel("qwen2").FamilyName("qwen2").ModelSourceWithModelHub("ModelScope").ModelSourceWithModelID("qwen/Qwen2-0.5B-Instruct-GGUF", "", "", nil, nil).Obj()
+	if _, err := validator.ResolveRevision(context.Background(), model, nil); !errors.As(err, &sourceErr) || sourceErr.Reason != ReasonInvalidSource {
+		t.Fatalf("expected a source error with reason %s, got %v", ReasonInvalidSource, err)
+	}
+}
+
+func TestSourceValidator_SecretRef(t *testing.T) {
+	server := fakeSourceServer()
+	defer server.Close()
+
+	validator := &SourceValidator{HTTPClient: server.Client(), HuggingfaceEndpoint: server.URL}
+	model := wrapper.MakeModel("llama3-8b").FamilyName("llama3").
+		ModelSourceWithModelHub("Huggingface").ModelSourceWithModelID("meta-llama/Meta-Llama-3-8B", "", "", nil, nil).
+		ModelSourceSecretRef("llama3-secret", map[string]string{HUGGING_FACE_TOKEN_KEY: "token"}).Obj()
+
+	if err := validator.Validate(context.Background(), model, CredentialsData(model, map[string][]byte{"token": []byte("hf-token")})); err != nil {
+		t.Fatalf("unexpected error with the mapped key: %v", err)
+	}
+
+	err := validator.Validate(context.Background(), model, CredentialsData(model, map[string][]byte{HUGGING_FACE_TOKEN_KEY: []byte("hf-token")}))
+	var sourceErr *SourceError
+	if !errors.As(err, &sourceErr) || sourceErr.Reason != ReasonSecretNotFound {
+		t.Fatalf("expected a source error with reason %s, got %v", ReasonSecretNotFound, err)
+	}
+	if want := "model meta-llama/Meta-Llama-3-8B requires credentials, secret llama3-secret not found"; sourceErr.Message != want {
+		t.Fatalf("unexpected message, want %q, got %q", want, sourceErr.Message)
+	}
+}
+
+func TestCredentialsSecretName(t *testing.T) {
+	testCases := []struct {
+		model *coreapi.OpenModel
+		want  string
+	}{
+		{
+			model: wrapper.MakeModel("llama3-8b").ModelSourceWithModelHub("Huggingface").ModelSourceWithModelID("meta-llama/Meta-Llama-3-8B", "", "", nil, nil).Obj(),
+			want:  MODELHUB_SECRET_NAME,
+		},
+		{
+			model: wrapper.MakeModel("qwen2").ModelSourceWithURI("oss://bucket.endpoint/models/qwen2").Obj(),
+			want:  OSS_ACCESS_SECRET_NAME,
+		},
+		{
+			model: wrapper.MakeModel("qwen2").ModelSourceWithURI("s3://bucket/models/qwen2").Obj(),
+			want:  AWS_ACCESS_SECRET_NAME,
+		},
+		{
+			model: wrapper.MakeModel("qwen2").ModelSourceWithURI("gcs://bucket/models/qwen2").Obj(),
+			want:  GCS_ACCESS_SECRET_NAME,
+		},
+		{
+			model: wrapper.MakeModel("qwen2").ModelSourceWithURI("azblob://account/container/models/qwen2").Obj(),
+			want:  AZURE_ACCESS_SECRET_NAME,
+		},
+		{
+			model: wrapper.MakeModel("qwen2").ModelSourceWithURI("https://example.com/models/qwen2.gguf").Obj(),
+			want:  HTTP_ACCESS_SECRET_NAME,
+		},
+		{
+			model: wrapper.MakeModel("qwen2").ModelSourceWithURI("oci://ghcr.io/inftyai/qwen2:latest").Obj(),
+			want:  OCI_ACCESS_SECRET_NAME,
+		},
+		{
+			model: wrapper.MakeModel("qwen2").ModelSourceWithURI("host:///mnt/models/qwen2").Obj(),
+			want:  "",
+		},
+		{
+			model: wrapper.MakeModel("qwen2").ModelSourceWithURI("s3://bucket/models/qwen2").ModelSourceSecretRef("qwen2-secret", nil).Obj(),
+			want:  "qwen2-secret",
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := CredentialsSecretName(tc.model); got != tc.want {
+			t.Errorf("unexpected secret name for %s, want %q, got %q", ptr.Deref(tc.model.Spec.Source.URI, ""), tc.want, got)
+		}
+	}
+}
+
+func TestCredentialsData(t *testing.T) {
+	data := map[string][]byte{"id": []byte("access-key"), AWS_ACCESS_KEY_SECRET: []byte("secret-key"), "other": []byte("other")}
+
+	model := wrapper.MakeModel("qwen2").ModelSourceWithURI("s3://bucket/models/qwen2").Obj()
+	if got := CredentialsData(model, data); !reflect.DeepEqual(data, got) {
+		t.Fatalf("unexpected credentials without the secretRef, want %v, got %v", data, got)
+	}
+
+	model = wrapper.MakeModel("qwen2").ModelSourceWithURI("s3://bucket/models/qwen2").
+		ModelSourceSecretRef("qwen2-secret", map[string]string{AWS_ACCESS_KEY_ID: "id"}).Obj()
+	want := map[string][]byte{AWS_ACCESS_KEY_ID: []byte("access-key"), AWS_ACCESS_KEY_SECRET: []byte("secret-key")}
+	if got := CredentialsData(model, data); !reflect.DeepEqual(want, got) {
+		t.Fatalf("unexpected credentials with the secretRef, want %v, got %v", want, got)
+	}
+}
+
+func TestSignAzure(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "https://account.blob.core.windows.net/container?restype=container&comp=list&prefix=models%2Fllama3", nil)
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	signAzure(req, "account", base64.StdEncoding.EncodeToString([]byte("account-key")), now)
+
+	stringToSign := "GET" + strings.Repeat("\n", 12) +
+		"x-ms-date:Thu, 02 Jan 2025 03:04:05 GMT\nx-ms-version:2021-08-06\n" +
+		"/account/container\ncomp:list\nprefix:models/llama3\nrestype:container"
+	mac := hmac.New(sha256.New, []byte("account-key"))
+	mac.Write([]byte(stringToSign))
+	want := "SharedKey account:" + base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	if got := req.Header.Get("Authorization"); got != want {
+		t.Fatalf("unexpected authorization, want %s, got %s", want, got)
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2025 The InftyAI Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modelSource
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	coreapplyv1 "k8s.io/client-go/applyconfigurations/core/v1"
+	"k8s.io/utils/ptr"
+
+	coreapi "github.com/inftyai/llmaz/api/core/v1alpha1"
+)
+
+const (
+	// MODEL_CHECKSUMS is the env of the expected checksums in JSON, verified by the model loader
+	// against the files under MODEL_LOCAL_PATH.
+	MODEL_CHECKSUMS  = "MODEL_CHECKSUMS"
+	MODEL_LOCAL_PATH = "MODEL_LOCAL_PATH"
+	// VERIFY_HUB_CHECKSUMS tells the model loader to verify the files against the checksums
+	// of the pinned commit on the model hub.
+	VERIFY_HUB_CHECKSUMS = "VERIFY_HUB_CHECKSUMS"
+
+	// ReasonChecksumMismatch is reported by the model loader in the termination message,
+	// once the downloaded files are missing or mismatched with the checksums.
+	ReasonChecksumMismatch = "ChecksumMismatch"
+)
+
+// modelLoaderFailureReasons are the reasons reported by the model loader, retrying will not help.
+var modelLoaderFailureReasons = []string{ReasonChecksumMismatch}
+
+// PinnedCommit returns the commit the revision of the model hub is pinned to,
+// empty if the revision is not pinned or the commit is not resolved yet.
+func PinnedCommit(model *coreapi.OpenModel) string {
+	hub := model.Spec.Source.ModelHub
+	if hub == nil || !ptr.Deref(hub.PinRevision, false) {
+		return ""
+	}
+	pinned := model.Status.PinnedRevision
+	if pinned == nil || pinned.Revision != ptr.Deref(hub.Revision, "main") {
+		return ""
+	}
+	return pinned.Commit
+}
+
+// injectChecksums passes the checksums to the model loader to verify the files under
+// the model path once downloaded.
+func injectChecksums(initContainer *coreapplyv1.ContainerApplyConfiguration, checksums map[string]string, modelPath string) {
+	if len(checksums) == 0 {
+		return
+	}
+	// Marshaling a map of strings never fails, and the keys are sorted.
+	data, _ := json.Marshal(checksums)
+	initContainer.WithEnv(
+		coreapplyv1.EnvVar().WithName(MODEL_CHECKSUMS).WithValue(string(data)),
+		coreapplyv1.EnvVar().WithName(MODEL_LOCAL_PATH).WithValue(modelPath),
+	)
+}
+
+// ModelLoaderFailure returns the reason and the message of the model loaders failed with the reasons
+// reported by themselves, like the checksum mismatch, empty if none of the pods failed this way.
+// Only the current state of the pods not being deleted counts, so the failures are cleared once
+// the model loaders succeed.
+func ModelLoaderFailure(pods []corev1.Pod) (reason, message string) {
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		for _, status := range pod.Status.InitContainerStatuses {
+			if !strings.HasPrefix(status.Name, MODEL_LOADER_CONTAINER_NAME) {
+				continue
+			}
+			terminated := status.State.Terminated
+			// The failed model loader is waiting to restart in the back-off, whose current
+			// failure is kept in the last termination.
+			if terminated == nil && status.State.Waiting != nil {
+				terminated = status.LastTerminationState.Terminated
+			}
+			if terminated == nil || terminated.ExitCode == 0 {
+				continue
+			}
+			reason, message, _ := strings.Cut(strings.TrimSpace(terminated.Message), ": ")
+			if slices.Contains(modelLoaderFailureReasons, reason) {
+				return reason, message
+			}
+		}
+	}
+	return "", ""
+}
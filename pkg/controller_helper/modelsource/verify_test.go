@@ -0,0 +1,215 @@
+/*
+Copyright 2025 The InftyAI Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modelSource
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	coreapplyv1 "k8s.io/client-go/applyconfigurations/core/v1"
+
+	coreapi "github.com/inftyai/llmaz/api/core/v1alpha1"
+	"github.com/inftyai/llmaz/test/util/wrapper"
+)
+
+const (
+	fakeCommit   = "0123456789abcdef0123456789abcdef01234567"
+	fakeChecksum = "3f786850e387550fdab836ed7e6dc881de23001b3f786850e387550fdab836ed"
+)
+
+func TestPinnedCommit(t *testing.T) {
+	tests := []struct {
+		name  string
+		model *coreapi.OpenModel
+		want  string
+	}{
+		{
+			name:  "not pinned",
+			model: wrapper.MakeModel("llama3").ModelSourceWithModelHub("Huggingface").ModelSourceWithModelID("meta/llama3", "", "", nil, nil).PinnedRevision("main", fakeCommit).Obj(),
+		},
+		{
+			name:  "pinned but not resolved yet",
+			model: wrapper.MakeModel("llama3").ModelSourceWithModelHub("Huggingface").ModelSourceWithModelID("meta/llama3", "", "", nil, nil).PinRevision(true).Obj(),
+		},
+		{
+			name:  "pinned with the default revision",
+			model: wrapper.MakeModel("llama3").ModelSourceWithModelHub("Huggingface").ModelSourceWithModelID("meta/llama3", "", "", nil, nil).PinRevision(true).PinnedRevision("main", fakeCommit).Obj(),
+			want:  fakeCommit,
+		},
+		{
+			name:  "revision changed",
+			model: wrapper.MakeModel("llama3").ModelSourceWithModelHub("Huggingface").ModelSourceWithModelID("meta/llama3", "", "v2", nil, nil).PinRevision(true).PinnedRevision("main", fakeCommit).Obj(),
+		},
+		{
+			name:  "uri",
+			model: wrapper.MakeModel("llama3").ModelSourceWithURI("s3://bucket/llama3").Obj(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, PinnedCommit(tt.model))
+		})
+	}
+}
+
+func TestInjectChecksums(t *testing.T) {
+	tests := []struct {
+		name         string
+		model        *coreapi.OpenModel
+		wantEnvs     map[string]string
+		wantRevision string
+	}{
+		{
+			name:  "no checksums",
+			model: wrapper.MakeModel("llama3").ModelSourceWithURI("s3://bucket/llama3").Obj(),
+		},
+		{
+			name:  "uri",
+			model: wrapper.MakeModel("llama3").ModelSourceWithURI("s3://bucket/llama3").ModelSourceChecksums(map[string]string{"model.safetensors": fakeChecksum, "config.json": fakeChecksum}).Obj(),
+			wantEnvs: map[string]string{
+				MODEL_CHECKSUMS:  `{"config.json":"` + fakeChecksum + `","model.safetensors":"` + fakeChecksum + `"}`,
+				MODEL_LOCAL_PATH: CONTAINER_MODEL_PATH + "models--llama3",
+			},
+		},
+		{
+			name:  "model hub with a file",
+			model: wrapper.MakeModel("qwen2").ModelSourceWithModelHub("Huggingface").ModelSourceWithModelID("qwen/qwen2-gguf", "qwen2.gguf", "", nil, nil).ModelSourceChecksums(map[string]string{"qwen2.gguf": fakeChecksum}).Obj(),
+			wantEnvs: map[string]string{
+				MODEL_CHECKSUMS:  `{"qwen2.gguf":"` + fakeChecksum + `"}`,
+				MODEL_LOCAL_PATH: CONTAINER_MODEL_PATH + "qwen2.gguf",
+			},
+		},
+		{
+			name:         "model hub pinned",
+			model:        wrapper.MakeModel("llama3").ModelSourceWithModelHub("Huggingface").ModelSourceWithModelID("meta/llama3", "", "v2", nil, nil).PinRevision(true).PinnedRevision("v2", fakeCommit).Obj(),
+			wantEnvs:     map[string]string{VERIFY_HUB_CHECKSUMS: "true"},
+			wantRevision: fakeCommit,
+		},
+		{
+			name:         "model hub not resolved yet",
+			model:        wrapper.MakeModel("llama3").ModelSourceWithModelHub("Huggingface").ModelSourceWithModelID("meta/llama3", "", "v2", nil, nil).PinRevision(true).Obj(),
+			wantRevision: "v2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template := coreapplyv1.PodTemplateSpec().WithSpec(coreapplyv1.PodSpec().WithContainers(
+				coreapplyv1.Container().WithName(MODEL_RUNNER_CONTAINER_NAME),
+			))
+			provider, err := NewModelSourceProvider(tt.model)
+			if err != nil {
+				t.Fatal(err)
+			}
+			provider.InjectModelLoader(template, 0, "model-loader:latest")
+
+			envs := map[string]string{}
+			var revision string
+			for _, env := range template.Spec.InitContainers[0].Env {
+				switch *env.Name {
+				case MODEL_CHECKSUMS, MODEL_LOCAL_PATH, VERIFY_HUB_CHECKSUMS:
+					envs[*env.Name] = *env.Value
+				case "REVISION":
+					revision = *env.Value
+				}
+			}
+			if tt.wantEnvs == nil {
+				tt.wantEnvs = map[string]string{}
+			}
+			assert.Equal(t, tt.wantEnvs, envs)
+			assert.Equal(t, tt.wantRevision, revision)
+		})
+	}
+}
+
+func TestModelLoaderFailure(t *testing.T) {
+	mismatched := &corev1.ContainerStateTerminated{ExitCode: 1, Message: "ChecksumMismatch: model.safetensors expected sha256 3f78..., got 0a1b...\n"}
+
+	tests := []struct {
+		name        string
+		statuses    []corev1.ContainerStatus
+		deleting    bool
+		wantReason  string
+		wantMessage string
+	}{
+		{
+			name:     "no failures",
+			statuses: []corev1.ContainerStatus{{Name: MODEL_LOADER_CONTAINER_NAME, State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0}}}},
+		},
+		{
+			name:     "failed with unknown reasons",
+			statuses: []corev1.ContainerStatus{{Name: MODEL_LOADER_CONTAINER_NAME, State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 1, Message: "connection reset"}}}},
+		},
+		{
+			name:     "other init containers",
+			statuses: []corev1.ContainerStatus{{Name: "init", State: corev1.ContainerState{Terminated: mismatched}}},
+		},
+		{
+			name:        "checksum mismatched",
+			statuses:    []corev1.ContainerStatus{{Name: MODEL_LOADER_CONTAINER_NAME + "-1", State: corev1.ContainerState{Terminated: mismatched}}},
+			wantReason:  ReasonChecksumMismatch,
+			wantMessage: "model.safetensors expected sha256 3f78..., got 0a1b...",
+		},
+		{
+			name: "checksum mismatched and waiting to restart",
+			statuses: []corev1.ContainerStatus{{
+				Name:                 MODEL_LOADER_CONTAINER_NAME,
+				State:                corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+				LastTerminationState: corev1.ContainerState{Terminated: mismatched},
+			}},
+			wantReason:  ReasonChecksumMismatch,
+			wantMessage: "model.safetensors expected sha256 3f78..., got 0a1b...",
+		},
+		{
+			name: "restarted after the checksum mismatched",
+			statuses: []corev1.ContainerStatus{{
+				Name:                 MODEL_LOADER_CONTAINER_NAME,
+				State:                corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+				LastTerminationState: corev1.ContainerState{Terminated: mismatched},
+			}},
+		},
+		{
+			name: "succeeded after the checksum mismatched",
+			statuses: []corev1.ContainerStatus{{
+				Name:                 MODEL_LOADER_CONTAINER_NAME,
+				State:                corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0}},
+				LastTerminationState: corev1.ContainerState{Terminated: mismatched},
+			}},
+		},
+		{
+			name:     "checksum mismatched in the deleting pod",
+			statuses: []corev1.ContainerStatus{{Name: MODEL_LOADER_CONTAINER_NAME, State: corev1.ContainerState{Terminated: mismatched}}},
+			deleting: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pods := []corev1.Pod{{}, {Status: corev1.PodStatus{InitContainerStatuses: tt.statuses}}}
+			if tt.deleting {
+				pods[1].DeletionTimestamp = &metav1.Time{Time: time.Now()}
+			}
+			reason, message := ModelLoaderFailure(pods)
+			assert.Equal(t, tt.wantReason, reason)
+			assert.Equal(t, tt.wantMessage, message)
+		})
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2025 The InftyAI Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	llmazSubsystemName = "llmaz"
+
+	// Reasons of activation failures.
+	ActivationFailureReasonScaleUp = "ScaleUpFailed"
+	ActivationFailureReasonTimeout = "Timeout"
+	ActivationFailureReasonRestore = "RestoreSelectorFailed"
+)
+
+var (
+	// ActivatorBufferedConnections records the number of connections held by the activator
+	// while waiting for the model to be scaled up from zero.
+	ActivatorBufferedConnections = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: llmazSubsystemName,
+			Name:      "activator_buffered_connections",
+			Help:      "The number of connections buffered by the activator waiting for the model to become ready.",
+		}, []string{"namespace", "model"},
+	)
+
+	// ActivatorColdStartDuration records the time elapsed from the first buffered
+	// connection to the first ready pod of the model.
+	ActivatorColdStartDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Subsystem: llmazSubsystemName,
+			Name:      "activator_cold_start_duration_seconds",
+			Help:      "The time taken to scale the model from zero to the first ready pod.",
+			// From 1s to ~68min, cold starts of large models can take tens of minutes.
+			Buckets: prometheus.ExponentialBuckets(1, 2, 13),
+		}, []string{"namespace", "model"},
+	)
+
+	// ActivatorActivationFailures records the number of failed activations.
+	ActivatorActivationFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: llmazSubsystemName,
+			Name:      "activator_activation_failures_total",
+			Help:      "The number of failed activations of scaled-to-zero models.",
+		}, []string{"namespace", "model", "reason"},
+	)
+
+	// ActivatorIdleScaleDowns records the number of times the model was scaled
+	// to zero by the activator after being idle.
+	ActivatorIdleScaleDowns = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: llmazSubsystemName,
+			Name:      "activator_idle_scale_downs_total",
+			Help:      "The number of times the model was scaled to zero and taken over by the activator.",
+		}, []string{"namespace", "model"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		ActivatorBufferedConnections,
+		ActivatorColdStartDuration,
+		ActivatorActivationFailures,
+		ActivatorIdleScaleDowns,
+	)
+}
+
+func BufferedConnectionsInc(namespace, model string) {
+	ActivatorBufferedConnections.WithLabelValues(namespace, model).Inc()
+}
+
+func BufferedConnectionsSub(namespace, model string, count int) {
+	ActivatorBufferedConnections.WithLabelValues(namespace, model).Sub(float64(count))
+}
+
+func ColdStartObserve(namespace, model string, duration time.Duration) {
+	ActivatorColdStartDuration.WithLabelValues(namespace, model).Observe(duration.Seconds())
+}
+
+func ActivationFailuresInc(namespace, model, reason string) {
+	ActivatorActivationFailures.WithLabelValues(namespace, model, reason).Inc()
+}
+
+func IdleScaleDownsInc(namespace, model string) {
+	ActivatorIdleScaleDowns.WithLabelValues(namespace, model).Inc()
+}
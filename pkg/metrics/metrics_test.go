@@ -0,0 +1,78 @@
+/*
+Copyright 2025 The InftyAI Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBufferedConnections(t *testing.T) {
+	BufferedConnectionsInc("default", "llama3")
+	BufferedConnectionsInc("default", "llama3")
+	BufferedConnectionsInc("default", "qwen2")
+	assert.Equal(t, float64(2), testutil.ToFloat64(ActivatorBufferedConnections.WithLabelValues("default", "llama3")))
+
+	BufferedConnectionsSub("default", "llama3", 2)
+	assert.Equal(t, float64(0), testutil.ToFloat64(ActivatorBufferedConnections.WithLabelValues("default", "llama3")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(ActivatorBufferedConnections.WithLabelValues("default", "qwen2")))
+}
+
+func TestColdStartObserve(t *testing.T) {
+	ColdStartObserve("default", "llama3", 3*time.Second)
+	ColdStartObserve("default", "llama3", 5*time.Minute)
+	assert.Equal(t, 1, testutil.CollectAndCount(ActivatorColdStartDuration))
+
+	expected := `
+# HELP llmaz_activator_cold_start_duration_seconds The time taken to scale the model from zero to the first ready pod.
+# TYPE llmaz_activator_cold_start_duration_seconds histogram
+llmaz_activator_cold_start_duration_seconds_bucket{model="llama3",namespace="default",le="1"} 0
+llmaz_activator_cold_start_duration_seconds_bucket{model="llama3",namespace="default",le="2"} 0
+llmaz_activator_cold_start_duration_seconds_bucket{model="llama3",namespace="default",le="4"} 1
+llmaz_activator_cold_start_duration_seconds_bucket{model="llama3",namespace="default",le="8"} 1
+llmaz_activator_cold_start_duration_seconds_bucket{model="llama3",namespace="default",le="16"} 1
+llmaz_activator_cold_start_duration_seconds_bucket{model="llama3",namespace="default",le="32"} 1
+llmaz_activator_cold_start_duration_seconds_bucket{model="llama3",namespace="default",le="64"} 1
+llmaz_activator_cold_start_duration_seconds_bucket{model="llama3",namespace="default",le="128"} 1
+llmaz_activator_cold_start_duration_seconds_bucket{model="llama3",namespace="default",le="256"} 1
+llmaz_activator_cold_start_duration_seconds_bucket{model="llama3",namespace="default",le="512"} 2
+llmaz_activator_cold_start_duration_seconds_bucket{model="llama3",namespace="default",le="1024"} 2
+llmaz_activator_cold_start_duration_seconds_bucket{model="llama3",namespace="default",le="2048"} 2
+llmaz_activator_cold_start_duration_seconds_bucket{model="llama3",namespace="default",le="4096"} 2
+llmaz_activator_cold_start_duration_seconds_bucket{model="llama3",namespace="default",le="+Inf"} 2
+llmaz_activator_cold_start_duration_seconds_sum{model="llama3",namespace="default"} 303
+llmaz_activator_cold_start_duration_seconds_count{model="llama3",namespace="default"} 2
+`
+	assert.NoError(t, testutil.CollectAndCompare(ActivatorColdStartDuration, strings.NewReader(expected)))
+}
+
+func TestActivationFailuresInc(t *testing.T) {
+	ActivationFailuresInc("default", "llama3", ActivationFailureReasonTimeout)
+	ActivationFailuresInc("default", "llama3", ActivationFailureReasonTimeout)
+	ActivationFailuresInc("default", "llama3", ActivationFailureReasonScaleUp)
+	assert.Equal(t, float64(2), testutil.ToFloat64(ActivatorActivationFailures.WithLabelValues("default", "llama3", ActivationFailureReasonTimeout)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(ActivatorActivationFailures.WithLabelValues("default", "llama3", ActivationFailureReasonScaleUp)))
+}
+
+func TestIdleScaleDownsInc(t *testing.T) {
+	IdleScaleDownsInc("default", "llama3")
+	assert.Equal(t, float64(1), testutil.ToFloat64(ActivatorIdleScaleDowns.WithLabelValues("default", "llama3")))
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2025 The InftyAI Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CronSchedule is a parsed cron expression in the standard 5-field format,
+// i.e. "minute hour day-of-month month day-of-week".
+type CronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	// Whether the day-of-month or day-of-week field is "*".
+	domStar, dowStar bool
+}
+
+type cronBounds struct {
+	min, max int
+}
+
+var (
+	minuteBounds = cronBounds{0, 59}
+	hourBounds   = cronBounds{0, 23}
+	domBounds    = cronBounds{1, 31}
+	monthBounds  = cronBounds{1, 12}
+	// Both 0 and 7 represent Sunday.
+	dowBounds = cronBounds{0, 7}
+)
+
+// ParseCron parses the cron expression, supporting "*", lists, ranges and steps,
+// e.g. "*/15 8-18 * * 1,2,3,4,5".
+func ParseCron(expr string) (*CronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected 5 fields in cron expression %q, got %d", expr, len(fields))
+	}
+
+	schedule := &CronSchedule{
+		domStar: fields[2] == "*",
+		dowStar: fields[4] == "*",
+	}
+
+	var err error
+	if schedule.minute, err = parseCronField(fields[0], minuteBounds); err != nil {
+		return nil, err
+	}
+	if schedule.hour, err = parseCronField(fields[1], hourBounds); err != nil {
+		return nil, err
+	}
+	if schedule.dom, err = parseCronField(fields[2], domBounds); err != nil {
+		return nil, err
+	}
+	if schedule.month, err = parseCronField(fields[3], monthBounds); err != nil {
+		return nil, err
+	}
+	if schedule.dow, err = parseCronField(fields[4], dowBounds); err != nil {
+		return nil, err
+	}
+	if schedule.dow&(1<<7) != 0 {
+		schedule.dow |= 1 << 0
+	}
+	return schedule, nil
+}
+
+func parseCronField(field string, bounds cronBounds) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangeAndStep := strings.SplitN(part, "/", 2)
+		start, end := bounds.min, bounds.max
+		step := 1
+
+		if rangeAndStep[0] != "*" {
+			lowAndHigh := strings.SplitN(rangeAndStep[0], "-", 2)
+			var err error
+			if start, err = strconv.Atoi(lowAndHigh[0]); err != nil {
+				return 0, fmt.Errorf("invalid value %q in cron field %q", lowAndHigh[0], field)
+			}
+			end = start
+			if len(lowAndHigh) == 2 {
+				if end, err = strconv.Atoi(lowAndHigh[1]); err != nil {
+					return 0, fmt.Errorf("invalid value %q in cron field %q", lowAndHigh[1], field)
+				}
+			} else if len(rangeAndStep) == 2 {
+				// "N/step" means from N to the maximum.
+				end = bounds.max
+			}
+		}
+
+		if len(rangeAndStep) == 2 {
+			var err error
+			if step, err = strconv.Atoi(rangeAndStep[1]); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step %q in cron field %q", rangeAndStep[1], field)
+			}
+		}
+
+		if start < bounds.min || end > bounds.max || start > end {
+			return 0, fmt.Errorf("value out of range [%d, %d] in cron field %q", bounds.min, bounds.max, field)
+		}
+		for i := start; i <= end; i += step {
+			bits |= 1 << uint(i)
+		}
+	}
+	return bits, nil
+}
+
+// Next returns the earliest time matching the schedule strictly after t, in the location of t.
+// Zero time will be returned if no time matches within the following five years.
+func (s *CronSchedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, loc).Add(time.Minute)
+	yearLimit := t.Year() + 5
+
+WRAP:
+	if t.Year() > yearLimit {
+		return time.Time{}
+	}
+
+	for s.month&(1<<uint(t.Month())) == 0 {
+		t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		if t.Month() == time.January {
+			goto WRAP
+		}
+	}
+
+	for !s.dayMatches(t) {
+		t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		if t.Day() == 1 {
+			goto WRAP
+		}
+	}
+
+	for s.hour&(1<<uint(t.Hour())) == 0 {
+		t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		if t.Hour() == 0 {
+			goto WRAP
+		}
+	}
+
+	for s.minute&(1<<uint(t.Minute())) == 0 {
+		t = t.Add(time.Minute)
+		if t.Minute() == 0 {
+			goto WRAP
+		}
+	}
+
+	return t
+}
+
+// dayMatches follows the cron convention, if both the day-of-month and day-of-week
+// are restricted, the day matches when either of them matches.
+func (s *CronSchedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
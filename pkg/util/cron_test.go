@@ -0,0 +1,162 @@
+/*
+Copyright 2025 The InftyAI Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCron(t *testing.T) {
+	testCases := []struct {
+		name   string
+		expr   string
+		failed bool
+	}{
+		{
+			name: "every minute",
+			expr: "* * * * *",
+		},
+		{
+			name: "lists, ranges and steps",
+			expr: "*/15 8-18 1,15 * 1-5",
+		},
+		{
+			name: "sunday represented by 7",
+			expr: "0 8 * * 7",
+		},
+		{
+			name:   "too few fields",
+			expr:   "0 8 * *",
+			failed: true,
+		},
+		{
+			name:   "value out of range",
+			expr:   "60 8 * * *",
+			failed: true,
+		},
+		{
+			name:   "reversed range",
+			expr:   "0 18-8 * * *",
+			failed: true,
+		},
+		{
+			name:   "invalid step",
+			expr:   "*/0 8 * * *",
+			failed: true,
+		},
+		{
+			name:   "not a number",
+			expr:   "0 eight * * *",
+			failed: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseCron(tc.expr)
+			if tc.failed != (err != nil) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestCronScheduleNext(t *testing.T) {
+	// 2025-01-01 is Wednesday.
+	base := time.Date(2025, 1, 1, 10, 30, 20, 0, time.UTC)
+
+	testCases := []struct {
+		name string
+		expr string
+		from time.Time
+		want time.Time
+	}{
+		{
+			name: "every minute",
+			expr: "* * * * *",
+			from: base,
+			want: time.Date(2025, 1, 1, 10, 31, 0, 0, time.UTC),
+		},
+		{
+			name: "later the same day",
+			expr: "0 18 * * *",
+			from: base,
+			want: time.Date(2025, 1, 1, 18, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "next day",
+			expr: "0 8 * * *",
+			from: base,
+			want: time.Date(2025, 1, 2, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "strictly after",
+			expr: "30 10 * * *",
+			from: time.Date(2025, 1, 1, 10, 30, 0, 0, time.UTC),
+			want: time.Date(2025, 1, 2, 10, 30, 0, 0, time.UTC),
+		},
+		{
+			name: "weekdays only",
+			expr: "0 8 * * 1-5",
+			from: time.Date(2025, 1, 3, 9, 0, 0, 0, time.UTC),
+			want: time.Date(2025, 1, 6, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "sunday",
+			expr: "0 8 * * 7",
+			from: base,
+			want: time.Date(2025, 1, 5, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "day of month or day of week",
+			expr: "0 0 10 * 5",
+			from: base,
+			want: time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "next year",
+			expr: "0 0 1 1 *",
+			from: base,
+			want: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "leap day",
+			expr: "0 0 29 2 *",
+			from: base,
+			want: time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "never matched",
+			expr: "0 0 31 2 *",
+			from: base,
+			want: time.Time{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			schedule, err := ParseCron(tc.expr)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := schedule.Next(tc.from); !got.Equal(tc.want) {
+				t.Fatalf("unexpected next time, want %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
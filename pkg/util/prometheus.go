@@ -0,0 +1,53 @@
+/*
+Copyright 2025 The InftyAI Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"io"
+
+	"github.com/prometheus/common/expfmt"
+)
+
+// SumMetrics parses the metrics in Prometheus text format and sums up the values of
+// all the series belonging to the given metric families, found reports whether
+// any of the metric families exists.
+func SumMetrics(reader io.Reader, names []string) (sum float64, found bool, err error) {
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(reader)
+	if err != nil {
+		return 0, false, err
+	}
+
+	for _, name := range names {
+		family, ok := families[name]
+		if !ok {
+			continue
+		}
+		found = true
+		for _, m := range family.GetMetric() {
+			switch {
+			case m.Gauge != nil:
+				sum += m.GetGauge().GetValue()
+			case m.Counter != nil:
+				sum += m.GetCounter().GetValue()
+			case m.Untyped != nil:
+				sum += m.GetUntyped().GetValue()
+			}
+		}
+	}
+	return sum, found, nil
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2025 The InftyAI Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSumMetrics(t *testing.T) {
+	testCases := []struct {
+		name      string
+		text      string
+		names     []string
+		wantSum   float64
+		wantFound bool
+		failed    bool
+	}{
+		{
+			name: "vllm gauges",
+			text: `# HELP vllm:num_requests_running Number of requests currently running on GPU.
+# TYPE vllm:num_requests_running gauge
+vllm:num_requests_running{model_name="qwen2"} 2.0
+# HELP vllm:num_requests_waiting Number of requests waiting to be processed.
+# TYPE vllm:num_requests_waiting gauge
+vllm:num_requests_waiting{model_name="qwen2"} 1.0
+`,
+			names:     []string{"vllm:num_requests_running", "vllm:num_requests_waiting", "sglang:num_running_reqs"},
+			wantSum:   3,
+			wantFound: true,
+		},
+		{
+			name: "counters of multiple series",
+			text: `# TYPE tgi_request_count counter
+tgi_request_count{method="POST"} 10
+tgi_request_count{method="GET"} 5
+`,
+			names:     []string{"tgi_request_count"},
+			wantSum:   15,
+			wantFound: true,
+		},
+		{
+			name: "no metrics found",
+			text: `# TYPE process_cpu_seconds_total counter
+process_cpu_seconds_total 1.5
+`,
+			names:     []string{"vllm:num_requests_running"},
+			wantSum:   0,
+			wantFound: false,
+		},
+		{
+			name:   "malformed text",
+			text:   "vllm:num_requests_running{model_name=\"qwen2\" 1\n",
+			names:  []string{"vllm:num_requests_running"},
+			failed: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gotSum, gotFound, err := SumMetrics(strings.NewReader(tc.text), tc.names)
+			if tc.failed {
+				if err == nil {
+					t.Fatal("test should fail")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if gotSum != tc.wantSum || gotFound != tc.wantFound {
+				t.Fatalf("unexpected result, want (%v, %v), got (%v, %v)", tc.wantSum, tc.wantFound, gotSum, gotFound)
+			}
+		})
+	}
+}
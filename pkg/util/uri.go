@@ -19,7 +19,11 @@ package util
 import (
 	"errors"
 	"fmt"
+	"net/url"
+	"regexp"
 	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 func ParseURI(uri string) (protocol string, address string, err error) {
@@ -55,3 +59,104 @@ func ParseS3(address string) (bucket, modelPath string, err error) {
 	bucket, modelPath = splits[0], splits[1]
 	return bucket, modelPath, nil
 }
+
+// ParseHTTPS address looks like: <host>/<path-to-file>[#sha256=<checksum>], the checksum is optional.
+func ParseHTTPS(address string) (modelPath, checksum string, err error) {
+	modelPath, fragment, found := strings.Cut(address, "#")
+	if found {
+		algorithm, value, _ := strings.Cut(fragment, "=")
+		if algorithm != "sha256" || !sha256Pattern.MatchString(value) {
+			return "", "", fmt.Errorf("checksum not right %s, only sha256=<checksum> is supported", fragment)
+		}
+		checksum = strings.ToLower(value)
+	}
+
+	u, err := url.Parse("https://" + modelPath)
+	if err != nil || u.Host == "" || u.Path == "" || strings.HasSuffix(u.Path, "/") {
+		return "", "", fmt.Errorf("address not right %s, should be the url of a file", address)
+	}
+	return modelPath, checksum, nil
+}
+
+// IsSHA256 returns whether the checksum is a hex encoded sha256.
+func IsSHA256(checksum string) bool {
+	return sha256Pattern.MatchString(checksum)
+}
+
+// ParseOCI address looks like: <registry>/<repository>[:<tag>|@<digest>], the tag defaults to latest.
+func ParseOCI(address string) (registry, repository, reference string, err error) {
+	registry, repository, found := strings.Cut(address, "/")
+	if !found || registry == "" || repository == "" {
+		return "", "", "", fmt.Errorf("address not right %s", address)
+	}
+
+	reference = "latest"
+	if i := strings.Index(repository, "@"); i != -1 {
+		repository, reference = repository[:i], repository[i+1:]
+		if !digestPattern.MatchString(reference) {
+			return "", "", "", fmt.Errorf("digest not right %s", reference)
+		}
+	} else if i := strings.LastIndex(repository, ":"); i != -1 {
+		repository, reference = repository[:i], repository[i+1:]
+		if !tagPattern.MatchString(reference) {
+			return "", "", "", fmt.Errorf("tag not right %s", reference)
+		}
+	}
+
+	if !repositoryPattern.MatchString(repository) {
+		return "", "", "", fmt.Errorf("repository not right %s", repository)
+	}
+	return registry, repository, reference, nil
+}
+
+// The patterns follow the OCI distribution spec.
+var (
+	sha256Pattern     = regexp.MustCompile(`^[a-fA-F0-9]{64}$`)
+	digestPattern     = regexp.MustCompile(`^[a-z0-9]+(?:[.+_-][a-z0-9]+)*:[a-zA-Z0-9=_-]+$`)
+	tagPattern        = regexp.MustCompile(`^[a-zA-Z0-9_][a-zA-Z0-9._-]{0,127}$`)
+	repositoryPattern = regexp.MustCompile(`^[a-z0-9]+(?:(?:\.|_|__|-+)[a-z0-9]+)*(?:/[a-z0-9]+(?:(?:\.|_|__|-+)[a-z0-9]+)*)*$`)
+)
+
+// ParsePVC address looks like: <claimName>/<modelPath>, the model path is relative to the root of the claim.
+func ParsePVC(address string) (claimName, modelPath string, err error) {
+	claimName, modelPath, _ = strings.Cut(address, "/")
+	modelPath = strings.Trim(modelPath, "/")
+	if len(validation.IsDNS1123Subdomain(claimName)) > 0 || modelPath == "" {
+		return "", "", fmt.Errorf("address not right %s", address)
+	}
+	return claimName, modelPath, nil
+}
+
+// ParseNFS address looks like: <server>/<modelPath>, the model path is absolute on the server.
+func ParseNFS(address string) (server, modelPath string, err error) {
+	server, modelPath, _ = strings.Cut(address, "/")
+	modelPath = strings.Trim(modelPath, "/")
+	if server == "" || modelPath == "" {
+		return "", "", fmt.Errorf("address not right %s", address)
+	}
+	return server, modelPath, nil
+}
+
+// ParseAzblob address looks like: <account>/<container>/<modelPath>
+func ParseAzblob(address string) (account, container, modelPath string, err error) {
+	splits := strings.SplitN(address, "/", 3)
+	if len(splits) != 3 || splits[0] == "" || splits[1] == "" || strings.Trim(splits[2], "/") == "" {
+		return "", "", "", fmt.Errorf("address not right %s", address)
+	}
+	return splits[0], splits[1], strings.Trim(splits[2], "/"), nil
+}
+
+// ParseABFSS address looks like: <container>@<account>.dfs.core.windows.net/<modelPath>
+func ParseABFSS(address string) (account, container, modelPath string, err error) {
+	container, rest, found := strings.Cut(address, "@")
+	if !found || container == "" {
+		return "", "", "", fmt.Errorf("address not right %s", address)
+	}
+	host, modelPath, _ := strings.Cut(rest, "/")
+	account, _, found = strings.Cut(host, ".dfs.")
+	modelPath = strings.Trim(modelPath, "/")
+	if !found || account == "" || modelPath == "" {
+		return "", "", "", fmt.Errorf("address not right %s", address)
+	}
+	return account, container, modelPath, nil
+}
@@ -19,6 +19,7 @@ package util
 import (
 	"errors"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -116,3 +117,280 @@ func TestParseURI(t *testing.T) {
 		}
 	}
 }
+
+func TestParseHTTPS(t *testing.T) {
+	checksum := "3f786850e387550fdab836ed7e6dc881de23001b3f786850e387550fdab836ed"
+	testCases := []struct {
+		name          string
+		address       string
+		wantModelPath string
+		wantChecksum  string
+		failed        bool
+	}{
+		{
+			name:          "normal address",
+			address:       "example.com/models/qwen2-0_5b-instruct-q5_k_m.gguf",
+			wantModelPath: "example.com/models/qwen2-0_5b-instruct-q5_k_m.gguf",
+		},
+		{
+			name:          "address with checksum",
+			address:       "example.com/models/qwen2.gguf#sha256=" + strings.ToUpper(checksum),
+			wantModelPath: "example.com/models/qwen2.gguf",
+			wantChecksum:  checksum,
+		},
+		{
+			name:    "unsupported checksum",
+			address: "example.com/models/qwen2.gguf#md5=d41d8cd98f00b204e9800998ecf8427e",
+			failed:  true,
+		},
+		{
+			name:    "malformed checksum",
+			address: "example.com/models/qwen2.gguf#sha256=abc",
+			failed:  true,
+		},
+		{
+			name:    "no path",
+			address: "example.com",
+			failed:  true,
+		},
+		{
+			name:    "directory",
+			address: "example.com/models/",
+			failed:  true,
+		},
+		{
+			name:    "query without path",
+			address: "example.com?file=qwen2.gguf",
+			failed:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gotModelPath, gotChecksum, err := ParseHTTPS(tc.address)
+			if tc.failed != (err != nil) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.wantModelPath != gotModelPath || tc.wantChecksum != gotChecksum {
+				t.Fatalf("unexpected result, got %s and %s", gotModelPath, gotChecksum)
+			}
+		})
+	}
+}
+
+func TestIsSHA256(t *testing.T) {
+	testCases := []struct {
+		checksum string
+		want     bool
+	}{
+		{checksum: "3f786850e387550fdab836ed7e6dc881de23001b3f786850e387550fdab836ed", want: true},
+		{checksum: "3F786850E387550FDAB836ED7E6DC881DE23001B3F786850E387550FDAB836ED", want: true},
+		{checksum: "sha256:3f786850e387550fdab836ed7e6dc881de23001b3f786850e387550fdab836ed", want: false},
+		{checksum: "3f786850e387550fdab836ed7e6dc881de23001b", want: false},
+		{checksum: "", want: false},
+	}
+
+	for _, tc := range testCases {
+		if got := IsSHA256(tc.checksum); got != tc.want {
+			t.Fatalf("IsSHA256(%q) = %v, want %v", tc.checksum, got, tc.want)
+		}
+	}
+}
+
+func TestParseOCI(t *testing.T) {
+	digest := "sha256:3f786850e387550fdab836ed7e6dc881de23001b3f786850e387550fdab836ed"
+	testCases := []struct {
+		name           string
+		address        string
+		wantRegistry   string
+		wantRepository string
+		wantReference  string
+		failed         bool
+	}{
+		{
+			name:           "default tag",
+			address:        "ghcr.io/inftyai/qwen2",
+			wantRegistry:   "ghcr.io",
+			wantRepository: "inftyai/qwen2",
+			wantReference:  "latest",
+		},
+		{
+			name:           "registry with port and tag",
+			address:        "localhost:5000/models/qwen2:0.5b-instruct",
+			wantRegistry:   "localhost:5000",
+			wantRepository: "models/qwen2",
+			wantReference:  "0.5b-instruct",
+		},
+		{
+			name:           "digest",
+			address:        "ghcr.io/inftyai/qwen2@" + digest,
+			wantRegistry:   "ghcr.io",
+			wantRepository: "inftyai/qwen2",
+			wantReference:  digest,
+		},
+		{
+			name:    "no repository",
+			address: "ghcr.io",
+			failed:  true,
+		},
+		{
+			name:    "uppercase repository",
+			address: "ghcr.io/InftyAI/qwen2",
+			failed:  true,
+		},
+		{
+			name:    "malformed digest",
+			address: "ghcr.io/inftyai/qwen2@sha256",
+			failed:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gotRegistry, gotRepository, gotReference, err := ParseOCI(tc.address)
+			if tc.failed != (err != nil) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.wantRegistry != gotRegistry || tc.wantRepository != gotRepository || tc.wantReference != gotReference {
+				t.Fatalf("unexpected result, got %s, %s and %s", gotRegistry, gotRepository, gotReference)
+			}
+		})
+	}
+}
+
+func TestParsePVC(t *testing.T) {
+	testCases := []struct {
+		name          string
+		address       string
+		wantClaimName string
+		wantModelPath string
+		failed        bool
+	}{
+		{
+			name:          "normal address",
+			address:       "models/llama3/Meta-Llama-3-8B/",
+			wantClaimName: "models",
+			wantModelPath: "llama3/Meta-Llama-3-8B",
+		},
+		{
+			name:    "no path",
+			address: "models/",
+			failed:  true,
+		},
+		{
+			name:    "invalid claim name",
+			address: "Models/llama3",
+			failed:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gotClaimName, gotModelPath, err := ParsePVC(tc.address)
+			if tc.failed != (err != nil) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.wantClaimName != gotClaimName || tc.wantModelPath != gotModelPath {
+				t.Fatalf("unexpected result, got %s and %s", gotClaimName, gotModelPath)
+			}
+		})
+	}
+}
+
+func TestParseNFS(t *testing.T) {
+	testCases := []struct {
+		name          string
+		address       string
+		wantServer    string
+		wantModelPath string
+		failed        bool
+	}{
+		{
+			name:          "normal address",
+			address:       "10.0.0.1/exports/models/qwen2.gguf",
+			wantServer:    "10.0.0.1",
+			wantModelPath: "exports/models/qwen2.gguf",
+		},
+		{
+			name:    "no server",
+			address: "/exports/models",
+			failed:  true,
+		},
+		{
+			name:    "no path",
+			address: "nfs.example.com",
+			failed:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gotServer, gotModelPath, err := ParseNFS(tc.address)
+			if tc.failed != (err != nil) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.wantServer != gotServer || tc.wantModelPath != gotModelPath {
+				t.Fatalf("unexpected result, got %s and %s", gotServer, gotModelPath)
+			}
+		})
+	}
+}
+
+func TestParseAzure(t *testing.T) {
+	testCases := []struct {
+		name          string
+		parse         func(string) (string, string, string, error)
+		address       string
+		wantAccount   string
+		wantContainer string
+		wantModelPath string
+		failed        bool
+	}{
+		{
+			name:          "azblob address",
+			parse:         ParseAzblob,
+			address:       "account/container/models/llama3",
+			wantAccount:   "account",
+			wantContainer: "container",
+			wantModelPath: "models/llama3",
+		},
+		{
+			name:    "azblob address without path",
+			parse:   ParseAzblob,
+			address: "account/container/",
+			failed:  true,
+		},
+		{
+			name:          "abfss address",
+			parse:         ParseABFSS,
+			address:       "container@account.dfs.core.windows.net/models/llama3",
+			wantAccount:   "account",
+			wantContainer: "container",
+			wantModelPath: "models/llama3",
+		},
+		{
+			name:    "abfss address without container",
+			parse:   ParseABFSS,
+			address: "account.dfs.core.windows.net/models/llama3",
+			failed:  true,
+		},
+		{
+			name:    "abfss address with blob endpoint",
+			parse:   ParseABFSS,
+			address: "container@account.blob.core.windows.net/models/llama3",
+			failed:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gotAccount, gotContainer, gotModelPath, err := tc.parse(tc.address)
+			if tc.failed != (err != nil) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.wantAccount != gotAccount || tc.wantContainer != gotContainer || tc.wantModelPath != gotModelPath {
+				t.Fatalf("unexpected result, got %s, %s and %s", gotAccount, gotContainer, gotModelPath)
+			}
+		})
+	}
+}
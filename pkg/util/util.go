@@ -18,6 +18,8 @@ package util
 
 import (
 	"fmt"
+	"hash/fnv"
+	"strconv"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
@@ -89,3 +91,55 @@ func MergeArgsWithCommand(command []string, args []string) []string {
 	command[len(command)-1] = fmt.Sprintf("%s %s", strings.TrimSuffix(command[len(command)-1], "\n"), fullArgs)
 	return command
 }
+
+// ShortName returns the name as it is if not longer than maxLength, otherwise it's truncated
+// and suffixed with the hash of the full name to stay unique, e.g. for the names of the
+// objects and the labels limited to 63 characters.
+func ShortName(name string, maxLength int) string {
+	if len(name) <= maxLength {
+		return name
+	}
+	hasher := fnv.New32a()
+	_, _ = hasher.Write([]byte(name))
+	hash := fmt.Sprintf("%08x", hasher.Sum32())
+	return strings.TrimRight(name[:maxLength-len(hash)-1], "-.") + "-" + hash
+}
+
+// MergeFlags merges the flags into the args by the flag names, the flags in args with the same
+// names are replaced, together with their values, and the others are kept as they are.
+// Both the "--flag value" and the "--flag=value" forms are supported.
+func MergeFlags(args []string, flags []string) []string {
+	names := map[string]bool{}
+	for _, flag := range flags {
+		if isFlag(flag) {
+			names[flagName(flag)] = true
+		}
+	}
+
+	merged := make([]string, 0, len(args)+len(flags))
+	for i := 0; i < len(args); i++ {
+		if !isFlag(args[i]) || !names[flagName(args[i])] {
+			merged = append(merged, args[i])
+			continue
+		}
+		// Skip the value of the replaced flag as well.
+		if !strings.Contains(args[i], "=") && i+1 < len(args) && !isFlag(args[i+1]) {
+			i++
+		}
+	}
+	return append(merged, flags...)
+}
+
+// isFlag returns true if the arg is a flag rather than a value, negative numbers are values.
+func isFlag(arg string) bool {
+	if len(arg) < 2 || !strings.HasPrefix(arg, "-") {
+		return false
+	}
+	_, err := strconv.ParseFloat(arg, 64)
+	return err != nil
+}
+
+func flagName(flag string) string {
+	name, _, _ := strings.Cut(flag, "=")
+	return name
+}
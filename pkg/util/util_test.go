@@ -17,6 +17,7 @@ limitations under the License.
 package util
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -268,3 +269,108 @@ func TestMergeArgsWithCommand(t *testing.T) {
 		})
 	}
 }
+
+func TestMergeFlags(t *testing.T) {
+	testCases := []struct {
+		name  string
+		args  []string
+		flags []string
+		want  []string
+	}{
+		{
+			name: "no flags",
+			args: []string{"--model", "/workspace/models", "--port", "8080"},
+			want: []string{"--model", "/workspace/models", "--port", "8080"},
+		},
+		{
+			name:  "new flags appended",
+			args:  []string{"--model", "/workspace/models"},
+			flags: []string{"--tensor-parallel-size", "2"},
+			want:  []string{"--model", "/workspace/models", "--tensor-parallel-size", "2"},
+		},
+		{
+			name:  "flags with values replaced",
+			args:  []string{"--model", "/workspace/models", "--tensor-parallel-size", "1", "--port", "8080"},
+			flags: []string{"--tensor-parallel-size=2"},
+			want:  []string{"--model", "/workspace/models", "--port", "8080", "--tensor-parallel-size=2"},
+		},
+		{
+			name:  "flags in the equal form replaced",
+			args:  []string{"--model=/workspace/models", "--max-model-len=8192", "--port", "8080"},
+			flags: []string{"--max-model-len", "4096"},
+			want:  []string{"--model=/workspace/models", "--port", "8080", "--max-model-len", "4096"},
+		},
+		{
+			name:  "boolean flags replaced",
+			args:  []string{"--enforce-eager", "--port", "8080"},
+			flags: []string{"--enforce-eager"},
+			want:  []string{"--port", "8080", "--enforce-eager"},
+		},
+		{
+			name:  "negative values",
+			args:  []string{"--seed", "-1", "--port", "8080"},
+			flags: []string{"--seed", "42"},
+			want:  []string{"--port", "8080", "--seed", "42"},
+		},
+		{
+			name:  "positional args kept",
+			args:  []string{"serve", "/workspace/models", "-tp", "1"},
+			flags: []string{"-tp", "2"},
+			want:  []string{"serve", "/workspace/models", "-tp", "2"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, MergeFlags(tc.args, tc.flags)); diff != "" {
+				t.Fatalf("unexpected args: %s", diff)
+			}
+		})
+	}
+}
+
+func TestShortName(t *testing.T) {
+	testCases := []struct {
+		name      string
+		input     string
+		maxLength int
+		want      string
+	}{
+		{
+			name:      "short name",
+			input:     "llama3-8b",
+			maxLength: 63,
+			want:      "llama3-8b",
+		},
+		{
+			name:      "name with the max length",
+			input:     strings.Repeat("a", 63),
+			maxLength: 63,
+			want:      strings.Repeat("a", 63),
+		},
+		{
+			name:      "long name",
+			input:     strings.Repeat("a", 70),
+			maxLength: 63,
+			want:      strings.Repeat("a", 54) + "-" + "5904740b",
+		},
+		{
+			name:      "truncated at the separators",
+			input:     "model-preheat-" + strings.Repeat("a", 16) + "-" + strings.Repeat("b", 40),
+			maxLength: 40,
+			want:      "model-preheat-" + strings.Repeat("a", 16) + "-" + "bc479e3e",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := ShortName(tc.input, tc.maxLength)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("unexpected name: %s", diff)
+			}
+			if len(got) > tc.maxLength {
+				t.Fatalf("name %s is longer than %d", got, tc.maxLength)
+			}
+		})
+	}
+}
@@ -27,7 +27,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	inferenceapi "github.com/inftyai/llmaz/api/inference/v1alpha1"
-	"github.com/inftyai/llmaz/pkg/util"
+	backendruntime "github.com/inftyai/llmaz/pkg/controller_helper/backendruntime"
 )
 
 type BackendRuntimeWebhook struct{}
@@ -91,12 +91,37 @@ func (w *BackendRuntimeWebhook) generateValidate(obj runtime.Object) field.Error
 		}
 	}
 
-	names := []string{}
-	for _, recommend := range backend.Spec.RecommendedConfigs {
-		if util.In(names, recommend.Name) {
-			allErrs = append(allErrs, field.Forbidden(specPath.Child("args", "name"), fmt.Sprintf("duplicated name %s", recommend.Name)))
+	for i, recommend := range backend.Spec.RecommendedConfigs {
+		if err := backendruntime.ValidateArgs(recommend.Args); err != nil {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("recommendedConfigs").Index(i).Child("args"), recommend.Args, err.Error()))
+		}
+		if recommend.MultiNode == nil {
+			continue
+		}
+		multiNodePath := specPath.Child("recommendedConfigs").Index(i).Child("multiNode")
+		if recommend.MultiNode.Leader != nil {
+			if err := backendruntime.ValidateArgs(recommend.MultiNode.Leader.Args); err != nil {
+				allErrs = append(allErrs, field.Invalid(multiNodePath.Child("leader", "args"), recommend.MultiNode.Leader.Args, err.Error()))
+			}
+		}
+		if recommend.MultiNode.Worker != nil {
+			if err := backendruntime.ValidateArgs(recommend.MultiNode.Worker.Args); err != nil {
+				allErrs = append(allErrs, field.Invalid(multiNodePath.Child("worker", "args"), recommend.MultiNode.Worker.Args, err.Error()))
+			}
+		}
+	}
+
+	// Configs could share the same name only with disjoint flavors or modelFamilies,
+	// otherwise more than one config could match the same workload.
+	configs := backend.Spec.RecommendedConfigs
+	for i := range configs {
+		for j := 0; j < i; j++ {
+			if backendruntime.RecommendedConfigsOverlap(configs[j], configs[i]) {
+				allErrs = append(allErrs, field.Forbidden(specPath.Child("recommendedConfigs").Index(i),
+					fmt.Sprintf("config %s overlaps with recommendedConfigs[%d] on flavors and modelFamilies", configs[i].Name, j)))
+				break
+			}
 		}
-		names = append(names, recommend.Name)
 	}
 	return allErrs
 }
@@ -0,0 +1,111 @@
+/*
+Copyright 2025 The InftyAI Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"path"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	coreapi "github.com/inftyai/llmaz/api/core/v1alpha1"
+	helper "github.com/inftyai/llmaz/pkg/controller_helper"
+	modelSource "github.com/inftyai/llmaz/pkg/controller_helper/modelsource"
+	"github.com/inftyai/llmaz/pkg/util"
+)
+
+// NamespacedModelHostPathPrefix is the only host path allowed for the namespaced Models,
+// followed by the namespace of the model, so the teams can't read the models of others.
+const NamespacedModelHostPathPrefix = "/mnt/models/"
+
+type ModelWebhook struct{}
+
+// SetupModelWebhook will setup the manager to manage the webhooks
+func SetupModelWebhook(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&coreapi.Model{}).
+		WithDefaulter(&ModelWebhook{}).
+		WithValidator(&ModelWebhook{}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-llmaz-io-v1alpha1-model,mutating=true,failurePolicy=fail,sideEffects=None,groups=llmaz.io,resources=models,verbs=create;update,versions=v1alpha1,name=mmodel.kb.io,admissionReviewVersions=v1
+
+var _ webhook.CustomDefaulter = &ModelWebhook{}
+
+// Default implements webhook.Defaulter so a webhook will be registered for the type
+func (w *ModelWebhook) Default(ctx context.Context, obj runtime.Object) error {
+	model := obj.(*coreapi.Model)
+	defaultModel(&model.ObjectMeta, &model.Spec)
+	return nil
+}
+
+//+kubebuilder:webhook:path=/validate-llmaz-io-v1alpha1-model,mutating=false,failurePolicy=fail,sideEffects=None,groups=llmaz.io,resources=models,verbs=create;update,versions=v1alpha1,name=vmodel.kb.io,admissionReviewVersions=v1
+
+var _ webhook.CustomValidator = &ModelWebhook{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (w *ModelWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	allErrs := w.generateValidate(obj)
+	model := obj.(*coreapi.Model)
+	for _, err := range validation.IsDNS1123Label(model.Name) {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("metadata.name"), model.Name, err))
+	}
+	return nil, allErrs.ToAggregate()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (w *ModelWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	allErrs := w.generateValidate(newObj)
+	return nil, allErrs.ToAggregate()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (w *ModelWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (w *ModelWebhook) generateValidate(obj runtime.Object) field.ErrorList {
+	model := helper.OpenModelFrom(obj.(*coreapi.Model))
+	specPath := field.NewPath("spec")
+
+	allErrs := validateModel(model)
+
+	// The cache and the preheated nodes are shared across the cluster.
+	if model.Spec.Cache != nil {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("cache"), "Cache is not supported for namespaced models"))
+	}
+	if modelSource.ModelPreheatEnabled(model) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("metadata", "annotations").Key(coreapi.ModelPreheatAnnoKey), "Preheat is not supported for namespaced models"))
+	}
+
+	if model.Spec.Source.URI != nil {
+		if protocol, address, err := util.ParseURI(string(*model.Spec.Source.URI)); err == nil && protocol == modelSource.HostPath {
+			prefix := NamespacedModelHostPathPrefix + model.Namespace + "/"
+			if !strings.HasPrefix(path.Clean(address), prefix) {
+				allErrs = append(allErrs, field.Invalid(specPath.Child("source", "uri"), *model.Spec.Source.URI, "host path of namespaced models should be under "+prefix))
+			}
+		}
+	}
+	return allErrs
+}
@@ -18,6 +18,10 @@ package webhook
 
 import (
 	"context"
+	"maps"
+	"path"
+	"slices"
+	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -54,20 +58,30 @@ var SUPPORTED_OBJ_STORES = map[string]struct{}{
 	modelSource.S3:       {},
 	modelSource.Ollama:   {},
 	modelSource.HostPath: {},
+	modelSource.HTTPS:    {},
+	modelSource.OCI:      {},
+	modelSource.PVC:      {},
+	modelSource.NFS:      {},
+	modelSource.AZBLOB:   {},
+	modelSource.ABFSS:    {},
 }
 
 // Default implements webhook.Defaulter so a webhook will be registered for the type
 func (w *OpenModelWebhook) Default(ctx context.Context, obj runtime.Object) error {
 	model := obj.(*coreapi.OpenModel)
-	if model.Labels == nil {
-		model.Labels = map[string]string{}
-	}
-	model.Labels[coreapi.ModelFamilyNameLabelKey] = string(model.Spec.FamilyName)
-	createdAt := ptr.Deref[metav1.Time](model.Spec.CreatedAt, metav1.Now().Rfc3339Copy())
-	model.Spec.CreatedAt = &createdAt
+	defaultModel(&model.ObjectMeta, &model.Spec)
 	return nil
 }
 
+func defaultModel(meta *metav1.ObjectMeta, spec *coreapi.ModelSpec) {
+	if meta.Labels == nil {
+		meta.Labels = map[string]string{}
+	}
+	meta.Labels[coreapi.ModelFamilyNameLabelKey] = string(spec.FamilyName)
+	createdAt := ptr.Deref[metav1.Time](spec.CreatedAt, metav1.Now().Rfc3339Copy())
+	spec.CreatedAt = &createdAt
+}
+
 //+kubebuilder:webhook:path=/validate-llmaz-io-v1alpha1-openmodel,mutating=false,failurePolicy=fail,sideEffects=None,groups=llmaz.io,resources=openmodels,verbs=create;update,versions=v1alpha1,name=vopenmodel.kb.io,admissionReviewVersions=v1
 
 var _ webhook.CustomValidator = &OpenModelWebhook{}
@@ -93,10 +107,12 @@ func (w *OpenModelWebhook) ValidateDelete(ctx context.Context, obj runtime.Objec
 	return nil, nil
 }
 
-// TODO: once namespaced models enabled, we should validate that only such volume path
-// /mnt/models/<model-namespace>/ is allowed.
 func (w *OpenModelWebhook) generateValidate(obj runtime.Object) field.ErrorList {
-	model := obj.(*coreapi.OpenModel)
+	return validateModel(obj.(*coreapi.OpenModel))
+}
+
+// validateModel validates the spec of the models, shared by the OpenModels and the namespaced Models.
+func validateModel(model *coreapi.OpenModel) field.ErrorList {
 	specPath := field.NewPath("spec")
 	sourcePath := specPath.Child("source")
 
@@ -121,6 +137,30 @@ func (w *OpenModelWebhook) generateValidate(obj runtime.Object) field.ErrorList
 					if _, _, err := util.ParseS3(address); err != nil {
 						allErrs = append(allErrs, field.Invalid(sourcePath.Child("uri"), *model.Spec.Source.URI, "URI with wrong address"))
 					}
+				case modelSource.HTTPS:
+					if _, _, err := util.ParseHTTPS(address); err != nil {
+						allErrs = append(allErrs, field.Invalid(sourcePath.Child("uri"), *model.Spec.Source.URI, "URI with wrong address: "+err.Error()))
+					}
+				case modelSource.OCI:
+					if _, _, _, err := util.ParseOCI(address); err != nil {
+						allErrs = append(allErrs, field.Invalid(sourcePath.Child("uri"), *model.Spec.Source.URI, "URI with wrong address: "+err.Error()))
+					}
+				case modelSource.PVC:
+					if _, _, err := util.ParsePVC(address); err != nil {
+						allErrs = append(allErrs, field.Invalid(sourcePath.Child("uri"), *model.Spec.Source.URI, "URI with wrong address"))
+					}
+				case modelSource.NFS:
+					if _, _, err := util.ParseNFS(address); err != nil {
+						allErrs = append(allErrs, field.Invalid(sourcePath.Child("uri"), *model.Spec.Source.URI, "URI with wrong address"))
+					}
+				case modelSource.AZBLOB:
+					if _, _, _, err := util.ParseAzblob(address); err != nil {
+						allErrs = append(allErrs, field.Invalid(sourcePath.Child("uri"), *model.Spec.Source.URI, "URI with wrong address"))
+					}
+				case modelSource.ABFSS:
+					if _, _, _, err := util.ParseABFSS(address); err != nil {
+						allErrs = append(allErrs, field.Invalid(sourcePath.Child("uri"), *model.Spec.Source.URI, "URI with wrong address"))
+					}
 				}
 			}
 		}
@@ -141,5 +181,79 @@ func (w *OpenModelWebhook) generateValidate(obj runtime.Object) field.ErrorList
 		}
 	}
 
+	// The credentials depend on the source, so only check the secretRef of valid sources.
+	if len(allErrs) == 0 && model.Spec.Source.SecretRef != nil {
+		allErrs = append(allErrs, validateSecretRef(model, sourcePath.Child("secretRef"))...)
+	}
+
+	if len(allErrs) == 0 && len(model.Spec.Source.Checksums) > 0 {
+		allErrs = append(allErrs, validateChecksums(model, sourcePath.Child("checksums"))...)
+	}
+
+	if hub := model.Spec.Source.ModelHub; hub != nil && ptr.Deref(hub.PinRevision, false) && ptr.Deref(hub.Name, coreapi.HUGGING_FACE) != coreapi.HUGGING_FACE {
+		allErrs = append(allErrs, field.Invalid(sourcePath.Child("modelHub.pinRevision"), true, "PinRevision can only set once modelHub is Huggingface"))
+	}
+
+	// The URI is parsed when building the provider, so only check the cache of valid sources.
+	if len(allErrs) == 0 && model.Spec.Cache != nil && !modelSource.ModelCacheSupported(model) {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("cache"), "Cache is not supported for host path, pvc, nfs and ollama models"))
+	}
+
+	if len(allErrs) == 0 && modelSource.ModelPreheatEnabled(model) {
+		annotationPath := field.NewPath("metadata", "annotations").Key(coreapi.ModelPreheatAnnoKey)
+		if !modelSource.ModelCacheSupported(model) {
+			allErrs = append(allErrs, field.Forbidden(annotationPath, "Preheat is not supported for host path, pvc, nfs and ollama models"))
+		}
+		if !modelSource.ModelCachedOnHost(model) {
+			allErrs = append(allErrs, field.Forbidden(annotationPath, "Preheat is not supported for models cached in the PersistentVolume"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateSecretRef checks the secret referenced by the model source, only the credentials
+// supported by the source could be mapped to the keys of the secret.
+func validateSecretRef(model *coreapi.OpenModel, refPath *field.Path) field.ErrorList {
+	ref := model.Spec.Source.SecretRef
+	credentials := modelSource.CredentialKeys(model)
+	if len(credentials) == 0 {
+		return field.ErrorList{field.Forbidden(refPath, "SecretRef is not supported for host path, pvc, nfs and ollama models")}
+	}
+
+	var allErrs field.ErrorList
+	for _, msg := range validation.IsDNS1123Subdomain(ref.Name) {
+		allErrs = append(allErrs, field.Invalid(refPath.Child("name"), ref.Name, msg))
+	}
+	for _, credential := range slices.Sorted(maps.Keys(ref.Keys)) {
+		keyPath := refPath.Child("keys").Key(credential)
+		if !slices.Contains(credentials, credential) {
+			allErrs = append(allErrs, field.NotSupported(keyPath, credential, credentials))
+		}
+		for _, msg := range validation.IsConfigMapKey(ref.Keys[credential]) {
+			allErrs = append(allErrs, field.Invalid(keyPath, ref.Keys[credential], msg))
+		}
+	}
+	return allErrs
+}
+
+// validateChecksums checks the checksums are sha256 of the files within the model,
+// which are verified by the model loader once downloaded.
+func validateChecksums(model *coreapi.OpenModel, checksumsPath *field.Path) field.ErrorList {
+	// The models not supported to cache are not downloaded by the model loader.
+	if !modelSource.ModelCacheSupported(model) {
+		return field.ErrorList{field.Forbidden(checksumsPath, "Checksums are not supported for host path, pvc, nfs and ollama models")}
+	}
+
+	var allErrs field.ErrorList
+	for _, filePath := range slices.Sorted(maps.Keys(model.Spec.Source.Checksums)) {
+		keyPath := checksumsPath.Key(filePath)
+		if path.IsAbs(filePath) || path.Clean(filePath) != filePath || filePath == "." || filePath == ".." || strings.HasPrefix(filePath, "../") {
+			allErrs = append(allErrs, field.Invalid(keyPath, filePath, "should be a path relative to the model"))
+		}
+		if checksum := model.Spec.Source.Checksums[filePath]; !util.IsSHA256(checksum) {
+			allErrs = append(allErrs, field.Invalid(keyPath, checksum, "should be a hex encoded sha256"))
+		}
+	}
 	return allErrs
 }
@@ -19,27 +19,44 @@ package webhook
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	coreapi "github.com/inftyai/llmaz/api/core/v1alpha1"
 	inferenceapi "github.com/inftyai/llmaz/api/inference/v1alpha1"
 	helper "github.com/inftyai/llmaz/pkg/controller_helper"
+	modelSource "github.com/inftyai/llmaz/pkg/controller_helper/modelsource"
+	"github.com/inftyai/llmaz/pkg/util"
 )
 
-type PlaygroundWebhook struct{}
+type PlaygroundWebhook struct {
+	// client reads the claimed models to check whether the flavors fit them,
+	// and the claims of the models on PVC.
+	client client.Client
+}
 
 // SetupPlaygroundWebhook will setup the manager to manage the webhooks
 func SetupPlaygroundWebhook(mgr ctrl.Manager) error {
+	w := &PlaygroundWebhook{client: mgr.GetClient()}
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(&inferenceapi.Playground{}).
-		WithDefaulter(&PlaygroundWebhook{}).
-		WithValidator(&PlaygroundWebhook{}).
+		WithDefaulter(w).
+		WithValidator(w).
 		Complete()
 }
 
@@ -67,9 +84,84 @@ func (w *PlaygroundWebhook) Default(ctx context.Context, obj runtime.Object) err
 	}
 	playground.Labels[coreapi.ModelNameLabelKey] = modelName
 
+	return w.defaultInferenceFlavors(ctx, playground)
+}
+
+// defaultInferenceFlavors claims the flavors fitting the main model, the smallest one is
+// preferred, once the playground claims no flavors and the model metadata is discovered.
+// Only the creation is defaulted, otherwise the flavors would change with the model metadata
+// discovered later and roll out the running workloads.
+func (w *PlaygroundWebhook) defaultInferenceFlavors(ctx context.Context, playground *inferenceapi.Playground) error {
+	if req, err := admission.RequestFromContext(ctx); err != nil || req.Operation != admissionv1.Create {
+		return nil
+	}
+
+	var flavors *[]coreapi.FlavorName
+	if playground.Spec.ModelClaim != nil {
+		flavors = &playground.Spec.ModelClaim.InferenceFlavors
+	} else if playground.Spec.ModelClaims != nil {
+		flavors = &playground.Spec.ModelClaims.InferenceFlavors
+	}
+	if flavors == nil || len(*flavors) > 0 {
+		return nil
+	}
+
+	if model := w.mainModel(ctx, playground); model != nil {
+		*flavors = helper.RecommendFlavors(model)
+	}
 	return nil
 }
 
+// mainModel returns the main model of the playground, nil if not found. The fitness of
+// the flavors is a best-effort check, so failing to fetch the models will not be rejected.
+func (w *PlaygroundWebhook) mainModel(ctx context.Context, playground *inferenceapi.Playground) *coreapi.OpenModel {
+	if w.client == nil {
+		return nil
+	}
+	models, err := helper.FetchModelsByPlayground(ctx, w.client, playground)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			log.FromContext(ctx).Error(err, "failed to fetch the models", "Playground", klog.KObj(playground))
+		}
+		return nil
+	}
+	if len(models) == 0 {
+		return nil
+	}
+	return models[0]
+}
+
+// generateWarnings warns about the claimed flavors which can't fit the main model,
+// all the flavors of the model are checked if none claimed.
+func (w *PlaygroundWebhook) generateWarnings(ctx context.Context, obj runtime.Object) admission.Warnings {
+	playground := obj.(*inferenceapi.Playground)
+	model := w.mainModel(ctx, playground)
+	if model == nil || model.Spec.InferenceConfig == nil {
+		return nil
+	}
+
+	var claimed []coreapi.FlavorName
+	if playground.Spec.ModelClaim != nil {
+		claimed = playground.Spec.ModelClaim.InferenceFlavors
+	} else if playground.Spec.ModelClaims != nil {
+		claimed = playground.Spec.ModelClaims.InferenceFlavors
+	}
+
+	required := helper.EstimateAcceleratorMemory(model)
+	var warnings admission.Warnings
+	for i := range model.Spec.InferenceConfig.Flavors {
+		flavor := &model.Spec.InferenceConfig.Flavors[i]
+		if len(claimed) > 0 && !slices.Contains(claimed, flavor.Name) {
+			continue
+		}
+		if !helper.FlavorFits(model, flavor) {
+			warnings = append(warnings, fmt.Sprintf("flavor %s provides %s accelerator memory, less than the estimated %s required by model %s",
+				flavor.Name, helper.FlavorAcceleratorMemory(flavor), required, model.Name))
+		}
+	}
+	return warnings
+}
+
 //+kubebuilder:webhook:path=/validate-inference-llmaz-io-v1alpha1-playground,mutating=false,failurePolicy=fail,sideEffects=None,groups=inference.llmaz.io,resources=playgrounds,verbs=create;update,versions=v1alpha1,name=vplayground.kb.io,admissionReviewVersions=v1
 
 var _ webhook.CustomValidator = &PlaygroundWebhook{}
@@ -81,13 +173,21 @@ func (w *PlaygroundWebhook) ValidateCreate(ctx context.Context, obj runtime.Obje
 	for _, err := range validation.IsDNS1123Label(playground.Name) {
 		allErrs = append(allErrs, field.Invalid(field.NewPath("metadata.name"), playground.Name, err))
 	}
-	return nil, allErrs.ToAggregate()
+	allErrs = append(allErrs, w.validateSourceReferences(ctx, playground)...)
+	if err := allErrs.ToAggregate(); err != nil {
+		return nil, err
+	}
+	return w.generateWarnings(ctx, obj), nil
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
 func (w *PlaygroundWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
 	allErrs := w.generateValidate(newObj)
-	return nil, allErrs.ToAggregate()
+	allErrs = append(allErrs, w.validateSourceReferences(ctx, newObj.(*inferenceapi.Playground))...)
+	if err := allErrs.ToAggregate(); err != nil {
+		return nil, err
+	}
+	return w.generateWarnings(ctx, newObj), nil
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
@@ -95,6 +195,77 @@ func (w *PlaygroundWebhook) ValidateDelete(ctx context.Context, obj runtime.Obje
 	return nil, nil
 }
 
+// validateSourceReferences checks the claims of the models on PVC and the secrets referenced by the models
+// exist in the namespace of the playground, which are required by the workloads. Models not found are left
+// to the controller.
+func (w *PlaygroundWebhook) validateSourceReferences(ctx context.Context, playground *inferenceapi.Playground) field.ErrorList {
+	if w.client == nil {
+		return nil
+	}
+	return validateSourceReferences(ctx, w.client, playground.Namespace, helper.ModelRefsByPlayground(playground), func(i int) *field.Path {
+		if playground.Spec.ModelClaims != nil {
+			return field.NewPath("spec", "modelClaims", "models").Index(i).Child("name")
+		}
+		return field.NewPath("spec", "modelClaim", "modelName")
+	})
+}
+
+// validateSourceReferences checks the claims and the secrets required by the workloads of the models exist
+// in the namespace, refPath returns the path of the i-th model reference. The secret referenced by a model
+// should store the required credentials, or at least one of them once they are alternatives of each other.
+func validateSourceReferences(ctx context.Context, c client.Client, namespace string, refs []coreapi.ModelRef, refPath func(int) *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, ref := range refs {
+		model, err := helper.FetchModel(ctx, c, namespace, ref.Name)
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				allErrs = append(allErrs, field.InternalError(refPath(i), fmt.Errorf("failed to fetch the model: %w", err)))
+			}
+			continue
+		}
+		if claimName := modelSource.SourceClaimName(model); claimName != "" {
+			claim := &corev1.PersistentVolumeClaim{}
+			if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: claimName}, claim); err != nil {
+				if apierrors.IsNotFound(err) {
+					allErrs = append(allErrs, field.Invalid(refPath(i), ref.Name,
+						fmt.Sprintf("PersistentVolumeClaim %s storing the model not found in namespace %s", claimName, namespace)))
+				} else {
+					allErrs = append(allErrs, field.InternalError(refPath(i), fmt.Errorf("failed to fetch the PersistentVolumeClaim %s: %w", claimName, err)))
+				}
+			}
+		}
+
+		if secretRef := model.Spec.Source.SecretRef; secretRef != nil {
+			secret := &corev1.Secret{}
+			if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: secretRef.Name}, secret); err != nil {
+				if apierrors.IsNotFound(err) {
+					allErrs = append(allErrs, field.Invalid(refPath(i), ref.Name,
+						fmt.Sprintf("Secret %s storing the credentials of the model not found in namespace %s", secretRef.Name, namespace)))
+				} else {
+					allErrs = append(allErrs, field.InternalError(refPath(i), fmt.Errorf("failed to fetch the Secret %s: %w", secretRef.Name, err)))
+				}
+				continue
+			}
+			hasCredential := func(credential string) bool {
+				_, ok := secret.Data[modelSource.SecretKey(model, credential)]
+				return ok
+			}
+			required := modelSource.RequiredCredentialKeys(model)
+			for _, credential := range required {
+				if !hasCredential(credential) {
+					allErrs = append(allErrs, field.Invalid(refPath(i), ref.Name,
+						fmt.Sprintf("key %s of the credential %s not found in Secret %s", modelSource.SecretKey(model, credential), credential, secretRef.Name)))
+				}
+			}
+			if credentials := modelSource.CredentialKeys(model); len(required) == 0 && len(credentials) > 0 && !slices.ContainsFunc(credentials, hasCredential) {
+				allErrs = append(allErrs, field.Invalid(refPath(i), ref.Name,
+					fmt.Sprintf("none of the credentials %s found in Secret %s", strings.Join(credentials, ", "), secretRef.Name)))
+			}
+		}
+	}
+	return allErrs
+}
+
 func (w *PlaygroundWebhook) generateValidate(obj runtime.Object) field.ErrorList {
 	playground := obj.(*inferenceapi.Playground)
 	specPath := field.NewPath("spec")
@@ -107,24 +278,13 @@ func (w *PlaygroundWebhook) generateValidate(obj runtime.Object) field.ErrorList
 		allErrs = append(allErrs, field.Forbidden(specPath, "modelClaim and modelClaims couldn't be both not nil"))
 	}
 	if playground.Spec.ModelClaims != nil {
-		mainModelCount := 0
-
-		for _, model := range playground.Spec.ModelClaims.Models {
-			if model.Name == coreapi.ModelName(coreapi.MainRole) {
-				mainModelCount += 1
-			}
-		}
-
-		// We only have to detect whether this is speculativeDecoding mode, so set the second argument to false is ok.
-		arg := helper.DetectArgFrom(playground)
-		if arg == helper.SpeculativeDecodingArg {
-			if len(playground.Spec.ModelClaims.Models) != 2 {
-				allErrs = append(allErrs, field.Forbidden(specPath.Child("modelClaims", "models"), "only two models are allowed in speculativeDecoding mode"))
-			}
-		}
-
-		if mainModelCount > 1 {
-			allErrs = append(allErrs, field.Forbidden(specPath.Child("modelClaims", "models"), "only one main model is allowed"))
+		allErrs = append(allErrs, validateModelRoles(playground.Spec.ModelClaims.Models, specPath.Child("modelClaims", "models"))...)
+	}
+	if helper.DynamicLoRAEnabled(playground) {
+		if playground.Spec.ModelClaims == nil {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("loraConfig", "dynamicLoading"), "lora models should be claimed with modelClaims"))
+		} else if helper.DetectArgFrom(playground) == helper.SpeculativeDecodingArg {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("loraConfig", "dynamicLoading"), "dynamicLoading is not allowed in speculativeDecoding mode"))
 		}
 	}
 
@@ -153,6 +313,26 @@ func (w *PlaygroundWebhook) generateValidate(obj runtime.Object) field.ErrorList
 				allErrs = append(allErrs, field.Invalid(specPath.Child("elasticConfig.scaleTrigger.hpa"), *playground.Spec.ElasticConfig.MinReplicas, "minReplicas must be less than maxReplicas"))
 			}
 		}
+
+		allErrs = append(allErrs, validateScaleToZero(playground.Spec.ElasticConfig.ScaleToZero, specPath.Child("elasticConfig", "scaleToZero"))...)
+
+		for i, warmup := range playground.Spec.ElasticConfig.WarmupSchedules {
+			warmupPath := specPath.Child("elasticConfig", "warmupSchedules").Index(i)
+			if _, err := util.ParseCron(warmup.Schedule); err != nil {
+				allErrs = append(allErrs, field.Invalid(warmupPath.Child("schedule"), warmup.Schedule, err.Error()))
+			}
+			if warmup.TimeZone != nil {
+				if _, err := time.LoadLocation(*warmup.TimeZone); err != nil {
+					allErrs = append(allErrs, field.Invalid(warmupPath.Child("timeZone"), *warmup.TimeZone, err.Error()))
+				}
+			}
+			if warmup.Duration.Duration <= 0 {
+				allErrs = append(allErrs, field.Invalid(warmupPath.Child("duration"), warmup.Duration.Duration.String(), "duration must be greater than 0"))
+			}
+			if warmup.Replicas != nil && playground.Spec.ElasticConfig.MaxReplicas > 0 && *warmup.Replicas > playground.Spec.ElasticConfig.MaxReplicas {
+				allErrs = append(allErrs, field.Invalid(warmupPath.Child("replicas"), *warmup.Replicas, "replicas must be less than or equal to maxReplicas"))
+			}
+		}
 	}
 
 	if playground.Spec.ElasticConfig != nil && playground.Spec.ElasticConfig.ScaleTrigger != nil {
@@ -23,6 +23,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
@@ -31,14 +32,18 @@ import (
 	modelSource "github.com/inftyai/llmaz/pkg/controller_helper/modelsource"
 )
 
-type ServiceWebhook struct{}
+type ServiceWebhook struct {
+	// client reads the claimed models to check the claims and the secrets required by the workloads.
+	client client.Client
+}
 
 // SetupServiceWebhook will setup the manager to manage the webhooks
 func SetupServiceWebhook(mgr ctrl.Manager) error {
+	w := &ServiceWebhook{client: mgr.GetClient()}
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(&inferenceapi.Service{}).
-		WithDefaulter(&ServiceWebhook{}).
-		WithValidator(&ServiceWebhook{}).
+		WithDefaulter(w).
+		WithValidator(w).
 		Complete()
 }
 
@@ -73,6 +78,7 @@ func (w *ServiceWebhook) ValidateCreate(ctx context.Context, obj runtime.Object)
 	if !runnerContainerExists {
 		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec.workloadTemplate.workerTemplate.spec.containers"), "model-runner container doesn't exist"))
 	}
+	allErrs = append(allErrs, w.validateSourceReferences(ctx, service)...)
 
 	return nil, allErrs.ToAggregate()
 }
@@ -80,6 +86,7 @@ func (w *ServiceWebhook) ValidateCreate(ctx context.Context, obj runtime.Object)
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
 func (w *ServiceWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
 	allErrs := w.generateValidate(newObj)
+	allErrs = append(allErrs, w.validateSourceReferences(ctx, newObj.(*inferenceapi.Service))...)
 	return nil, allErrs.ToAggregate()
 }
 
@@ -88,29 +95,69 @@ func (w *ServiceWebhook) ValidateDelete(ctx context.Context, obj runtime.Object)
 	return nil, nil
 }
 
+// validateSourceReferences checks the claims of the models on PVC and the secrets referenced by the models
+// exist in the namespace of the service, see the one of the PlaygroundWebhook.
+func (w *ServiceWebhook) validateSourceReferences(ctx context.Context, service *inferenceapi.Service) field.ErrorList {
+	if w.client == nil {
+		return nil
+	}
+	return validateSourceReferences(ctx, w.client, service.Namespace, service.Spec.ModelClaims.Models, func(i int) *field.Path {
+		return field.NewPath("spec", "modelClaims", "models").Index(i).Child("name")
+	})
+}
+
 func (w *ServiceWebhook) generateValidate(obj runtime.Object) field.ErrorList {
 	service := obj.(*inferenceapi.Service)
 	specPath := field.NewPath("spec")
 	var allErrs field.ErrorList
 
-	mainModelCount := 0
-	var speculativeDecoding bool
-	for _, model := range service.Spec.ModelClaims.Models {
-		if model.Role == nil || *model.Role == coreapi.MainRole {
+	allErrs = append(allErrs, validateModelRoles(service.Spec.ModelClaims.Models, specPath.Child("modelClaims", "models"))...)
+	allErrs = append(allErrs, validateScaleToZero(service.Spec.ScaleToZero, specPath.Child("scaleToZero"))...)
+	return allErrs
+}
+
+// validateModelRoles validates the roles of the claimed models, exactly one main model is required,
+// speculativeDecoding mode takes one draft model and any number of lora models are allowed otherwise.
+func validateModelRoles(models []coreapi.ModelRef, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	var mainModelCount, draftModelCount, loraModelCount int
+	for _, model := range models {
+		switch {
+		case model.Role == nil || *model.Role == coreapi.MainRole:
 			mainModelCount += 1
-		}
-		if model.Role != nil && *model.Role == coreapi.DraftRole {
-			speculativeDecoding = true
+		case *model.Role == coreapi.DraftRole:
+			draftModelCount += 1
+		case *model.Role == coreapi.LoraRole:
+			loraModelCount += 1
 		}
 	}
 
-	if speculativeDecoding {
-		if len(service.Spec.ModelClaims.Models) != 2 {
-			allErrs = append(allErrs, field.Forbidden(specPath.Child("modelClaims", "models"), "only two models are allowed in speculativeDecoding mode"))
+	if mainModelCount != 1 {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "only one main model is required"))
+	}
+	if draftModelCount > 0 {
+		if len(models) != 2 {
+			allErrs = append(allErrs, field.Forbidden(fldPath, "only two models are allowed in speculativeDecoding mode"))
 		}
-		if mainModelCount != 1 {
-			allErrs = append(allErrs, field.Forbidden(specPath.Child("modelClaims", "models"), "main model is required"))
+		if loraModelCount > 0 {
+			allErrs = append(allErrs, field.Forbidden(fldPath, "lora models are not allowed in speculativeDecoding mode"))
 		}
 	}
 	return allErrs
 }
+
+func validateScaleToZero(config *inferenceapi.ScaleToZero, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if config == nil {
+		return allErrs
+	}
+
+	if config.IdleTimeout != nil && config.IdleTimeout.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("idleTimeout"), config.IdleTimeout.Duration.String(), "idleTimeout must be greater than 0"))
+	}
+	if config.ColdStartTimeout != nil && config.ColdStartTimeout.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("coldStartTimeout"), config.ColdStartTimeout.Duration.String(), "coldStartTimeout must be greater than 0"))
+	}
+	return allErrs
+}
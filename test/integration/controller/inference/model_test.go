@@ -0,0 +1,95 @@
+/*
+Copyright 2025 The InftyAI Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inference
+
+import (
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	coreapi "github.com/inftyai/llmaz/api/core/v1alpha1"
+	modelSource "github.com/inftyai/llmaz/pkg/controller_helper/modelsource"
+	"github.com/inftyai/llmaz/test/util"
+	"github.com/inftyai/llmaz/test/util/wrapper"
+)
+
+var _ = ginkgo.Describe("model controller test", func() {
+	var model *coreapi.OpenModel
+
+	ginkgo.AfterEach(func() {
+		gomega.Expect(k8sClient.Delete(ctx, model)).To(gomega.Succeed())
+	})
+
+	ginkgo.It("model with host path source is ready", func() {
+		model = wrapper.MakeModel("model-host-path").FamilyName("llama3").ModelSourceWithURI("host:///mnt/models/llama3").Obj()
+		gomega.Expect(k8sClient.Create(ctx, model)).To(gomega.Succeed())
+
+		gomega.Eventually(func() bool {
+			newModel := &coreapi.OpenModel{}
+			if err := k8sClient.Get(ctx, types.NamespacedName{Name: model.Name}, newModel); err != nil {
+				return false
+			}
+			return apimeta.IsStatusConditionTrue(newModel.Status.Conditions, coreapi.ModelReady)
+		}, util.IntegrationTimeout, util.Interval).Should(gomega.BeTrue())
+	})
+
+	ginkgo.It("model with host path cache is ready once cached on the nodes", func() {
+		model = wrapper.MakeModel("model-host-path-cache").FamilyName("llama3").ModelSourceWithModelHub("Huggingface").
+			ModelSourceWithModelID("meta-llama/Meta-Llama-3-8B", "", "", nil, nil).Cache(coreapi.HostPathModelCache).Obj()
+		gomega.Expect(k8sClient.Create(ctx, model)).To(gomega.Succeed())
+
+		cachePhase := func() coreapi.ModelCachePhase {
+			newModel := &coreapi.OpenModel{}
+			if err := k8sClient.Get(ctx, types.NamespacedName{Name: model.Name}, newModel); err != nil || newModel.Status.Cache == nil {
+				return ""
+			}
+			return newModel.Status.Cache.Phase
+		}
+		gomega.Eventually(cachePhase, util.IntegrationTimeout, util.Interval).Should(gomega.Equal(coreapi.ModelCachePending))
+
+		node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+			Name:   "node-host-path-cache",
+			Labels: map[string]string{modelSource.ModelPreheatedLabelKey(model.Name): modelSource.SourceHash(model)},
+		}}
+		gomega.Expect(k8sClient.Create(ctx, node)).To(gomega.Succeed())
+		defer func() {
+			gomega.Expect(k8sClient.Delete(ctx, node)).To(gomega.Succeed())
+		}()
+		gomega.Eventually(cachePhase, util.IntegrationTimeout, util.Interval).Should(gomega.Equal(coreapi.ModelCacheReady))
+	})
+
+	ginkgo.It("model with a missing secretRef fails", func() {
+		model = wrapper.MakeModel("model-missing-secret").FamilyName("llama3").ModelSourceWithModelHub("Huggingface").
+			ModelSourceWithModelID("meta-llama/Meta-Llama-3-8B", "", "", nil, nil).ModelSourceSecretRef("missing-secret", nil).Obj()
+		gomega.Expect(k8sClient.Create(ctx, model)).To(gomega.Succeed())
+
+		gomega.Eventually(func() string {
+			newModel := &coreapi.OpenModel{}
+			if err := k8sClient.Get(ctx, types.NamespacedName{Name: model.Name}, newModel); err != nil {
+				return ""
+			}
+			condition := apimeta.FindStatusCondition(newModel.Status.Conditions, coreapi.ModelFailed)
+			if condition == nil || condition.Status != metav1.ConditionTrue {
+				return ""
+			}
+			return condition.Reason
+		}, util.IntegrationTimeout, util.Interval).Should(gomega.Equal("SecretNotFound"))
+	})
+})
@@ -24,6 +24,7 @@ import (
 	"github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
@@ -428,6 +429,81 @@ var _ = ginkgo.Describe("playground controller test", func() {
 				},
 			},
 		}),
+		ginkgo.Entry("Playground with the namespaced model", &testValidatingCase{
+			makePlayground: func() *inferenceapi.Playground {
+				return util.MockASamplePlayground(ns.Name)
+			},
+			updates: []*update{
+				{
+					updateFunc: func(playground *inferenceapi.Playground) {
+						// The namespaced model shadows the OpenModel with the same name.
+						gomega.Expect(k8sClient.Delete(ctx, model)).To(gomega.Succeed())
+						namespacedModel := wrapper.MakeModel(model.Name).FamilyName("llama3").
+							ModelSourceWithURI("s3://bucket/meta-llama-3-8B").
+							InferenceFlavors(*wrapper.MakeFlavor("a100").SetRequest("nvidia.com/gpu", "1").Obj()).
+							NamespacedObj(ns.Name)
+						gomega.Expect(k8sClient.Create(ctx, namespacedModel)).To(gomega.Succeed())
+						gomega.Expect(k8sClient.Create(ctx, playground)).To(gomega.Succeed())
+					},
+					checkFunc: func(ctx context.Context, k8sClient client.Client, playground *inferenceapi.Playground) {
+						validation.ValidatePlayground(ctx, k8sClient, playground)
+						validation.ValidatePlaygroundStatusEqualTo(ctx, k8sClient, playground, inferenceapi.PlaygroundProgressing, "Pending", metav1.ConditionTrue)
+					},
+				},
+				{
+					updateFunc: func(playground *inferenceapi.Playground) {
+						util.UpdateLwsToReady(ctx, k8sClient, playground.Name, playground.Namespace)
+						// Recreate the OpenModel to be deleted after the test.
+						model = util.MockASampleModel()
+						gomega.Expect(k8sClient.Create(ctx, model)).To(gomega.Succeed())
+					},
+					checkFunc: func(ctx context.Context, k8sClient client.Client, playground *inferenceapi.Playground) {
+						validation.ValidatePlayground(ctx, k8sClient, playground)
+						validation.ValidatePlaygroundStatusEqualTo(ctx, k8sClient, playground, inferenceapi.PlaygroundAvailable, "PlaygroundReady", metav1.ConditionTrue)
+					},
+				},
+			},
+		}),
+		ginkgo.Entry("Playground picks up the model metadata discovered after creation", &testValidatingCase{
+			makePlayground: func() *inferenceapi.Playground {
+				return wrapper.MakePlayground("playground", ns.Name).ModelClaim(model.Name).Label(coreapi.ModelNameLabelKey, model.Name).
+					BackendRuntime("sglang").
+					Obj()
+			},
+			updates: []*update{
+				{
+					updateFunc: func(playground *inferenceapi.Playground) {
+						gomega.Expect(k8sClient.Create(ctx, playground)).To(gomega.Succeed())
+					},
+					checkFunc: func(ctx context.Context, k8sClient client.Client, playground *inferenceapi.Playground) {
+						gomega.Eventually(func() error {
+							return checkStartupFailureThreshold(ctx, k8sClient, playground, 30)
+						}, util.IntegrationTimeout, util.Interval).Should(gomega.Succeed())
+					},
+				},
+				{
+					updateFunc: func(playground *inferenceapi.Playground) {
+						// The metadata is set in the Eventually block in case it's reset by the model controller.
+						gomega.Eventually(func() error {
+							newModel := &coreapi.OpenModel{}
+							if err := k8sClient.Get(ctx, types.NamespacedName{Name: model.Name}, newModel); err != nil {
+								return err
+							}
+							if newModel.Status.Metadata == nil || newModel.Status.Metadata.WeightSize == nil {
+								newModel.Status.Metadata = &coreapi.ModelMetadata{WeightSize: ptr.To(resource.MustParse("16Gi"))}
+								if err := k8sClient.Status().Update(ctx, newModel); err != nil {
+									return err
+								}
+							}
+							return checkStartupFailureThreshold(ctx, k8sClient, playground, 34)
+						}, util.IntegrationTimeout, util.Interval).Should(gomega.Succeed())
+					},
+					checkFunc: func(ctx context.Context, k8sClient client.Client, playground *inferenceapi.Playground) {
+						validation.ValidatePlayground(ctx, k8sClient, playground)
+					},
+				},
+			},
+		}),
 		ginkgo.Entry("Playground with backendRuntimeConfig's resource requests greater than limits", &testValidatingCase{
 			makePlayground: func() *inferenceapi.Playground {
 				return wrapper.MakePlayground("playground", ns.Name).ModelClaim(model.Name).Label(coreapi.ModelNameLabelKey, model.Name).
@@ -512,3 +588,19 @@ var _ = ginkgo.Describe("playground controller test", func() {
 		}),
 	)
 })
+
+// checkStartupFailureThreshold checks the failure threshold of the startup probe synthesized for the Playground.
+func checkStartupFailureThreshold(ctx context.Context, k8sClient client.Client, playground *inferenceapi.Playground, want int32) error {
+	service := inferenceapi.Service{}
+	if err := k8sClient.Get(ctx, types.NamespacedName{Name: playground.Name, Namespace: playground.Namespace}, &service); err != nil {
+		return err
+	}
+	probe := service.Spec.WorkloadTemplate.WorkerTemplate.Spec.Containers[0].StartupProbe
+	if probe == nil {
+		return fmt.Errorf("startup probe not found")
+	}
+	if probe.FailureThreshold != want {
+		return fmt.Errorf("unexpected startup probe failure threshold, want %d, got %d", want, probe.FailureThreshold)
+	}
+	return nil
+}
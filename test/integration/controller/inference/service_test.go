@@ -23,9 +23,12 @@ import (
 	"github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	lws "sigs.k8s.io/lws/api/leaderworkerset/v1"
 
 	coreapi "github.com/inftyai/llmaz/api/core/v1alpha1"
 	inferenceapi "github.com/inftyai/llmaz/api/inference/v1alpha1"
@@ -249,5 +252,154 @@ var _ = ginkgo.Describe("inferenceService controller test", func() {
 				},
 			},
 		}),
+		ginkgo.Entry("service reports the flavor, model path and the running image in status", &testValidatingCase{
+			makeService: func() *inferenceapi.Service {
+				return util.MockASampleService(ns.Name)
+			},
+			updates: []*update{
+				{
+					updateFunc: func(service *inferenceapi.Service) {
+						gomega.Expect(k8sClient.Create(ctx, service)).To(gomega.Succeed())
+					},
+					checkFunc: func(ctx context.Context, k8sClient client.Client, service *inferenceapi.Service) {
+						gomega.Eventually(func(g gomega.Gomega) {
+							newService := &inferenceapi.Service{}
+							g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: service.Name, Namespace: service.Namespace}, newService)).To(gomega.Succeed())
+							g.Expect(newService.Status.Flavor).To(gomega.Equal(coreapi.FlavorName("a100")))
+							g.Expect(newService.Status.ModelPath).NotTo(gomega.BeEmpty())
+							// No pods are running yet.
+							g.Expect(newService.Status.Image).To(gomega.BeEmpty())
+						}, util.IntegrationTimeout, util.Interval).Should(gomega.Succeed())
+					},
+				},
+				{
+					updateFunc: func(service *inferenceapi.Service) {
+						// No kubelet runs in envtest, report the running container by hand.
+						pod := &corev1.Pod{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      service.Name + "-0",
+								Namespace: service.Namespace,
+								Labels: map[string]string{
+									lws.SetNameLabelKey:       service.Name,
+									lws.WorkerIndexLabelKey:   "0",
+									coreapi.ModelNameLabelKey: model.Name,
+								},
+							},
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{{Name: "model-runner", Image: "vllm/vllm-openai:latest"}},
+							},
+						}
+						gomega.Expect(k8sClient.Create(ctx, pod)).To(gomega.Succeed())
+						pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+							Name:  "model-runner",
+							Image: "docker.io/vllm/vllm-openai:v0.7.3",
+							State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: metav1.Now()}},
+						}}
+						gomega.Expect(k8sClient.Status().Update(ctx, pod)).To(gomega.Succeed())
+					},
+					checkFunc: func(ctx context.Context, k8sClient client.Client, service *inferenceapi.Service) {
+						gomega.Eventually(func() string {
+							newService := &inferenceapi.Service{}
+							if err := k8sClient.Get(ctx, types.NamespacedName{Name: service.Name, Namespace: service.Namespace}, newService); err != nil {
+								return ""
+							}
+							return newService.Status.Image
+						}, util.IntegrationTimeout, util.Interval).Should(gomega.Equal("docker.io/vllm/vllm-openai:v0.7.3"))
+					},
+				},
+			},
+		}),
+		ginkgo.Entry("service falls back to the next flavor once pods are unschedulable", &testValidatingCase{
+			makeService: func() *inferenceapi.Service {
+				return wrapper.MakeService("service-llama3-8b", ns.Name).
+					ModelClaims([]string{model.Name}, []string{"main"}, "a100", "a10").
+					Annotation(inferenceapi.FlavorFallbackTimeoutAnnoKey, "1s").
+					WorkerTemplate().
+					Obj()
+			},
+			updates: []*update{
+				{
+					updateFunc: func(service *inferenceapi.Service) {
+						gomega.Expect(k8sClient.Create(ctx, service)).To(gomega.Succeed())
+					},
+					checkFunc: func(ctx context.Context, k8sClient client.Client, service *inferenceapi.Service) {
+						validation.ValidateService(ctx, k8sClient, service)
+						gomega.Eventually(func() coreapi.FlavorName {
+							newService := &inferenceapi.Service{}
+							if err := k8sClient.Get(ctx, types.NamespacedName{Name: service.Name, Namespace: service.Namespace}, newService); err != nil {
+								return ""
+							}
+							return newService.Status.Flavor
+						}, util.IntegrationTimeout, util.Interval).Should(gomega.Equal(coreapi.FlavorName("a100")))
+					},
+				},
+				{
+					updateFunc: func(service *inferenceapi.Service) {
+						// No scheduler runs in envtest, mark the pod unschedulable by hand.
+						pod := &corev1.Pod{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      service.Name + "-0",
+								Namespace: service.Namespace,
+								Labels: map[string]string{
+									lws.SetNameLabelKey:       service.Name,
+									coreapi.ModelNameLabelKey: model.Name,
+								},
+							},
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{{Name: "model-runner", Image: "vllm/vllm-openai:latest"}},
+							},
+						}
+						gomega.Expect(k8sClient.Create(ctx, pod)).To(gomega.Succeed())
+						pod.Status.Conditions = []corev1.PodCondition{{
+							Type:               corev1.PodScheduled,
+							Status:             corev1.ConditionFalse,
+							Reason:             corev1.PodReasonUnschedulable,
+							LastTransitionTime: metav1.Now(),
+						}}
+						gomega.Expect(k8sClient.Status().Update(ctx, pod)).To(gomega.Succeed())
+					},
+					checkFunc: func(ctx context.Context, k8sClient client.Client, service *inferenceapi.Service) {
+						gomega.Eventually(func() coreapi.FlavorName {
+							newService := &inferenceapi.Service{}
+							if err := k8sClient.Get(ctx, types.NamespacedName{Name: service.Name, Namespace: service.Namespace}, newService); err != nil {
+								return ""
+							}
+							return newService.Status.Flavor
+						}, util.IntegrationTimeout, util.Interval).Should(gomega.Equal(coreapi.FlavorName("a10")))
+						gomega.Eventually(func() bool {
+							events := &corev1.EventList{}
+							if err := k8sClient.List(ctx, events, client.InNamespace(service.Namespace)); err != nil {
+								return false
+							}
+							for _, event := range events.Items {
+								if event.InvolvedObject.Name == service.Name && event.Reason == "FlavorFallback" {
+									return true
+								}
+							}
+							return false
+						}, util.IntegrationTimeout, util.Interval).Should(gomega.BeTrue())
+					},
+				},
+			},
+		}),
 	)
+	ginkgo.It("should print the flavor and the image of the service", func() {
+		crd := &unstructured.Unstructured{}
+		crd.SetGroupVersionKind(schema.GroupVersionKind{Group: "apiextensions.k8s.io", Version: "v1", Kind: "CustomResourceDefinition"})
+		gomega.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "services.inference.llmaz.io"}, crd)).To(gomega.Succeed())
+
+		versions, _, err := unstructured.NestedSlice(crd.Object, "spec", "versions")
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(versions).NotTo(gomega.BeEmpty())
+		columns, _, err := unstructured.NestedSlice(versions[0].(map[string]interface{}), "additionalPrinterColumns")
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		jsonPaths := map[string]interface{}{}
+		for _, column := range columns {
+			c := column.(map[string]interface{})
+			jsonPaths[c["name"].(string)] = c["jsonPath"]
+		}
+		gomega.Expect(jsonPaths).To(gomega.HaveKeyWithValue("FLAVOR", ".status.flavor"))
+		gomega.Expect(jsonPaths).To(gomega.HaveKeyWithValue("IMAGE", ".status.image"))
+	})
 })
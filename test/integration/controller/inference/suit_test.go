@@ -19,8 +19,11 @@ package inference
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"testing"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -44,6 +47,7 @@ import (
 	inferenceapi "github.com/inftyai/llmaz/api/inference/v1alpha1"
 	corecontroller "github.com/inftyai/llmaz/pkg/controller/core"
 	inferencecontroller "github.com/inftyai/llmaz/pkg/controller/inference"
+	modelSource "github.com/inftyai/llmaz/pkg/controller_helper/modelsource"
 	"github.com/inftyai/llmaz/test/util"
 )
 
@@ -55,6 +59,7 @@ var k8sClient client.Client
 var testEnv *envtest.Environment
 var ctx context.Context
 var cancel context.CancelFunc
+var sourceServer *httptest.Server
 
 func TestAPIs(t *testing.T) {
 	RegisterFailHandler(Fail)
@@ -114,8 +119,23 @@ var _ = BeforeSuite(func() {
 	})
 	Expect(err).ToNot(HaveOccurred())
 
+	// The model sources are validated against the fake server rather than the public endpoints.
+	sourceServer = fakeSourceServer()
+	validator := &modelSource.SourceValidator{
+		HTTPClient:          sourceServer.Client(),
+		HuggingfaceEndpoint: sourceServer.URL,
+		ModelScopeEndpoint:  sourceServer.URL,
+		OllamaEndpoint:      sourceServer.URL,
+		S3Endpoint:          sourceServer.URL,
+		GCSEndpoint:         sourceServer.URL,
+	}
 	modelController := corecontroller.NewModelReconciler(mgr.GetClient(), mgr.GetScheme(), mgr.GetEventRecorderFor("model"))
+	modelController.Validator = validator
 	Expect(modelController.SetupWithManager(mgr)).NotTo(HaveOccurred())
+	namespacedModelController := corecontroller.NewNamespacedModelReconciler(mgr.GetClient(), mgr.GetScheme(), mgr.GetEventRecorderFor("namespaced-model"))
+	namespacedModelController.Validator = validator
+	namespacedModelController.ValidateSources = true
+	Expect(namespacedModelController.SetupWithManager(mgr)).NotTo(HaveOccurred())
 	playgroundController := inferencecontroller.NewPlaygroundReconciler(mgr.GetClient(), mgr.GetScheme(), mgr.GetEventRecorderFor("playground"))
 	Expect(playgroundController.SetupWithManager(mgr)).NotTo(HaveOccurred())
 	serviceController := inferencecontroller.NewServiceReconciler(mgr.GetClient(), mgr.GetScheme(), mgr.GetEventRecorderFor("service"))
@@ -139,7 +159,20 @@ var _ = BeforeSuite(func() {
 
 var _ = AfterSuite(func() {
 	cancel()
+	sourceServer.Close()
 	By("tearing down the test environment")
 	err := testEnv.Stop()
 	Expect(err).NotTo(HaveOccurred())
 })
+
+// fakeSourceServer serves all the Huggingface models, the other sources are not found.
+func fakeSourceServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, "/api/models/") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		modelID := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/models/"), "/revision/")[0]
+		_, _ = fmt.Fprintf(w, `{"id": %q, "sha": "fake-commit", "siblings": [{"rfilename": "qwen2-0_5b-instruct-q5_k_m.gguf"}]}`, modelID)
+	}))
+}
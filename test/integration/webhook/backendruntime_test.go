@@ -21,6 +21,7 @@ import (
 	"github.com/onsi/gomega"
 	"k8s.io/apimachinery/pkg/types"
 
+	coreapi "github.com/inftyai/llmaz/api/core/v1alpha1"
 	inferenceapi "github.com/inftyai/llmaz/api/inference/v1alpha1"
 	"github.com/inftyai/llmaz/test/util"
 	"github.com/inftyai/llmaz/test/util/wrapper"
@@ -85,6 +86,79 @@ var _ = ginkgo.Describe("BackendRuntime default and validation", func() {
 			},
 			createFailed: false,
 		}),
+		ginkgo.Entry("BackendRuntime creation with templated arguments", &testValidatingCase{
+			creationFunc: func() *inferenceapi.BackendRuntime {
+				return util.MockASampleBackendRuntime().Arg("tp", []string{"--model", "{{ .ModelPath }}", "--tensor-parallel-size", "{{ .AcceleratorCount | default 1 }}"}).Obj()
+			},
+			createFailed: false,
+		}),
+		ginkgo.Entry("BackendRuntime creation with malformed arguments", &testValidatingCase{
+			creationFunc: func() *inferenceapi.BackendRuntime {
+				return util.MockASampleBackendRuntime().Arg("malformed", []string{"--model", "{{ .ModelPath "}).Obj()
+			},
+			createFailed: true,
+		}),
+		ginkgo.Entry("BackendRuntime creation with multi-node arguments", &testValidatingCase{
+			creationFunc: func() *inferenceapi.BackendRuntime {
+				return util.MockASampleBackendRuntime().
+					Arg("multi-nodes", []string{"--nnodes", "{{ .Size }}", "--node-rank", "{{ .WorkerIndex }}"}).
+					MultiNode("multi-nodes", inferenceapi.MultiNodeConfig{
+						Worker: &inferenceapi.NodeConfig{Args: []string{"--dist-init-addr", "{{ .LeaderAddress }}:20000"}},
+					}).Obj()
+			},
+			createFailed: false,
+		}),
+		ginkgo.Entry("BackendRuntime creation with malformed multi-node arguments", &testValidatingCase{
+			creationFunc: func() *inferenceapi.BackendRuntime {
+				return util.MockASampleBackendRuntime().
+					Arg("multi-nodes", []string{"--nnodes", "{{ .Size }}"}).
+					MultiNode("multi-nodes", inferenceapi.MultiNodeConfig{
+						Leader: &inferenceapi.NodeConfig{Args: []string{"--node-rank", "{{ .WorkerIndex "}},
+					}).Obj()
+			},
+			createFailed: true,
+		}),
+		ginkgo.Entry("BackendRuntime creation with per-flavor recommended configs", &testValidatingCase{
+			creationFunc: func() *inferenceapi.BackendRuntime {
+				backend := util.MockASampleBackendRuntime().Obj()
+				backend.Spec.RecommendedConfigs = append(backend.Spec.RecommendedConfigs, inferenceapi.RecommendedConfig{
+					Name:    backend.Spec.RecommendedConfigs[0].Name,
+					Flavors: []coreapi.FlavorName{"a100"},
+					Args:    []string{"--max-model-len", "8192"},
+				})
+				return backend
+			},
+			createFailed: false,
+		}),
+		ginkgo.Entry("BackendRuntime creation with overlapped flavors of recommended configs", &testValidatingCase{
+			creationFunc: func() *inferenceapi.BackendRuntime {
+				backend := util.MockASampleBackendRuntime().Obj()
+				backend.Spec.RecommendedConfigs = append(backend.Spec.RecommendedConfigs,
+					inferenceapi.RecommendedConfig{
+						Name:    backend.Spec.RecommendedConfigs[0].Name,
+						Flavors: []coreapi.FlavorName{"a100"},
+						Args:    []string{"--max-model-len", "8192"},
+					},
+					inferenceapi.RecommendedConfig{
+						Name:    backend.Spec.RecommendedConfigs[0].Name,
+						Flavors: []coreapi.FlavorName{"a100", "l4"},
+						Args:    []string{"--max-model-len", "4096"},
+					})
+				return backend
+			},
+			createFailed: true,
+		}),
+		ginkgo.Entry("BackendRuntime creation with duplicated recommended configs", &testValidatingCase{
+			creationFunc: func() *inferenceapi.BackendRuntime {
+				backend := util.MockASampleBackendRuntime().Obj()
+				backend.Spec.RecommendedConfigs = append(backend.Spec.RecommendedConfigs, inferenceapi.RecommendedConfig{
+					Name: backend.Spec.RecommendedConfigs[0].Name,
+					Args: []string{"--max-model-len", "8192"},
+				})
+				return backend
+			},
+			createFailed: true,
+		}),
 		ginkgo.Entry("BackendRuntime creation with no resources", &testValidatingCase{
 			creationFunc: func() *inferenceapi.BackendRuntime {
 				return wrapper.MakeBackendRuntime("vllm").
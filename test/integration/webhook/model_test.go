@@ -146,6 +146,144 @@ var _ = ginkgo.Describe("model default and validation", func() {
 			},
 			failed: true,
 		}),
+		ginkgo.Entry("model creation with https protocol", &testValidatingCase{
+			model: func() *coreapi.OpenModel {
+				return wrapper.MakeModel("qwen2-0--5b").FamilyName("qwen2").ModelSourceWithURI("https://example.com/models/qwen2-0_5b-instruct-q5_k_m.gguf#sha256=3f786850e387550fdab836ed7e6dc881de23001b3f786850e387550fdab836ed").Obj()
+			},
+			failed: false,
+		}),
+		ginkgo.Entry("model creation with https directory URI", &testValidatingCase{
+			model: func() *coreapi.OpenModel {
+				return wrapper.MakeModel("qwen2-0--5b").FamilyName("qwen2").ModelSourceWithURI("https://example.com/models/").Obj()
+			},
+			failed: true,
+		}),
+		ginkgo.Entry("model creation with https wrong checksum", &testValidatingCase{
+			model: func() *coreapi.OpenModel {
+				return wrapper.MakeModel("qwen2-0--5b").FamilyName("qwen2").ModelSourceWithURI("https://example.com/models/qwen2-0_5b-instruct-q5_k_m.gguf#md5=d41d8cd98f00b204e9800998ecf8427e").Obj()
+			},
+			failed: true,
+		}),
+		ginkgo.Entry("model creation with oci protocol", &testValidatingCase{
+			model: func() *coreapi.OpenModel {
+				return wrapper.MakeModel("qwen2-0--5b").FamilyName("qwen2").ModelSourceWithURI("oci://ghcr.io/inftyai/qwen2:0.5b-instruct").Obj()
+			},
+			failed: false,
+		}),
+		ginkgo.Entry("model creation with oci no repository URI", &testValidatingCase{
+			model: func() *coreapi.OpenModel {
+				return wrapper.MakeModel("qwen2-0--5b").FamilyName("qwen2").ModelSourceWithURI("oci://ghcr.io").Obj()
+			},
+			failed: true,
+		}),
+		ginkgo.Entry("model creation with pvc protocol", &testValidatingCase{
+			model: func() *coreapi.OpenModel {
+				return wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithURI("pvc://models/meta-llama-3-8B").Obj()
+			},
+			failed: false,
+		}),
+		ginkgo.Entry("model creation with pvc no path URI", &testValidatingCase{
+			model: func() *coreapi.OpenModel {
+				return wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithURI("pvc://models").Obj()
+			},
+			failed: true,
+		}),
+		ginkgo.Entry("model creation with nfs protocol", &testValidatingCase{
+			model: func() *coreapi.OpenModel {
+				return wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithURI("nfs://10.0.0.1/exports/meta-llama-3-8B").Obj()
+			},
+			failed: false,
+		}),
+		ginkgo.Entry("model creation with azblob protocol", &testValidatingCase{
+			model: func() *coreapi.OpenModel {
+				return wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithURI("azblob://account/models/meta-llama-3-8B").Obj()
+			},
+			failed: false,
+		}),
+		ginkgo.Entry("model creation with azblob no container URI", &testValidatingCase{
+			model: func() *coreapi.OpenModel {
+				return wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithURI("azblob://account").Obj()
+			},
+			failed: true,
+		}),
+		ginkgo.Entry("model creation with abfss protocol", &testValidatingCase{
+			model: func() *coreapi.OpenModel {
+				return wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithURI("abfss://models@account.dfs.core.windows.net/meta-llama-3-8B").Obj()
+			},
+			failed: false,
+		}),
+		ginkgo.Entry("model creation with abfss no account URI", &testValidatingCase{
+			model: func() *coreapi.OpenModel {
+				return wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithURI("abfss://models/meta-llama-3-8B").Obj()
+			},
+			failed: true,
+		}),
+		ginkgo.Entry("model creation with secretRef", &testValidatingCase{
+			model: func() *coreapi.OpenModel {
+				return wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithURI("s3://bucket/meta-llama-3-8B").ModelSourceSecretRef("llama3-secret", map[string]string{"AWS_ACCESS_KEY_ID": "id"}).Obj()
+			},
+			failed: false,
+		}),
+		ginkgo.Entry("model creation with secretRef mapping unknown credentials", &testValidatingCase{
+			model: func() *coreapi.OpenModel {
+				return wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithURI("s3://bucket/meta-llama-3-8B").ModelSourceSecretRef("llama3-secret", map[string]string{"HF_TOKEN": "token"}).Obj()
+			},
+			failed: true,
+		}),
+		ginkgo.Entry("model creation with secretRef of invalid name", &testValidatingCase{
+			model: func() *coreapi.OpenModel {
+				return wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithURI("s3://bucket/meta-llama-3-8B").ModelSourceSecretRef("Llama3_Secret", nil).Obj()
+			},
+			failed: true,
+		}),
+		ginkgo.Entry("model creation with secretRef from pvc", &testValidatingCase{
+			model: func() *coreapi.OpenModel {
+				return wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithURI("pvc://models/meta-llama-3-8B").ModelSourceSecretRef("llama3-secret", nil).Obj()
+			},
+			failed: true,
+		}),
+		ginkgo.Entry("model creation with checksums", &testValidatingCase{
+			model: func() *coreapi.OpenModel {
+				return wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithURI("s3://bucket/meta-llama-3-8B").ModelSourceChecksums(map[string]string{"model.safetensors": "3f786850e387550fdab836ed7e6dc881de23001b3f786850e387550fdab836ed"}).Obj()
+			},
+			failed: false,
+		}),
+		ginkgo.Entry("model creation with checksums of files out of the model", &testValidatingCase{
+			model: func() *coreapi.OpenModel {
+				return wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithURI("s3://bucket/meta-llama-3-8B").ModelSourceChecksums(map[string]string{"../model.safetensors": "3f786850e387550fdab836ed7e6dc881de23001b3f786850e387550fdab836ed"}).Obj()
+			},
+			failed: true,
+		}),
+		ginkgo.Entry("model creation with invalid checksums", &testValidatingCase{
+			model: func() *coreapi.OpenModel {
+				return wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithURI("s3://bucket/meta-llama-3-8B").ModelSourceChecksums(map[string]string{"model.safetensors": "sha256:3f786850e387550fdab836ed7e6dc881de23001b3f786850e387550fdab836ed"}).Obj()
+			},
+			failed: true,
+		}),
+		ginkgo.Entry("model creation with checksums from pvc", &testValidatingCase{
+			model: func() *coreapi.OpenModel {
+				return wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithURI("pvc://models/meta-llama-3-8B").ModelSourceChecksums(map[string]string{"model.safetensors": "3f786850e387550fdab836ed7e6dc881de23001b3f786850e387550fdab836ed"}).Obj()
+			},
+			failed: true,
+		}),
+		ginkgo.Entry("model creation with pinned revision", &testValidatingCase{
+			model: func() *coreapi.OpenModel {
+				return wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithModelHub("Huggingface").ModelSourceWithModelID("meta-llama/Meta-Llama-3-8B", "", "", nil, nil).PinRevision(true).Obj()
+			},
+			failed: false,
+		}),
+		ginkgo.Entry("model creation with pinned revision from ModelScope", &testValidatingCase{
+			model: func() *coreapi.OpenModel {
+				return wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithModelHub("ModelScope").ModelSourceWithModelID("LLM-Research/Meta-Llama-3-8B", "", "", nil, nil).PinRevision(true).Obj()
+			},
+			failed: true,
+		}),
+		ginkgo.Entry("cache the model from pvc", &testValidatingCase{
+			model: func() *coreapi.OpenModel {
+				return wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithURI("pvc://models/meta-llama-3-8B").Cache(coreapi.HostPathModelCache).Obj()
+			},
+			failed: true,
+		}),
 		ginkgo.Entry("unknown modelHub", &testValidatingCase{
 			model: func() *coreapi.OpenModel {
 				return wrapper.MakeModel("llama3-8b").ModelSourceWithModelHub("unknown").FamilyName("llama3").Obj()
@@ -188,5 +326,29 @@ var _ = ginkgo.Describe("model default and validation", func() {
 			},
 			failed: true,
 		}),
+		ginkgo.Entry("cache the model from modelHub", &testValidatingCase{
+			model: func() *coreapi.OpenModel {
+				return wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithModelHub("Huggingface").ModelSourceWithModelID("meta-llama/Meta-Llama-3-8B", "", "", nil, nil).Cache(coreapi.PersistentVolumeModelCache).Obj()
+			},
+			failed: false,
+		}),
+		ginkgo.Entry("cache the model from host path", &testValidatingCase{
+			model: func() *coreapi.OpenModel {
+				return wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithURI("host:///models/meta-llama-3-8B").Cache(coreapi.HostPathModelCache).Obj()
+			},
+			failed: true,
+		}),
+		ginkgo.Entry("preheat the model from object stores", &testValidatingCase{
+			model: func() *coreapi.OpenModel {
+				return wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithURI("s3://bucket/meta-llama-3-8B").Annotation(coreapi.ModelPreheatAnnoKey, "true").Obj()
+			},
+			failed: false,
+		}),
+		ginkgo.Entry("preheat the model cached in the persistent volume", &testValidatingCase{
+			model: func() *coreapi.OpenModel {
+				return wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithURI("s3://bucket/meta-llama-3-8B").Annotation(coreapi.ModelPreheatAnnoKey, "true").Cache(coreapi.PersistentVolumeModelCache).Obj()
+			},
+			failed: true,
+		}),
 	)
 })
@@ -0,0 +1,101 @@
+/*
+Copyright 2025 The InftyAI Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	coreapi "github.com/inftyai/llmaz/api/core/v1alpha1"
+	"github.com/inftyai/llmaz/test/util/wrapper"
+)
+
+var _ = ginkgo.Describe("namespaced model default and validation", func() {
+	// Each test runs in a separate namespace.
+	var ns *corev1.Namespace
+
+	ginkgo.BeforeEach(func() {
+		ns = &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-ns-",
+			},
+		}
+		gomega.Expect(k8sClient.Create(ctx, ns)).To(gomega.Succeed())
+	})
+	ginkgo.AfterEach(func() {
+		gomega.Expect(k8sClient.Delete(ctx, ns)).To(gomega.Succeed())
+	})
+
+	ginkgo.It("should apply the defaults", func() {
+		model := wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithModelID("meta-llama/Meta-Llama-3-8B", "", "", nil, nil).NamespacedObj(ns.Name)
+		gomega.Expect(k8sClient.Create(ctx, model)).To(gomega.Succeed())
+		gomega.Expect(model.Labels).To(gomega.HaveKeyWithValue(coreapi.ModelFamilyNameLabelKey, "llama3"))
+		gomega.Expect(model.Spec.CreatedAt).NotTo(gomega.BeNil())
+	})
+
+	type testValidatingCase struct {
+		model  func(namespace string) *coreapi.Model
+		failed bool
+	}
+	ginkgo.DescribeTable("test validating",
+		func(tc *testValidatingCase) {
+			if tc.failed {
+				gomega.Expect(k8sClient.Create(ctx, tc.model(ns.Name))).To(gomega.HaveOccurred())
+			} else {
+				gomega.Expect(k8sClient.Create(ctx, tc.model(ns.Name))).To(gomega.Succeed())
+			}
+		},
+		ginkgo.Entry("model creation with modelhub", &testValidatingCase{
+			model: func(namespace string) *coreapi.Model {
+				return wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithModelID("meta-llama/Meta-Llama-3-8B", "", "", nil, nil).NamespacedObj(namespace)
+			},
+			failed: false,
+		}),
+		ginkgo.Entry("model creation with invalid source", &testValidatingCase{
+			model: func(namespace string) *coreapi.Model {
+				return wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithURI("s3:/bucket/meta-llama-3-8B").NamespacedObj(namespace)
+			},
+			failed: true,
+		}),
+		ginkgo.Entry("model creation with cache", &testValidatingCase{
+			model: func(namespace string) *coreapi.Model {
+				return wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithURI("s3://bucket/meta-llama-3-8B").Cache(coreapi.HostPathModelCache).NamespacedObj(namespace)
+			},
+			failed: true,
+		}),
+		ginkgo.Entry("model creation with preheat", &testValidatingCase{
+			model: func(namespace string) *coreapi.Model {
+				return wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithURI("s3://bucket/meta-llama-3-8B").Annotation(coreapi.ModelPreheatAnnoKey, "true").NamespacedObj(namespace)
+			},
+			failed: true,
+		}),
+		ginkgo.Entry("model creation with host path of the namespace", &testValidatingCase{
+			model: func(namespace string) *coreapi.Model {
+				return wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithURI("host:///mnt/models/" + namespace + "/meta-llama-3-8B").NamespacedObj(namespace)
+			},
+			failed: false,
+		}),
+		ginkgo.Entry("model creation with host path of other namespaces", &testValidatingCase{
+			model: func(namespace string) *coreapi.Model {
+				return wrapper.MakeModel("llama3-8b").FamilyName("llama3").ModelSourceWithURI("host:///mnt/models/" + namespace + "/../default/meta-llama-3-8B").NamespacedObj(namespace)
+			},
+			failed: true,
+		}),
+	)
+})
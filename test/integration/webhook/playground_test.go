@@ -21,10 +21,13 @@ import (
 	"github.com/onsi/ginkgo/v2"
 	"github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	coreapi "github.com/inftyai/llmaz/api/core/v1alpha1"
 	inferenceapi "github.com/inftyai/llmaz/api/inference/v1alpha1"
+	"github.com/inftyai/llmaz/test/util"
 	"github.com/inftyai/llmaz/test/util/wrapper"
 )
 
@@ -118,6 +121,42 @@ var _ = ginkgo.Describe("Playground default and validation", func() {
 			},
 			failed: true,
 		}),
+		ginkgo.Entry("multiple lora models", &testValidatingCase{
+			playground: func() *inferenceapi.Playground {
+				return wrapper.MakePlayground("playground", ns.Name).Replicas(1).ModelClaims([]string{"llama3-8b", "llama3-sql", "llama3-chat"}, []string{"main", "lora", "lora"}).Obj()
+			},
+			failed: false,
+		}),
+		ginkgo.Entry("lora models in speculativeDecoding mode is not allowed", &testValidatingCase{
+			playground: func() *inferenceapi.Playground {
+				return wrapper.MakePlayground("playground", ns.Name).Replicas(1).ModelClaims([]string{"llama3-8b", "llama3-2b", "llama3-sql"}, []string{"main", "draft", "lora"}).Obj()
+			},
+			failed: true,
+		}),
+		ginkgo.Entry("lora models loaded dynamically", &testValidatingCase{
+			playground: func() *inferenceapi.Playground {
+				return wrapper.MakePlayground("playground", ns.Name).Replicas(1).ModelClaims([]string{"llama3-8b", "llama3-sql"}, []string{"main", "lora"}).DynamicLoRA(true).Obj()
+			},
+			failed: false,
+		}),
+		ginkgo.Entry("dynamic lora loading with modelClaim is not allowed", &testValidatingCase{
+			playground: func() *inferenceapi.Playground {
+				return wrapper.MakePlayground("playground", ns.Name).Replicas(1).ModelClaim("llama3-8b").DynamicLoRA(true).Obj()
+			},
+			failed: true,
+		}),
+		ginkgo.Entry("dynamic lora loading in speculativeDecoding mode is not allowed", &testValidatingCase{
+			playground: func() *inferenceapi.Playground {
+				return wrapper.MakePlayground("playground", ns.Name).Replicas(1).ModelClaims([]string{"llama3-8b", "llama3-2b"}, []string{"main", "draft"}).DynamicLoRA(true).Obj()
+			},
+			failed: true,
+		}),
+		ginkgo.Entry("lora models without main model", &testValidatingCase{
+			playground: func() *inferenceapi.Playground {
+				return wrapper.MakePlayground("playground", ns.Name).Replicas(1).ModelClaims([]string{"llama3-sql", "llama3-chat"}, []string{"lora", "lora"}).Obj()
+			},
+			failed: true,
+		}),
 		ginkgo.Entry("hpa couldn't be nil once elasticConfig is not nil", &testValidatingCase{
 			playground: func() *inferenceapi.Playground {
 				return wrapper.MakePlayground("playground", ns.Name).ModelClaim("llama3-8b").Replicas(1).HPA(nil).Obj()
@@ -142,6 +181,54 @@ var _ = ginkgo.Describe("Playground default and validation", func() {
 			},
 			failed: true,
 		}),
+		ginkgo.Entry("valid scaleToZero policy", &testValidatingCase{
+			playground: func() *inferenceapi.Playground {
+				return wrapper.MakePlayground("playground", ns.Name).ModelClaim("llama3-8b").Replicas(1).ElasticConfig(1, 10).ScaleToZero("10m", "15m").Obj()
+			},
+			failed: false,
+		}),
+		ginkgo.Entry("scaleToZero with zero idleTimeout", &testValidatingCase{
+			playground: func() *inferenceapi.Playground {
+				return wrapper.MakePlayground("playground", ns.Name).ModelClaim("llama3-8b").Replicas(1).ElasticConfig(1, 10).ScaleToZero("0s", "").Obj()
+			},
+			failed: true,
+		}),
+		ginkgo.Entry("scaleToZero with negative coldStartTimeout", &testValidatingCase{
+			playground: func() *inferenceapi.Playground {
+				return wrapper.MakePlayground("playground", ns.Name).ModelClaim("llama3-8b").Replicas(1).ElasticConfig(1, 10).ScaleToZero("", "-1m").Obj()
+			},
+			failed: true,
+		}),
+		ginkgo.Entry("valid warmup schedule", &testValidatingCase{
+			playground: func() *inferenceapi.Playground {
+				return wrapper.MakePlayground("playground", ns.Name).ModelClaim("llama3-8b").Replicas(1).ElasticConfig(1, 10).WarmupSchedule("0 8 * * 1-5", "Asia/Shanghai", "10h", 2).Obj()
+			},
+			failed: false,
+		}),
+		ginkgo.Entry("warmup schedule with invalid cron expression", &testValidatingCase{
+			playground: func() *inferenceapi.Playground {
+				return wrapper.MakePlayground("playground", ns.Name).ModelClaim("llama3-8b").Replicas(1).ElasticConfig(1, 10).WarmupSchedule("0 25 * * *", "", "10h", 2).Obj()
+			},
+			failed: true,
+		}),
+		ginkgo.Entry("warmup schedule with unknown time zone", &testValidatingCase{
+			playground: func() *inferenceapi.Playground {
+				return wrapper.MakePlayground("playground", ns.Name).ModelClaim("llama3-8b").Replicas(1).ElasticConfig(1, 10).WarmupSchedule("0 8 * * *", "Mars/Olympus", "10h", 2).Obj()
+			},
+			failed: true,
+		}),
+		ginkgo.Entry("warmup schedule with zero duration", &testValidatingCase{
+			playground: func() *inferenceapi.Playground {
+				return wrapper.MakePlayground("playground", ns.Name).ModelClaim("llama3-8b").Replicas(1).ElasticConfig(1, 10).WarmupSchedule("0 8 * * *", "", "0s", 2).Obj()
+			},
+			failed: true,
+		}),
+		ginkgo.Entry("warmup replicas greater than maxReplicas", &testValidatingCase{
+			playground: func() *inferenceapi.Playground {
+				return wrapper.MakePlayground("playground", ns.Name).ModelClaim("llama3-8b").Replicas(1).ElasticConfig(1, 10).WarmupSchedule("0 8 * * *", "", "10h", 20).Obj()
+			},
+			failed: true,
+		}),
 	)
 
 	type testDefaultingCase struct {
@@ -186,4 +273,106 @@ var _ = ginkgo.Describe("Playground default and validation", func() {
 			},
 		}),
 	)
+
+	ginkgo.It("should default inferenceFlavors to the flavors fitting the model", func() {
+		model := wrapper.MakeModel("llama3-70b").FamilyName("llama3").ModelSourceWithModelHub("Huggingface").ModelSourceWithModelID("meta-llama/Meta-Llama-3-70B", "", "", nil, nil).
+			InferenceFlavors(
+				*wrapper.MakeFlavor("a10").SetRequest("nvidia.com/gpu", "1").SetParams("ACCELERATOR-MEMORY", "24Gi").Obj(),
+				*wrapper.MakeFlavor("h100x4").SetRequest("nvidia.com/gpu", "4").SetParams("ACCELERATOR-MEMORY", "80Gi").Obj(),
+				*wrapper.MakeFlavor("a100x4").SetRequest("nvidia.com/gpu", "4").SetParams("ACCELERATOR-MEMORY", "40Gi").Obj(),
+				*wrapper.MakeFlavor("unknown").SetRequest("nvidia.com/gpu", "8").Obj(),
+			).Obj()
+		model.Annotations = map[string]string{coreapi.ModelSizeAnnoKey: "140Gi"}
+		gomega.Expect(k8sClient.Create(ctx, model)).To(gomega.Succeed())
+		defer func() {
+			gomega.Expect(k8sClient.Delete(ctx, model)).To(gomega.Succeed())
+		}()
+
+		playground := wrapper.MakePlayground("playground", ns.Name).ModelClaim("llama3-70b").Replicas(1).Obj()
+		gomega.Expect(k8sClient.Create(ctx, playground)).To(gomega.Succeed())
+		gomega.Expect(playground.Spec.ModelClaim.InferenceFlavors).To(gomega.Equal([]coreapi.FlavorName{"a100x4", "h100x4", "unknown"}))
+
+		// Only the creation is defaulted.
+		playground.Spec.ModelClaim.InferenceFlavors = nil
+		gomega.Expect(k8sClient.Update(ctx, playground)).To(gomega.Succeed())
+		gomega.Expect(playground.Spec.ModelClaim.InferenceFlavors).To(gomega.BeEmpty())
+	})
+
+	ginkgo.It("should validate the claim of the model on pvc", func() {
+		model := wrapper.MakeModel("llama3-8b-pvc").FamilyName("llama3").ModelSourceWithURI("pvc://models/meta-llama-3-8B").Obj()
+		gomega.Expect(k8sClient.Create(ctx, model)).To(gomega.Succeed())
+		defer func() {
+			gomega.Expect(k8sClient.Delete(ctx, model)).To(gomega.Succeed())
+		}()
+
+		playground := wrapper.MakePlayground("playground", ns.Name).ModelClaim("llama3-8b-pvc").Replicas(1).Obj()
+		gomega.Expect(k8sClient.Create(ctx, playground)).To(gomega.HaveOccurred())
+
+		claim := &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "models", Namespace: ns.Name},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadOnlyMany},
+				Resources: corev1.VolumeResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("100Gi")},
+				},
+			},
+		}
+		gomega.Expect(k8sClient.Create(ctx, claim)).To(gomega.Succeed())
+		gomega.Eventually(func() error {
+			return k8sClient.Create(ctx, wrapper.MakePlayground("playground", ns.Name).ModelClaim("llama3-8b-pvc").Replicas(1).Obj())
+		}, util.IntegrationTimeout, util.Interval).Should(gomega.Succeed())
+	})
+
+	ginkgo.It("should validate the secret referenced by the model", func() {
+		model := wrapper.MakeModel("llama3-8b-secret").FamilyName("llama3").ModelSourceWithURI("s3://bucket/models/meta-llama-3-8B").
+			ModelSourceSecretRef("llama3-secret", map[string]string{"AWS_ACCESS_KEY_ID": "id"}).Obj()
+		gomega.Expect(k8sClient.Create(ctx, model)).To(gomega.Succeed())
+		defer func() {
+			gomega.Expect(k8sClient.Delete(ctx, model)).To(gomega.Succeed())
+		}()
+
+		playground := wrapper.MakePlayground("playground", ns.Name).ModelClaim("llama3-8b-secret").Replicas(1).Obj()
+		gomega.Expect(k8sClient.Create(ctx, playground)).To(gomega.HaveOccurred())
+
+		// The mapped key is required.
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "llama3-secret", Namespace: ns.Name},
+			Data:       map[string][]byte{"AWS_ACCESS_KEY_ID": []byte("access-key")},
+		}
+		gomega.Expect(k8sClient.Create(ctx, secret)).To(gomega.Succeed())
+		playground = wrapper.MakePlayground("playground", ns.Name).ModelClaim("llama3-8b-secret").Replicas(1).Obj()
+		gomega.Expect(k8sClient.Create(ctx, playground)).To(gomega.HaveOccurred())
+
+		// The credentials not optional are required as well.
+		secret.Data = map[string][]byte{"id": []byte("access-key")}
+		gomega.Expect(k8sClient.Update(ctx, secret)).To(gomega.Succeed())
+		playground = wrapper.MakePlayground("playground", ns.Name).ModelClaim("llama3-8b-secret").Replicas(1).Obj()
+		gomega.Expect(k8sClient.Create(ctx, playground)).To(gomega.HaveOccurred())
+
+		secret.Data = map[string][]byte{"id": []byte("access-key"), "AWS_SECRET_ACCESS_KEY": []byte("secret-key")}
+		gomega.Expect(k8sClient.Update(ctx, secret)).To(gomega.Succeed())
+		gomega.Eventually(func() error {
+			return k8sClient.Create(ctx, wrapper.MakePlayground("playground", ns.Name).ModelClaim("llama3-8b-secret").Replicas(1).Obj())
+		}, util.IntegrationTimeout, util.Interval).Should(gomega.Succeed())
+	})
+
+	ginkgo.It("should resolve the namespaced model first", func() {
+		model := wrapper.MakeModel("llama3-8b-shadowed").FamilyName("llama3").ModelSourceWithURI("s3://bucket/models/meta-llama-3-8B").Obj()
+		gomega.Expect(k8sClient.Create(ctx, model)).To(gomega.Succeed())
+		defer func() {
+			gomega.Expect(k8sClient.Delete(ctx, model)).To(gomega.Succeed())
+		}()
+
+		// The namespaced model requires the claim, which is missing.
+		namespacedModel := wrapper.MakeModel("llama3-8b-shadowed").FamilyName("llama3").ModelSourceWithURI("pvc://models/meta-llama-3-8B").NamespacedObj(ns.Name)
+		gomega.Expect(k8sClient.Create(ctx, namespacedModel)).To(gomega.Succeed())
+		gomega.Eventually(func() error {
+			return k8sClient.Create(ctx, wrapper.MakePlayground("playground", ns.Name).ModelClaim("llama3-8b-shadowed").Replicas(1).Obj(), client.DryRunAll)
+		}, util.IntegrationTimeout, util.Interval).Should(gomega.HaveOccurred())
+
+		gomega.Expect(k8sClient.Delete(ctx, namespacedModel)).To(gomega.Succeed())
+		gomega.Eventually(func() error {
+			return k8sClient.Create(ctx, wrapper.MakePlayground("playground", ns.Name).ModelClaim("llama3-8b-shadowed").Replicas(1).Obj())
+		}, util.IntegrationTimeout, util.Interval).Should(gomega.Succeed())
+	})
 })
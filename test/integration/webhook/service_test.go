@@ -22,6 +22,7 @@ import (
 	"github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	lws "sigs.k8s.io/lws/api/leaderworkerset/v1"
 
 	inferenceapi "github.com/inftyai/llmaz/api/inference/v1alpha1"
@@ -137,5 +138,54 @@ var _ = ginkgo.Describe("service default and validation", func() {
 			},
 			failed: true,
 		}),
+		ginkgo.Entry("multiple lora models", &testValidatingCase{
+			service: func() *inferenceapi.Service {
+				return wrapper.MakeService("service-llama3-8b", ns.Name).
+					ModelClaims([]string{"llama3-8b", "llama3-sql", "llama3-chat"}, []string{"main", "lora", "lora"}).
+					WorkerTemplate().
+					Obj()
+			},
+			failed: false,
+		}),
+		ginkgo.Entry("two main models", &testValidatingCase{
+			service: func() *inferenceapi.Service {
+				return wrapper.MakeService("service-llama3-8b", ns.Name).
+					ModelClaims([]string{"llama3-8b", "llama3-405b"}, []string{"main", "main"}).
+					WorkerTemplate().
+					Obj()
+			},
+			failed: true,
+		}),
+		ginkgo.Entry("scaleToZero with zero idleTimeout", &testValidatingCase{
+			service: func() *inferenceapi.Service {
+				return wrapper.MakeService("service-llama3-8b", ns.Name).
+					ModelClaims([]string{"llama3-8b"}, []string{"main"}).
+					WorkerTemplate().
+					ScaleToZero("0s", "").
+					Obj()
+			},
+			failed: true,
+		}),
 	)
+
+	ginkgo.It("should validate the secret referenced by the model", func() {
+		model := wrapper.MakeModel("llama3-8b-secret").FamilyName("llama3").ModelSourceWithURI("s3://bucket/models/meta-llama-3-8B").
+			ModelSourceSecretRef("llama3-secret", nil).NamespacedObj(ns.Name)
+		gomega.Expect(k8sClient.Create(ctx, model)).To(gomega.Succeed())
+
+		gomega.Eventually(func() error {
+			return k8sClient.Create(ctx, wrapper.MakeService("service-llama3-8b", ns.Name).
+				ModelClaims([]string{"llama3-8b-secret"}, []string{"main"}).WorkerTemplate().Obj(), client.DryRunAll)
+		}, util.IntegrationTimeout, util.Interval).Should(gomega.HaveOccurred())
+
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "llama3-secret", Namespace: ns.Name},
+			Data:       map[string][]byte{"AWS_ACCESS_KEY_ID": []byte("access-key"), "AWS_SECRET_ACCESS_KEY": []byte("secret-key")},
+		}
+		gomega.Expect(k8sClient.Create(ctx, secret)).To(gomega.Succeed())
+		gomega.Eventually(func() error {
+			return k8sClient.Create(ctx, wrapper.MakeService("service-llama3-8b", ns.Name).
+				ModelClaims([]string{"llama3-8b-secret"}, []string{"main"}).WorkerTemplate().Obj())
+		}, util.IntegrationTimeout, util.Interval).Should(gomega.Succeed())
+	})
 })
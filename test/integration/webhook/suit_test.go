@@ -124,6 +124,8 @@ var _ = BeforeSuite(func() {
 
 	err = apiwebhook.SetupOpenModelWebhook(mgr)
 	Expect(err).NotTo(HaveOccurred())
+	err = apiwebhook.SetupModelWebhook(mgr)
+	Expect(err).NotTo(HaveOccurred())
 	err = apiwebhook.SetupPlaygroundWebhook(mgr)
 	Expect(err).NotTo(HaveOccurred())
 	err = apiwebhook.SetupServiceWebhook(mgr)
@@ -68,19 +68,16 @@ func (w *BackendRuntimeWrapper) Lifecycle(lifecycle *corev1.Lifecycle) *BackendR
 }
 
 func (w *BackendRuntimeWrapper) Arg(name string, args []string) *BackendRuntimeWrapper {
-	if w.Spec.RecommendedConfigs == nil {
-		w.Spec.RecommendedConfigs = []inferenceapi.RecommendedConfig{
-			{
-				Name: name,
-			},
-		}
-	}
 	for i, recommend := range w.Spec.RecommendedConfigs {
 		if recommend.Name == name {
 			w.Spec.RecommendedConfigs[i].Args = args
-			break
+			return w
 		}
 	}
+	w.Spec.RecommendedConfigs = append(w.Spec.RecommendedConfigs, inferenceapi.RecommendedConfig{
+		Name: name,
+		Args: args,
+	})
 	return w
 }
 
@@ -156,3 +153,12 @@ func (w *BackendRuntimeWrapper) Probe(name string, probe *corev1.Probe) *Backend
 	}
 	return w
 }
+
+func (w *BackendRuntimeWrapper) MultiNode(name string, config inferenceapi.MultiNodeConfig) *BackendRuntimeWrapper {
+	for i, recommend := range w.Spec.RecommendedConfigs {
+		if recommend.Name == name {
+			w.Spec.RecommendedConfigs[i].MultiNode = &config
+		}
+	}
+	return w
+}
@@ -42,6 +42,17 @@ func (w *ModelWrapper) Obj() *coreapi.OpenModel {
 	return &w.OpenModel
 }
 
+// NamespacedObj returns the model as a namespaced Model in the namespace.
+func (w *ModelWrapper) NamespacedObj(namespace string) *coreapi.Model {
+	model := &coreapi.Model{
+		ObjectMeta: *w.ObjectMeta.DeepCopy(),
+		Spec:       *w.Spec.DeepCopy(),
+		Status:     *w.Status.DeepCopy(),
+	}
+	model.Namespace = namespace
+	return model
+}
+
 func (w *ModelWrapper) FamilyName(name string) *ModelWrapper {
 	w.Spec.FamilyName = coreapi.ModelName(name)
 	return w
@@ -91,6 +102,29 @@ func (w *ModelWrapper) ModelSourceWithURI(uri string) *ModelWrapper {
 	return w
 }
 
+func (w *ModelWrapper) ModelSourceSecretRef(name string, keys map[string]string) *ModelWrapper {
+	w.Spec.Source.SecretRef = &coreapi.SecretReference{Name: name, Keys: keys}
+	return w
+}
+
+func (w *ModelWrapper) ModelSourceChecksums(checksums map[string]string) *ModelWrapper {
+	w.Spec.Source.Checksums = checksums
+	return w
+}
+
+func (w *ModelWrapper) PinRevision(pin bool) *ModelWrapper {
+	if w.Spec.Source.ModelHub == nil {
+		w.Spec.Source.ModelHub = &coreapi.ModelHub{}
+	}
+	w.Spec.Source.ModelHub.PinRevision = &pin
+	return w
+}
+
+func (w *ModelWrapper) PinnedRevision(revision, commit string) *ModelWrapper {
+	w.Status.PinnedRevision = &coreapi.PinnedRevision{Revision: revision, Commit: commit}
+	return w
+}
+
 func (w *ModelWrapper) InferenceFlavors(flavors ...coreapi.Flavor) *ModelWrapper {
 	if w.Spec.InferenceConfig == nil {
 		w.Spec.InferenceConfig = &coreapi.InferenceConfig{}
@@ -99,6 +133,24 @@ func (w *ModelWrapper) InferenceFlavors(flavors ...coreapi.Flavor) *ModelWrapper
 	return w
 }
 
+func (w *ModelWrapper) Annotation(k, v string) *ModelWrapper {
+	if w.Annotations == nil {
+		w.Annotations = map[string]string{}
+	}
+	w.Annotations[k] = v
+	return w
+}
+
+func (w *ModelWrapper) Cache(cacheType coreapi.ModelCacheType) *ModelWrapper {
+	w.Spec.Cache = &coreapi.ModelCache{Type: cacheType}
+	return w
+}
+
+func (w *ModelWrapper) CacheStatus(phase coreapi.ModelCachePhase) *ModelWrapper {
+	w.Status.Cache = &coreapi.ModelCacheStatus{Phase: phase}
+	return w
+}
+
 func (w *ModelWrapper) Label(k, v string) *ModelWrapper {
 	if w.Labels == nil {
 		w.Labels = map[string]string{}
@@ -156,3 +208,8 @@ func (w *FlavorWrapper) SetParams(k, v string) *FlavorWrapper {
 	w.Params[k] = v
 	return w
 }
+
+func (w *FlavorWrapper) Args(args ...string) *FlavorWrapper {
+	w.Flavor.Args = args
+	return w
+}
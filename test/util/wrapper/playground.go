@@ -17,6 +17,8 @@ limitations under the License.
 package wrapper
 
 import (
+	"time"
+
 	coreapi "github.com/inftyai/llmaz/api/core/v1alpha1"
 	inferenceapi "github.com/inftyai/llmaz/api/inference/v1alpha1"
 	v1 "k8s.io/api/core/v1"
@@ -176,6 +178,36 @@ func (w *PlaygroundWrapper) HPA(config *inferenceapi.HPATrigger) *PlaygroundWrap
 	return w
 }
 
+func (w *PlaygroundWrapper) ScaleToZero(idleTimeout, coldStartTimeout string) *PlaygroundWrapper {
+	if w.Spec.ElasticConfig == nil {
+		w.Spec.ElasticConfig = &inferenceapi.ElasticConfig{}
+	}
+	w.Spec.ElasticConfig.ScaleToZero = makeScaleToZero(idleTimeout, coldStartTimeout)
+	return w
+}
+
+func (w *PlaygroundWrapper) DynamicLoRA(enabled bool) *PlaygroundWrapper {
+	w.Spec.LoRAConfig = &inferenceapi.LoRAConfig{DynamicLoading: ptr.To(enabled)}
+	return w
+}
+
+func (w *PlaygroundWrapper) WarmupSchedule(schedule, timeZone, duration string, replicas int32) *PlaygroundWrapper {
+	if w.Spec.ElasticConfig == nil {
+		w.Spec.ElasticConfig = &inferenceapi.ElasticConfig{}
+	}
+	d, _ := time.ParseDuration(duration)
+	warmup := inferenceapi.WarmupSchedule{
+		Schedule: schedule,
+		Duration: metav1.Duration{Duration: d},
+		Replicas: ptr.To[int32](replicas),
+	}
+	if timeZone != "" {
+		warmup.TimeZone = ptr.To(timeZone)
+	}
+	w.Spec.ElasticConfig.WarmupSchedules = append(w.Spec.ElasticConfig.WarmupSchedules, warmup)
+	return w
+}
+
 func (w *PlaygroundWrapper) SharedMemorySize(v string) *PlaygroundWrapper {
 	if w.Spec.BackendRuntimeConfig == nil {
 		w = w.BackendRuntime("vllm")
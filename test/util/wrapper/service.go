@@ -17,6 +17,8 @@ limitations under the License.
 package wrapper
 
 import (
+	"time"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -112,7 +114,34 @@ func (w *ServiceWrapper) Replicas(replicas int32) *ServiceWrapper {
 	return w
 }
 
+func (w *ServiceWrapper) ScaleToZero(idleTimeout, coldStartTimeout string) *ServiceWrapper {
+	w.Spec.ScaleToZero = makeScaleToZero(idleTimeout, coldStartTimeout)
+	return w
+}
+
+func (w *ServiceWrapper) Annotation(k, v string) *ServiceWrapper {
+	if w.Annotations == nil {
+		w.Annotations = map[string]string{}
+	}
+	w.Annotations[k] = v
+	return w
+}
+
 func (w *ServiceWrapper) RestartPolicy(policy string) *ServiceWrapper {
 	w.Spec.WorkloadTemplate.RestartPolicy = lws.RestartPolicyType(policy)
 	return w
 }
+
+// makeScaleToZero builds the scaleToZero policy, empty durations will be left unset.
+func makeScaleToZero(idleTimeout, coldStartTimeout string) *inferenceapi.ScaleToZero {
+	config := &inferenceapi.ScaleToZero{Enabled: ptr.To(true)}
+	if idleTimeout != "" {
+		duration, _ := time.ParseDuration(idleTimeout)
+		config.IdleTimeout = &metav1.Duration{Duration: duration}
+	}
+	if coldStartTimeout != "" {
+		duration, _ := time.ParseDuration(coldStartTimeout)
+		config.ColdStartTimeout = &metav1.Duration{Duration: duration}
+	}
+	return config
+}